@@ -0,0 +1,205 @@
+// MIT License
+//
+// Copyright (c) 2021 Theodor Amariucai and EASE Lab
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package benchmarking
+
+import (
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"math"
+	"sort"
+	"stellar/benchmarking/networking/benchhttp"
+	"stellar/setup"
+	"time"
+)
+
+const (
+	calibrationPrecision        = 0.05
+	calibrationSamples          = 3
+	calibrationProbeLimit       = 30
+	initialCalibrationIncrement = int64(1e6)
+)
+
+// CalibrationConfiguration returns the configuration deploying one calibration function per runtime and memory size
+// used by the given configuration. Each calibration function busy-spins on the desired service times of all
+// sub-experiments sharing its runtime and memory size.
+func CalibrationConfiguration(config setup.Configuration) setup.Configuration {
+	calibrationConfig := config
+	calibrationConfig.SubExperiments = nil
+	calibrationConfig.Comparisons = nil
+
+	calibrationIndices := make(map[string]int)
+	for _, subExperiment := range config.SubExperiments {
+		key := fmt.Sprintf("%s-%dmb", subExperiment.Runtime, subExperiment.FunctionMemoryMB)
+		index, ok := calibrationIndices[key]
+		if !ok {
+			index = len(calibrationConfig.SubExperiments)
+			calibrationIndices[key] = index
+			calibrationConfig.SubExperiments = append(calibrationConfig.SubExperiments, setup.SubExperiment{
+				ID:                      index,
+				Title:                   fmt.Sprintf("calibration-%s", key),
+				Bursts:                  1,
+				BurstSizes:              []int{1},
+				SpinMode:                setup.SpinModeIncrement,
+				IATType:                 subExperiment.IATType,
+				PackageType:             subExperiment.PackageType,
+				PackagePattern:          subExperiment.PackagePattern,
+				Parallelism:             1,
+				Visualization:           subExperiment.Visualization,
+				Function:                subExperiment.Function,
+				FunctionMemoryMB:        subExperiment.FunctionMemoryMB,
+				DataTransferChainLength: 1,
+				Handler:                 subExperiment.Handler,
+				Runtime:                 subExperiment.Runtime,
+				PlotOptions:             subExperiment.PlotOptions,
+			})
+		}
+
+		calibration := &calibrationConfig.SubExperiments[index]
+		for _, serviceTime := range subExperiment.DesiredServiceTimes {
+			if !containsServiceTime(calibration.DesiredServiceTimes, serviceTime) {
+				calibration.DesiredServiceTimes = append(calibration.DesiredServiceTimes, serviceTime)
+			}
+		}
+	}
+	return calibrationConfig
+}
+
+// CalibrateBusySpin binary-searches, on each deployed calibration function, the increment limit for which the function
+// reports each desired service time as its execution duration, and stores the results in the cache.
+func CalibrateBusySpin(calibrationConfig setup.Configuration, cache *setup.BusySpinCache) {
+	for _, calibration := range calibrationConfig.SubExperiments {
+		measure := func(incrementLimit int64) float64 {
+			return measureExecutionDurationMs(calibrationConfig.Provider, calibration, incrementLimit)
+		}
+		log.Infof("[%s] Warming up the calibration function, baseline execution duration is %.2fms.",
+			calibration.Title, measure(0))
+
+		for _, serviceTime := range calibration.DesiredServiceTimes {
+			desiredDuration, err := time.ParseDuration(serviceTime)
+			if err != nil {
+				log.Fatalf("Could not parse desired function run duration %s from configuration file.", serviceTime)
+			}
+			desiredMs := float64(desiredDuration.Microseconds()) / 1000
+
+			incrementLimit, measuredMs := int64(0), 0.
+			if desiredMs > 0 {
+				var converged bool
+				incrementLimit, measuredMs, converged = searchIncrement(desiredMs, measure)
+				if !converged {
+					log.Warnf("[%s] Increment %d (measured %.2fms) is not within %v%% of desired duration %v.",
+						calibration.Title, incrementLimit, measuredMs, calibrationPrecision*100, serviceTime)
+				}
+			}
+
+			log.Infof("[%s] Using increment %d (measured %.2fms) for desired %v.",
+				calibration.Title, incrementLimit, measuredMs, serviceTime)
+			cache.Store(setup.BusySpinCalibration{
+				Provider:         calibrationConfig.Provider,
+				Runtime:          calibration.Runtime,
+				FunctionMemoryMB: calibration.FunctionMemoryMB,
+				ServiceTime:      serviceTime,
+				IncrementLimit:   incrementLimit,
+				MeasuredMs:       measuredMs,
+				CalibratedAt:     time.Now().UTC(),
+			})
+		}
+	}
+}
+
+// searchIncrement doubles the increment limit until the measured duration exceeds the desired one, then binary-searches
+// the increment limit in between. It returns the closest increment limit found, its measured duration and whether
+// it is within the calibration precision.
+func searchIncrement(desiredMs float64, measure func(incrementLimit int64) float64) (int64, float64, bool) {
+	withinPrecision := func(measuredMs float64) bool {
+		return math.Abs(measuredMs-desiredMs) <= calibrationPrecision*desiredMs
+	}
+
+	lower, upper := int64(0), initialCalibrationIncrement
+	upperMs := measure(upper)
+	for probes := 1; upperMs < desiredMs; probes++ {
+		if probes == calibrationProbeLimit {
+			log.Fatalf("Increment %d still ran faster (%.2fms) than desired %.2fms after %d tries!", upper, upperMs, desiredMs, probes)
+		}
+		lower, upper = upper, upper*2
+		upperMs = measure(upper)
+	}
+
+	best, bestMs := upper, upperMs
+	for probes := 0; probes < calibrationProbeLimit && !withinPrecision(bestMs); probes++ {
+		middle := lower + (upper-lower)/2
+		if middle == lower {
+			break
+		}
+
+		middleMs := measure(middle)
+		if math.Abs(middleMs-desiredMs) < math.Abs(bestMs-desiredMs) {
+			best, bestMs = middle, middleMs
+		}
+		if middleMs < desiredMs {
+			lower = middle
+		} else {
+			upper = middle
+		}
+	}
+	return best, bestMs, withinPrecision(bestMs)
+}
+
+// measureExecutionDurationMs returns the median execution duration reported by the calibration function when
+// busy-spinning up to the given increment limit
+func measureExecutionDurationMs(provider string, calibration setup.SubExperiment, incrementLimit int64) float64 {
+	busySpin := setup.BusySpin{Mode: setup.SpinModeIncrement, IncrementLimit: incrementLimit}
+
+	var samples []float64
+	for attempts := 0; len(samples) < calibrationSamples; attempts++ {
+		if attempts == 2*calibrationSamples {
+			log.Fatalf("[%s] Too many calibration requests failed.", calibration.Title)
+		}
+
+		request := benchhttp.CreateRequest(provider, 0, calibration.Endpoints[0], busySpin, false,
+			calibration.Routes[0], setup.WorkloadProfile{})
+		ok, respBody, _, _ := benchhttp.ExecuteRequest(*request)
+		if !ok {
+			log.Errorf("[%s] Calibration request failed, retrying...", calibration.Title)
+			continue
+		}
+
+		response := benchhttp.ExtractProducerConsumerResponse(respBody)
+		if response.ServerTiming == nil {
+			log.Fatalf("[%s] Function %s does not report its execution duration, it cannot be calibrated.",
+				calibration.Title, calibration.Function)
+		}
+		samples = append(samples, response.ServerTiming.ExecutionDurationMs)
+	}
+
+	sort.Float64s(samples)
+	return samples[len(samples)/2]
+}
+
+func containsServiceTime(serviceTimes []string, serviceTime string) bool {
+	for _, existing := range serviceTimes {
+		if existing == serviceTime {
+			return true
+		}
+	}
+	return false
+}
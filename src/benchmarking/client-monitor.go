@@ -0,0 +1,402 @@
+// MIT License
+//
+// Copyright (c) 2021 Theodor Amariucai and EASE Lab
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package benchmarking
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"math"
+	"os"
+	"path/filepath"
+	"runtime"
+	"stellar/setup"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// clientStatsInterval is the period at which the client resources are sampled
+	clientStatsInterval = time.Second
+	// clockTicksPerSecond is the USER_HZ unit of the process times in /proc/self/stat, 100 on all common Linux builds
+	clockTicksPerSecond = 100
+	bytesPerMegabyte    = 1024 * 1024
+
+	// Past these limits, the client may have been the bottleneck of a sub-experiment
+	saturatedCPUPercent         = 90.
+	saturatedMemoryPercent      = 90.
+	saturatedOpenFilesPercent   = 90.
+	saturatedTimeWaitSocketsMax = 20000.
+)
+
+var clientStatsHeader = []string{
+	"Timestamp",
+	"CPU Utilization (%)",
+	"Process CPU Utilization (%)",
+	"Memory Used (%)",
+	"Process RSS (MB)",
+	"Goroutines",
+	"Open File Descriptors",
+	"TIME_WAIT Sockets",
+	"Received (MB/s)",
+	"Transmitted (MB/s)",
+}
+
+// ClientSample is a snapshot of the resources used on the client host. Utilizations and throughputs are averaged
+// since the previous sample.
+type ClientSample struct {
+	Timestamp              time.Time
+	CPUPercent             float64
+	ProcessCPUPercent      float64
+	MemoryPercent          float64
+	ProcessRSSMB           float64
+	Goroutines             int
+	OpenFiles              int
+	OpenFilesPercent       float64
+	TimeWaitSockets        int
+	ReceivedMBPerSecond    float64
+	TransmittedMBPerSecond float64
+}
+
+// clientCounters are the cumulative counters from which utilizations and throughputs are derived
+type clientCounters struct {
+	at            time.Time
+	cpuBusyTicks  uint64
+	cpuTotalTicks uint64
+	processTicks  uint64
+	receivedBytes uint64
+	sentBytes     uint64
+}
+
+// ClientMonitor samples the resources of the client host in the background and writes them to a CSV file.
+type ClientMonitor struct {
+	mu       sync.Mutex
+	samples  []ClientSample
+	file     *os.File
+	writer   *csv.Writer
+	previous clientCounters
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// StartClientMonitor starts sampling the client resources every interval into the CSV file at the given path.
+// Resources which cannot be read from /proc (e.g., outside Linux) are recorded as zero.
+func StartClientMonitor(path string, interval time.Duration) *ClientMonitor {
+	log.Infof("Creating client statistics file at `%s`", path)
+	file, err := os.Create(path)
+	if err != nil {
+		log.Fatalf("Could not create client statistics file: %s", err.Error())
+	}
+
+	monitor := &ClientMonitor{
+		file:     file,
+		writer:   csv.NewWriter(file),
+		previous: readClientCounters(),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	if err := monitor.writer.Write(clientStatsHeader); err != nil {
+		log.Fatal(err)
+	}
+
+	go monitor.run(interval)
+	return monitor
+}
+
+// Stop takes a last sample and closes the client statistics file.
+func (m *ClientMonitor) Stop() {
+	close(m.stop)
+	<-m.done
+	m.sample()
+
+	m.writer.Flush()
+	if err := m.file.Close(); err != nil {
+		log.Errorf("Could not close client statistics file: %s", err.Error())
+	}
+}
+
+func (m *ClientMonitor) run(interval time.Duration) {
+	defer close(m.done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.stop:
+			return
+		case <-ticker.C:
+			m.sample()
+		}
+	}
+}
+
+func (m *ClientMonitor) sample() {
+	counters := readClientCounters()
+	elapsedSeconds := counters.at.Sub(m.previous.at).Seconds()
+	openFiles, openFilesLimit := countOpenFiles()
+
+	sample := ClientSample{
+		Timestamp:       counters.at,
+		CPUPercent:      ratioPercent(counters.cpuBusyTicks-m.previous.cpuBusyTicks, counters.cpuTotalTicks-m.previous.cpuTotalTicks),
+		MemoryPercent:   readMemoryPercent(),
+		ProcessRSSMB:    readProcessRSSMB(),
+		Goroutines:      runtime.NumGoroutine(),
+		OpenFiles:       openFiles,
+		TimeWaitSockets: countTimeWaitSockets(),
+	}
+	if openFilesLimit > 0 {
+		sample.OpenFilesPercent = 100 * float64(openFiles) / float64(openFilesLimit)
+	}
+	if elapsedSeconds > 0 {
+		processSeconds := float64(counters.processTicks-m.previous.processTicks) / clockTicksPerSecond
+		sample.ProcessCPUPercent = 100 * processSeconds / elapsedSeconds / float64(runtime.NumCPU())
+		sample.ReceivedMBPerSecond = float64(counters.receivedBytes-m.previous.receivedBytes) / bytesPerMegabyte / elapsedSeconds
+		sample.TransmittedMBPerSecond = float64(counters.sentBytes-m.previous.sentBytes) / bytesPerMegabyte / elapsedSeconds
+	}
+	m.previous = counters
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.samples = append(m.samples, sample)
+	if err := m.writer.Write([]string{
+		sample.Timestamp.Format(time.RFC3339Nano),
+		formatClientStat(sample.CPUPercent),
+		formatClientStat(sample.ProcessCPUPercent),
+		formatClientStat(sample.MemoryPercent),
+		formatClientStat(sample.ProcessRSSMB),
+		strconv.Itoa(sample.Goroutines),
+		strconv.Itoa(sample.OpenFiles),
+		strconv.Itoa(sample.TimeWaitSockets),
+		formatClientStat(sample.ReceivedMBPerSecond),
+		formatClientStat(sample.TransmittedMBPerSecond),
+	}); err != nil {
+		log.Fatal(err)
+	}
+	m.writer.Flush()
+}
+
+// Samples returns the samples taken between the given times.
+func (m *ClientMonitor) Samples(from time.Time, to time.Time) []ClientSample {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var samples []ClientSample
+	for _, sample := range m.samples {
+		if !sample.Timestamp.Before(from) && !sample.Timestamp.After(to) {
+			samples = append(samples, sample)
+		}
+	}
+	return samples
+}
+
+// ClientSaturation is a client resource whose peak during a sub-experiment may have exceeded what the host sustains
+type ClientSaturation struct {
+	Metric    string
+	Peak      float64
+	Threshold float64
+}
+
+// DetectSaturation returns the client resources which were saturated in the given samples.
+func DetectSaturation(samples []ClientSample) []ClientSaturation {
+	peaks := map[string]float64{}
+	for _, sample := range samples {
+		peaks["CPU Utilization (%)"] = math.Max(peaks["CPU Utilization (%)"], sample.CPUPercent)
+		peaks["Memory Used (%)"] = math.Max(peaks["Memory Used (%)"], sample.MemoryPercent)
+		peaks["Open File Descriptors (% of limit)"] = math.Max(peaks["Open File Descriptors (% of limit)"], sample.OpenFilesPercent)
+		peaks["TIME_WAIT Sockets"] = math.Max(peaks["TIME_WAIT Sockets"], float64(sample.TimeWaitSockets))
+	}
+
+	var saturations []ClientSaturation
+	for _, limit := range []ClientSaturation{
+		{Metric: "CPU Utilization (%)", Threshold: saturatedCPUPercent},
+		{Metric: "Memory Used (%)", Threshold: saturatedMemoryPercent},
+		{Metric: "Open File Descriptors (% of limit)", Threshold: saturatedOpenFilesPercent},
+		{Metric: "TIME_WAIT Sockets", Threshold: saturatedTimeWaitSocketsMax},
+	} {
+		if peaks[limit.Metric] >= limit.Threshold {
+			limit.Peak = peaks[limit.Metric]
+			saturations = append(saturations, limit)
+		}
+	}
+	return saturations
+}
+
+// markClientSaturation warns about the client resources saturated while the sub-experiment ran, and lists them in
+// the client-saturation.csv file of the sub-experiment (with a header only if none were).
+func markClientSaturation(monitor *ClientMonitor, experiment setup.SubExperiment, directoryPath string, from time.Time, to time.Time) {
+	// Each sample averages the interval before it, so the one following the end still covers the sub-experiment
+	saturations := DetectSaturation(monitor.Samples(from, to.Add(clientStatsInterval)))
+
+	rows := [][]string{{"Metric", "Peak", "Threshold"}}
+	for _, saturation := range saturations {
+		log.Warnf("[sub-experiment %d] Client may have been saturated, distorting the results: peak %s of %.1f exceeds %.1f.",
+			experiment.ID, saturation.Metric, saturation.Peak, saturation.Threshold)
+		rows = append(rows, []string{saturation.Metric, formatClientStat(saturation.Peak), formatClientStat(saturation.Threshold)})
+	}
+
+	saturationFile, err := os.Create(filepath.Join(directoryPath, "client-saturation.csv"))
+	if err != nil {
+		log.Fatalf("[sub-experiment %d] Could not create client saturation file: %s", experiment.ID, err.Error())
+	}
+	defer saturationFile.Close()
+
+	if err := csv.NewWriter(saturationFile).WriteAll(rows); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func readClientCounters() clientCounters {
+	counters := clientCounters{at: time.Now()}
+
+	// The first line of /proc/stat sums the CPU time of all cores: user nice system idle iowait irq softirq steal ...
+	if fields := procFields("/proc/stat", "cpu"); len(fields) >= 5 {
+		for index, field := range fields {
+			ticks, _ := strconv.ParseUint(field, 10, 64)
+			counters.cpuTotalTicks += ticks
+			if index != 3 && index != 4 {
+				counters.cpuBusyTicks += ticks
+			}
+		}
+	}
+
+	// Fields after the parenthesized command name start at the state, utime and stime are the 12th and 13th ones
+	if stat, err := os.ReadFile("/proc/self/stat"); err == nil {
+		fields := strings.Fields(string(stat[strings.LastIndexByte(string(stat), ')')+1:]))
+		if len(fields) > 12 {
+			userTicks, _ := strconv.ParseUint(fields[11], 10, 64)
+			systemTicks, _ := strconv.ParseUint(fields[12], 10, 64)
+			counters.processTicks = userTicks + systemTicks
+		}
+	}
+
+	forEachProcLine("/proc/net/dev", func(line string) {
+		iface, statistics, found := strings.Cut(line, ":")
+		if !found || strings.TrimSpace(iface) == "lo" {
+			return
+		}
+		fields := strings.Fields(statistics)
+		if len(fields) > 8 {
+			received, _ := strconv.ParseUint(fields[0], 10, 64)
+			sent, _ := strconv.ParseUint(fields[8], 10, 64)
+			counters.receivedBytes += received
+			counters.sentBytes += sent
+		}
+	})
+
+	return counters
+}
+
+func readMemoryPercent() float64 {
+	memoryKB := map[string]float64{}
+	forEachProcLine("/proc/meminfo", func(line string) {
+		fields := strings.Fields(line)
+		if len(fields) >= 2 {
+			memoryKB[strings.TrimSuffix(fields[0], ":")], _ = strconv.ParseFloat(fields[1], 64)
+		}
+	})
+
+	if memoryKB["MemTotal"] == 0 {
+		return 0
+	}
+	return 100 * (memoryKB["MemTotal"] - memoryKB["MemAvailable"]) / memoryKB["MemTotal"]
+}
+
+func readProcessRSSMB() float64 {
+	statm, err := os.ReadFile("/proc/self/statm")
+	if err != nil {
+		return 0
+	}
+	fields := strings.Fields(string(statm))
+	if len(fields) < 2 {
+		return 0
+	}
+	residentPages, _ := strconv.ParseFloat(fields[1], 64)
+	return residentPages * float64(os.Getpagesize()) / bytesPerMegabyte
+}
+
+// countOpenFiles returns the number of file descriptors of the process and their soft limit.
+func countOpenFiles() (int, int) {
+	descriptors, err := os.ReadDir("/proc/self/fd")
+	if err != nil {
+		return 0, 0
+	}
+
+	limit := 0
+	forEachProcLine("/proc/self/limits", func(line string) {
+		if fields := strings.Fields(strings.TrimPrefix(line, "Max open files")); strings.HasPrefix(line, "Max open files") && len(fields) > 0 {
+			limit, _ = strconv.Atoi(fields[0])
+		}
+	})
+	return len(descriptors), limit
+}
+
+// countTimeWaitSockets counts the TCP sockets of the host in the TIME_WAIT state (06 in the st column).
+func countTimeWaitSockets() int {
+	count := 0
+	for _, path := range []string{"/proc/net/tcp", "/proc/net/tcp6"} {
+		forEachProcLine(path, func(line string) {
+			if fields := strings.Fields(line); len(fields) > 3 && fields[3] == "06" {
+				count++
+			}
+		})
+	}
+	return count
+}
+
+// procFields returns the fields following the given key on the first line of the file starting with it.
+func procFields(path string, key string) []string {
+	var fields []string
+	forEachProcLine(path, func(line string) {
+		if lineFields := strings.Fields(line); fields == nil && len(lineFields) > 0 && lineFields[0] == key {
+			fields = lineFields[1:]
+		}
+	})
+	return fields
+}
+
+func forEachProcLine(path string, handle func(line string)) {
+	file, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		handle(scanner.Text())
+	}
+}
+
+func ratioPercent(part uint64, total uint64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return 100 * float64(part) / float64(total)
+}
+
+func formatClientStat(value float64) string {
+	return fmt.Sprintf("%.2f", value)
+}
@@ -0,0 +1,190 @@
+// MIT License
+//
+// Copyright (c) 2021 Theodor Amariucai and EASE Lab
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package benchmarking
+
+import (
+	"context"
+	log "github.com/sirupsen/logrus"
+	"net/rpc"
+	"stellar/benchmarking/writers"
+	"stellar/setup"
+	"stellar/tracing"
+	"sync"
+	"time"
+)
+
+const (
+	// clockSyncSamples is the number of clock requests made to each worker, the one with the lowest round trip is kept
+	clockSyncSamples = 8
+	// burstStartLead leaves the workers time to receive a burst before its synchronised start
+	burstStartLead = 250 * time.Millisecond
+)
+
+// WorkerPool splits each burst across remote workers and merges the records they send back.
+type WorkerPool struct {
+	workers []*remoteWorker
+}
+
+type remoteWorker struct {
+	address string
+	client  *rpc.Client
+	// clockOffset is the worker clock minus the coordinator clock
+	clockOffset time.Duration
+}
+
+// ConnectWorkers connects to the workers listening on the given addresses and estimates their clock offsets.
+func ConnectWorkers(addresses []string) *WorkerPool {
+	pool := &WorkerPool{}
+	for _, address := range addresses {
+		client, err := rpc.Dial("tcp", address)
+		if err != nil {
+			log.Fatalf("Could not connect to worker at %s: %s", address, err.Error())
+		}
+
+		worker := &remoteWorker{address: address, client: client}
+		roundTrip := worker.synchronizeClock()
+		log.Infof("Connected to worker at %s, clock offset is %v (round trip %v).", address, worker.clockOffset, roundTrip)
+		pool.workers = append(pool.workers, worker)
+	}
+	return pool
+}
+
+// Close disconnects from the workers.
+func (p *WorkerPool) Close() {
+	for _, worker := range p.workers {
+		worker.client.Close()
+	}
+}
+
+// synchronizeClock estimates the clock offset of the worker, assuming the clock was read halfway through the round
+// trip, and returns the round trip of the sample used.
+func (w *remoteWorker) synchronizeClock() time.Duration {
+	bestRoundTrip := time.Duration(-1)
+	for i := 0; i < clockSyncSamples; i++ {
+		var reply ClockReply
+		sentAt := time.Now()
+		if err := w.client.Call(WorkerServiceName+".Clock", ClockArgs{}, &reply); err != nil {
+			log.Fatalf("Could not read the clock of worker at %s: %s", w.address, err.Error())
+		}
+		roundTrip := time.Since(sentAt)
+
+		if bestRoundTrip < 0 || roundTrip < bestRoundTrip {
+			bestRoundTrip = roundTrip
+			w.clockOffset = time.Unix(0, reply.UnixNano).Sub(sentAt.Add(roundTrip / 2))
+		}
+	}
+	return bestRoundTrip
+}
+
+// sendBurst has every worker send its share of the burst at the same time, then writes the records as the workers
+// stream them back, with their timestamps moved to the coordinator clock.
+func (p *WorkerPool) sendBurst(ctx context.Context, provider string, config setup.SubExperiment, burstID int, requests int, gatewayEndpoint setup.EndpointInfo,
+	busySpin setup.BusySpin, latenciesWriter *writers.RTTLatencyWriter, dataTransfersWriter *writers.DataTransferWriter, route string, errorCount *ErrorCount) {
+	startAt := time.Now().Add(burstStartLead)
+	traceContext := tracing.Inject(ctx)
+	shares := splitRequests(requests, len(p.workers))
+
+	var workersWaitGroup sync.WaitGroup
+	for index, worker := range p.workers {
+		if shares[index] == 0 {
+			continue
+		}
+
+		workersWaitGroup.Add(1)
+		go func(worker *remoteWorker, share int) {
+			defer workersWaitGroup.Done()
+
+			args := BurstArgs{
+				Provider:           provider,
+				SubExperimentID:    config.ID,
+				BurstID:            burstID,
+				Requests:           share,
+				StartAtUnixNano:    startAt.Add(worker.clockOffset).UnixNano(),
+				Endpoint:           gatewayEndpoint,
+				Route:              route,
+				BusySpin:           busySpin,
+				PayloadLengthBytes: config.PayloadLengthBytes,
+				StorageTransfer:    config.StorageTransfer,
+				Workload:           config.Workload,
+				TraceContext:       traceContext,
+			}
+			var burst BurstReply
+			if err := worker.client.Call(WorkerServiceName+".Burst", args, &burst); err != nil {
+				log.Errorf("[sub-experiment %d] Worker at %s could not send burst %d: %s", config.ID, worker.address, burstID, err.Error())
+				recordWorkerFailures(config.ID, map[string]int{failureWorker: share}, errorCount)
+				return
+			}
+
+			reported := 0
+			for {
+				var reply RecordsReply
+				if err := worker.client.Call(WorkerServiceName+".Records", RecordsArgs{WorkerBurstID: burst.WorkerBurstID}, &reply); err != nil {
+					log.Errorf("[sub-experiment %d] Lost the remaining records of burst %d from worker at %s: %s", config.ID, burstID, worker.address, err.Error())
+					recordWorkerFailures(config.ID, map[string]int{failureWorker: share - reported}, errorCount)
+					return
+				}
+
+				for _, count := range reply.Failures {
+					reported += count
+				}
+				recordWorkerFailures(config.ID, reply.Failures, errorCount)
+
+				for _, record := range reply.Records {
+					record.SentAt = record.SentAt.Add(-worker.clockOffset)
+					record.ReceivedAt = record.ReceivedAt.Add(-worker.clockOffset)
+					writeRequestRecord(record, latenciesWriter, dataTransfersWriter)
+					runProgress.requestCompleted(config.ID, record.latencyMs())
+				}
+				reported += len(reply.Records)
+
+				if reply.Done {
+					return
+				}
+			}
+		}(worker, shares[index])
+	}
+
+	workersWaitGroup.Wait()
+}
+
+// recordWorkerFailures counts the failed requests reported by or lost with a worker
+func recordWorkerFailures(experimentID int, failures map[string]int, errorCount *ErrorCount) {
+	for failure, count := range failures {
+		for i := 0; i < count; i++ {
+			errorCount.Increment()
+		}
+		runProgress.requestFailed(experimentID, failure, count)
+	}
+}
+
+// splitRequests spreads the requests of a burst as evenly as possible across the workers.
+func splitRequests(requests int, workers int) []int {
+	shares := make([]int, workers)
+	for i := range shares {
+		shares[i] = requests / workers
+		if i < requests%workers {
+			shares[i]++
+		}
+	}
+	return shares
+}
@@ -0,0 +1,171 @@
+// MIT License
+//
+// Copyright (c) 2021 Theodor Amariucai and EASE Lab
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package benchmarking
+
+import (
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// latencyBucketsMs are the upper bounds of the request latency histograms exposed to Prometheus
+var latencyBucketsMs = []float64{5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000, 30000, 60000}
+
+var metricLabelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// ServeMetrics exposes the progress of the run as Prometheus metrics on the /metrics path of the given address.
+func (p *Progress) ServeMetrics(address string) *http.Server {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		log.Fatalf("Could not listen for metrics scrapes on %s: %s", address, err.Error())
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", p)
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			log.Errorf("Metrics endpoint stopped: %s", err.Error())
+		}
+	}()
+
+	log.Infof("Serving Prometheus metrics at http://%s/metrics", listener.Addr())
+	return server
+}
+
+// ServeHTTP writes the metrics in the Prometheus text exposition format.
+func (p *Progress) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	p.WriteMetrics(w)
+}
+
+// WriteMetrics writes the metrics in the Prometheus text exposition format.
+func (p *Progress) WriteMetrics(writer io.Writer) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := time.Now()
+
+	writeMetricFamily(writer, "stellar_requests_planned", "gauge", "Requests the sub-experiment will send.",
+		p.perSubExperiment(func(s *subExperimentProgress) float64 { return float64(s.plannedRequests) }))
+	writeMetricFamily(writer, "stellar_requests_sent_total", "counter", "Requests sent.",
+		p.perSubExperiment(func(s *subExperimentProgress) float64 { return float64(s.sentRequests) }))
+	writeMetricFamily(writer, "stellar_requests_completed_total", "counter", "Requests which succeeded.",
+		p.perSubExperiment(func(s *subExperimentProgress) float64 { return float64(s.completedRequests) }))
+
+	var errorSamples []metricSample
+	for _, subExperiment := range p.subExperiments {
+		classes := make([]string, 0, len(subExperiment.failures))
+		for class := range subExperiment.failures {
+			classes = append(classes, class)
+		}
+		sort.Strings(classes)
+		for _, class := range classes {
+			errorSamples = append(errorSamples, metricSample{
+				labels: subExperiment.labels() + `,class="` + metricLabelEscaper.Replace(class) + `"`,
+				value:  float64(subExperiment.failures[class]),
+			})
+		}
+	}
+	writeMetricFamily(writer, "stellar_request_errors_total", "counter", "Requests which failed, by class of failure.", errorSamples)
+
+	var latencySamples []metricSample
+	for _, subExperiment := range p.subExperiments {
+		latencySamples = append(latencySamples, subExperiment.latencyHistogram()...)
+	}
+	writeMetricFamily(writer, "stellar_request_latency_milliseconds", "histogram", "Client latency of the requests which succeeded.", latencySamples)
+
+	writeMetricFamily(writer, "stellar_bursts_planned", "gauge", "Bursts the sub-experiment will send.",
+		p.perSubExperiment(func(s *subExperimentProgress) float64 { return float64(s.plannedBursts) }))
+	writeMetricFamily(writer, "stellar_bursts_completed_total", "counter", "Bursts whose responses were all received.",
+		p.perSubExperiment(func(s *subExperimentProgress) float64 { return float64(s.completedBursts) }))
+	writeMetricFamily(writer, "stellar_current_burst", "gauge", "ID of the last burst started, -1 before the first one.",
+		p.perSubExperiment(func(s *subExperimentProgress) float64 { return float64(s.currentBurst) }))
+	writeMetricFamily(writer, "stellar_eta_seconds", "gauge", "Estimated time until the sub-experiment finishes.",
+		p.perSubExperiment(func(s *subExperimentProgress) float64 { return s.eta(now).Seconds() }))
+	writeMetricFamily(writer, "stellar_run_eta_seconds", "gauge", "Estimated time until the run finishes.",
+		[]metricSample{{value: p.runETA(now).Seconds()}})
+}
+
+type metricSample struct {
+	suffix string
+	labels string
+	value  float64
+}
+
+func (p *Progress) perSubExperiment(value func(subExperiment *subExperimentProgress) float64) []metricSample {
+	samples := make([]metricSample, 0, len(p.subExperiments))
+	for _, subExperiment := range p.subExperiments {
+		samples = append(samples, metricSample{labels: subExperiment.labels(), value: value(subExperiment)})
+	}
+	return samples
+}
+
+func (s *subExperimentProgress) labels() string {
+	return fmt.Sprintf(`sub_experiment="%d",title="%s"`, s.id, metricLabelEscaper.Replace(s.title))
+}
+
+// latencyHistogram returns the cumulative buckets, sum and count of the latencies received so far.
+func (s *subExperimentProgress) latencyHistogram() []metricSample {
+	bucketCounts := make([]int, len(latencyBucketsMs))
+	sum := 0.
+	for _, latency := range s.latenciesMs {
+		sum += latency
+		for index, upperBound := range latencyBucketsMs {
+			if latency <= upperBound {
+				bucketCounts[index]++
+			}
+		}
+	}
+
+	samples := make([]metricSample, 0, len(latencyBucketsMs)+3)
+	for index, upperBound := range latencyBucketsMs {
+		samples = append(samples, metricSample{
+			suffix: "_bucket",
+			labels: s.labels() + `,le="` + strconv.FormatFloat(upperBound, 'f', -1, 64) + `"`,
+			value:  float64(bucketCounts[index]),
+		})
+	}
+	return append(samples,
+		metricSample{suffix: "_bucket", labels: s.labels() + `,le="+Inf"`, value: float64(len(s.latenciesMs))},
+		metricSample{suffix: "_sum", labels: s.labels(), value: sum},
+		metricSample{suffix: "_count", labels: s.labels(), value: float64(len(s.latenciesMs))},
+	)
+}
+
+func writeMetricFamily(writer io.Writer, name string, metricType string, help string, samples []metricSample) {
+	fmt.Fprintf(writer, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, metricType)
+	for _, sample := range samples {
+		value := strconv.FormatFloat(sample.value, 'f', -1, 64)
+		if sample.labels == "" {
+			fmt.Fprintf(writer, "%s%s %s\n", name, sample.suffix, value)
+		} else {
+			fmt.Fprintf(writer, "%s%s{%s} %s\n", name, sample.suffix, sample.labels, value)
+		}
+	}
+}
@@ -28,8 +28,10 @@ import (
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 	"stellar/benchmarking/networking/benchgrpc/proto_gen"
 	"stellar/setup"
+	"stellar/tracing"
 	"time"
 )
 
@@ -37,16 +39,18 @@ const (
 	timeout = 3 * time.Minute // 15 minutes are not practical for vHive
 )
 
-// ExecuteRequest will send a gRPC request and return the timestamp chain (if any).
-func ExecuteRequest(payloadLengthBytes int, gatewayEndpoint setup.EndpointInfo, incrementLimit int64, storageTransfer bool) (string, time.Time, time.Time) {
+// ExecuteRequest will send a gRPC request and return the timestamp chain (if any) and the JSON-encoded server timing
+// envelope (if any). The trace context of ctx, if any, is propagated in the request metadata.
+func ExecuteRequest(ctx context.Context, payloadLengthBytes int, gatewayEndpoint setup.EndpointInfo, incrementLimit int64, storageTransfer bool) (string, string, time.Time, time.Time) {
 	conn, err := grpc.Dial(gatewayEndpoint.ID, grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
 	if err != nil {
 		log.Fatalf("Did not connect: %v", err)
 	}
 	defer conn.Close()
 
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
+	ctx = metadata.NewOutgoingContext(ctx, metadata.New(tracing.Inject(ctx)))
 
 	client := proto_gen.NewProducerConsumerClient(conn)
 
@@ -70,5 +74,5 @@ func ExecuteRequest(payloadLengthBytes int, gatewayEndpoint setup.EndpointInfo,
 	}
 	reqReceivedTime := time.Now()
 
-	return reply.GetTimestampChain(), reqSentTime, reqReceivedTime
+	return reply.GetTimestampChain(), reply.GetServerTiming(), reqSentTime, reqReceivedTime
 }
@@ -29,12 +29,11 @@ import (
 	"stellar/setup"
 	"stellar/setup/deployment/connection/amazon"
 	"strings"
-	"time"
 )
 
 // CreateRequest will generate an HTTP request according to the provider passed in the sub-experiment
 // configuration object.
-func CreateRequest(provider string, payloadLengthBytes int, gatewayEndpoint setup.EndpointInfo, assignedFunctionIncrementLimit int64, storageTransfer bool, route string) *http.Request {
+func CreateRequest(provider string, payloadLengthBytes int, gatewayEndpoint setup.EndpointInfo, busySpin setup.BusySpin, storageTransfer bool, route string, workload setup.WorkloadProfile) *http.Request {
 	var request *http.Request
 
 	switch provider {
@@ -44,11 +43,10 @@ func CreateRequest(provider string, payloadLengthBytes int, gatewayEndpoint setu
 			fmt.Sprintf("%s.execute-api.%s.amazonaws.com", gatewayEndpoint.ID, amazon.AWSRegion),
 		)
 
-		appendProducerConsumerParameters(provider, request, payloadLengthBytes, assignedFunctionIncrementLimit,
-			gatewayEndpoint, storageTransfer, route)
+		appendProducerConsumerParameters(provider, request, payloadLengthBytes, busySpin,
+			gatewayEndpoint, storageTransfer, route, workload)
 
-		_, err := amazon.AWSSingletonInstance.RequestSigner.Sign(request, nil, "execute-api", amazon.AWSRegion, time.Now())
-		if err != nil {
+		if err := amazon.AWSSingletonInstance.SignRequest(request); err != nil {
 			log.Fatalf("Could not sign AWS HTTP request: %s", err.Error())
 		}
 	case "azure":
@@ -59,19 +57,19 @@ func CreateRequest(provider string, payloadLengthBytes int, gatewayEndpoint setu
 			fmt.Sprintf("%s.azurewebsites.net", gatewayEndpoint.ID),
 		)
 
-		appendProducerConsumerParameters(provider, request, payloadLengthBytes, assignedFunctionIncrementLimit, gatewayEndpoint, storageTransfer, route)
+		appendProducerConsumerParameters(provider, request, payloadLengthBytes, busySpin, gatewayEndpoint, storageTransfer, route, workload)
 	case "google":
 		// Example Google Cloud Functions URL:
 		// us-west2-zinc-hour-315914.cloudfunctions.net/hellopy-1
 		request = createGeneralHttpsRequest(http.MethodGet, strings.Split(gatewayEndpoint.ID, "/")[0])
 
-		appendProducerConsumerParameters(provider, request, payloadLengthBytes, assignedFunctionIncrementLimit, gatewayEndpoint, storageTransfer, route)
+		appendProducerConsumerParameters(provider, request, payloadLengthBytes, busySpin, gatewayEndpoint, storageTransfer, route, workload)
 	case "cloudflare":
 		fallthrough
 	case "gcr":
 		request = createGeneralHttpsRequest(http.MethodGet, gatewayEndpoint.ID)
 
-		appendProducerConsumerParameters(provider, request, payloadLengthBytes, assignedFunctionIncrementLimit, gatewayEndpoint, storageTransfer, route)
+		appendProducerConsumerParameters(provider, request, payloadLengthBytes, busySpin, gatewayEndpoint, storageTransfer, route, workload)
 	case "aliyun":
 		// Example Alibaba Cloud URL:
 		// http://5cfeb440ed6d4ad69ae29d8408aa606e-ap-southeast-1.alicloudapi.com/foo
@@ -80,7 +78,7 @@ func CreateRequest(provider string, payloadLengthBytes int, gatewayEndpoint setu
 			fmt.Sprintf("%s-us-west-1.alicloudapi.com", gatewayEndpoint.ID),
 		)
 
-		appendProducerConsumerParameters(provider, request, payloadLengthBytes, assignedFunctionIncrementLimit, gatewayEndpoint, storageTransfer, route)
+		appendProducerConsumerParameters(provider, request, payloadLengthBytes, busySpin, gatewayEndpoint, storageTransfer, route, workload)
 	default:
 		return createGeneralHttpsRequest(http.MethodGet, provider)
 	}
@@ -43,8 +43,8 @@ func TestCreateAWSRequest(t *testing.T) {
 
 	connection.Initialize("aws", "", "../../../setup/deployment/raw-code/functions/producer-consumer/api-template.json")
 
-	randomAssignedIncrement := int64(1482911482)
-	req := CreateRequest("aws", randomPayloadLength, randomEndpoint, randomAssignedIncrement, false, "route1")
+	randomBusySpin := setup.BusySpin{Mode: setup.SpinModeIncrement, IncrementLimit: 1482911482}
+	req := CreateRequest("aws", randomPayloadLength, randomEndpoint, randomBusySpin, false, "route1", setup.WorkloadProfile{})
 
 	expectedHostname := fmt.Sprintf("%s.execute-api.%s.amazonaws.com", randomEndpoint.ID, amazon.AWSRegion)
 	require.Equal(t, expectedHostname, req.Host)
@@ -55,8 +55,8 @@ func TestCreateAWSRequest(t *testing.T) {
 
 func TestCreateExternalRequest(t *testing.T) {
 	randomPayloadLength := 7
-	randomAssignedIncrement := int64(1482911482)
-	req := CreateRequest("www.google.com", randomPayloadLength, setup.EndpointInfo{}, randomAssignedIncrement, false, "route1")
+	randomBusySpin := setup.BusySpin{Mode: setup.SpinModeIncrement, IncrementLimit: 1482911482}
+	req := CreateRequest("www.google.com", randomPayloadLength, setup.EndpointInfo{}, randomBusySpin, false, "route1", setup.WorkloadProfile{})
 
 	require.Equal(t, "www.google.com", req.Host)
 	require.Equal(t, "www.google.com", req.URL.Host)
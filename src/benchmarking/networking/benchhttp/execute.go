@@ -24,10 +24,13 @@ package benchhttp
 
 import (
 	"context"
+	"errors"
 	log "github.com/sirupsen/logrus"
 	"io"
+	"net"
 	"net/http"
 	"net/http/httptrace"
+	"stellar/tracing"
 	"time"
 )
 
@@ -35,31 +38,64 @@ const (
 	timeout = 15 * time.Minute
 )
 
+// Classes of failed requests, as reported by ExecuteClassifiedRequest
+const (
+	FailureTimeout    = "timeout"
+	FailureConnection = "connection"
+	FailureRead       = "read"
+	FailureStatus4xx  = "status_4xx"
+	FailureStatus5xx  = "status_5xx"
+	FailureStatus     = "status_other"
+)
+
 // ExecuteRequest will send an HTTP request, check its status code and return the response body.
 func ExecuteRequest(req http.Request) (bool, []byte, time.Time, time.Time) {
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
-	ok := true
+	failure, bodyBytes, reqSentTime, reqReceivedTime := ExecuteClassifiedRequest(context.Background(), req)
+	return failure == "", bodyBytes, reqSentTime, reqReceivedTime
+}
+
+// ExecuteClassifiedRequest is ExecuteRequest, returning the class of the failure instead of whether the request
+// succeeded. The class is empty for successful requests. The trace context of ctx, if any, is propagated in the
+// request headers.
+func ExecuteClassifiedRequest(ctx context.Context, req http.Request) (string, []byte, time.Time, time.Time) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	failure := ""
 	defer cancel()
 
+	req.Header = req.Header.Clone()
+	for key, value := range tracing.Inject(ctx) {
+		req.Header.Set(key, value)
+	}
+
 	err, resp, reqSentTime, reqReceivedTime := sendTimedRequest(ctx, req)
 	if err != nil {
-		ok = false
 		log.Errorf("Could not send HTTP request: %s", err.Error())
-		return ok, nil, reqSentTime, reqReceivedTime
+		var netErr net.Error
+		if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+			return FailureTimeout, nil, reqSentTime, reqReceivedTime
+		}
+		return FailureConnection, nil, reqSentTime, reqReceivedTime
 	}
 
 	bodyBytes, err := io.ReadAll(resp.Body)
 	if err != nil {
-		ok = false
+		failure = FailureRead
 		log.Errorf("Could not read HTTP response body: %s", err.Error())
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		ok = false
+		switch {
+		case resp.StatusCode >= 400 && resp.StatusCode < 500:
+			failure = FailureStatus4xx
+		case resp.StatusCode >= 500:
+			failure = FailureStatus5xx
+		default:
+			failure = FailureStatus
+		}
 		log.Errorf("Response from %s had status %s: %s", req.URL.Hostname(), resp.Status, string(bodyBytes))
 	}
 
-	return ok, bodyBytes, reqSentTime, reqReceivedTime
+	return failure, bodyBytes, reqSentTime, reqReceivedTime
 }
 
 // https://stackoverflow.com/questions/48077098/getting-ttfb-time-to-first-byte-value-in-golang/48077762#48077762
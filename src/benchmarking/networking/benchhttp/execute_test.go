@@ -30,8 +30,8 @@ import (
 
 func TestExecuteExternalHTTPRequest(t *testing.T) {
 	randomPayloadLength := 7
-	randomAssignedIncrement := int64(1482911482)
-	req := CreateRequest("www.google.com", randomPayloadLength, setup.EndpointInfo{}, randomAssignedIncrement, false, "route1")
+	randomBusySpin := setup.BusySpin{Mode: setup.SpinModeIncrement, IncrementLimit: 1482911482}
+	req := CreateRequest("www.google.com", randomPayloadLength, setup.EndpointInfo{}, randomBusySpin, false, "route1", setup.WorkloadProfile{})
 
 	_, respBytes, reqSentTime, reqReceivedTime := ExecuteRequest(*req)
 	require.Equal(t, true, respBytes != nil)
@@ -27,8 +27,10 @@ import (
 	"fmt"
 	log "github.com/sirupsen/logrus"
 	"net/http"
+	"stellar/benchmarking/writers"
 	"stellar/setup"
 	"stellar/setup/deployment/connection/amazon"
+	"strconv"
 	"strings"
 )
 
@@ -36,6 +38,39 @@ import (
 type ProducerConsumerResponse struct {
 	RequestID      string   `json:"RequestID"`
 	TimestampChain []string `json:"TimestampChain"`
+	// ServerTiming is nil if the function does not return the standard timing envelope
+	ServerTiming *ServerTiming `json:"ServerTiming"`
+}
+
+// ServerTiming is the standard server-side timing envelope returned by the bundled benchmark functions
+type ServerTiming struct {
+	HandlerStartMs      float64 `json:"HandlerStartMs"`
+	HandlerEndMs        float64 `json:"HandlerEndMs"`
+	ExecutionDurationMs float64 `json:"ExecutionDurationMs"`
+	// SpinDurationMs is the service time the function achieved while busy-spinning
+	SpinDurationMs float64 `json:"SpinDurationMs"`
+	// InitDurationMs is the time the instance spent in global initialization, reported by cold invocations only
+	InitDurationMs float64 `json:"InitDurationMs"`
+	ColdStart      bool    `json:"ColdStart"`
+	Runtime        string  `json:"Runtime"`
+	MemoryLimitMB  int     `json:"MemoryLimitMB"`
+}
+
+// Fields returns the timing envelope as latency record fields, in the order of writers.ServerTimingHeader
+func (t *ServerTiming) Fields() []string {
+	if t == nil {
+		return make([]string, len(writers.ServerTimingHeader))
+	}
+	return []string{
+		strconv.FormatFloat(t.InitDurationMs, 'f', -1, 64),
+		strconv.FormatFloat(t.ExecutionDurationMs, 'f', -1, 64),
+		strconv.FormatFloat(t.SpinDurationMs, 'f', -1, 64),
+		strconv.FormatBool(t.ColdStart),
+		strconv.FormatFloat(t.HandlerStartMs, 'f', -1, 64),
+		strconv.FormatFloat(t.HandlerEndMs, 'f', -1, 64),
+		t.Runtime,
+		strconv.Itoa(t.MemoryLimitMB),
+	}
 }
 
 // ExtractProducerConsumerResponse will process an HTTP response body coming from a producer-consumer function
@@ -51,17 +86,35 @@ func ExtractProducerConsumerResponse(respBody []byte) ProducerConsumerResponse {
 	return response
 }
 
+// ExtractServerTiming decodes a JSON-encoded timing envelope, such as the one of gRPC replies, nil if there is none
+func ExtractServerTiming(encoded string) *ServerTiming {
+	if encoded == "" {
+		return nil
+	}
+
+	var timing ServerTiming
+	if err := json.Unmarshal([]byte(encoded), &timing); err != nil {
+		log.Errorf("ExtractServerTiming encountered an error: %v", err)
+		return nil
+	}
+	return &timing
+}
+
 func appendProducerConsumerParameters(provider string, request *http.Request, payloadLengthBytes int,
-	assignedFunctionIncrementLimit int64, gatewayEndpoint setup.EndpointInfo, storageTransfer bool, route string) *http.Request {
+	busySpin setup.BusySpin, gatewayEndpoint setup.EndpointInfo, storageTransfer bool, route string,
+	workload setup.WorkloadProfile) *http.Request {
 	const (
 		googleBucket = "stellar-us-west-2"
 	)
 
-	request.URL.RawQuery = fmt.Sprintf("IncrementLimit=%d&PayloadLengthBytes=%d&DataTransferChainIDs=%v",
-		assignedFunctionIncrementLimit,
+	request.URL.RawQuery = fmt.Sprintf("%s&PayloadLengthBytes=%d&DataTransferChainIDs=%v",
+		busySpin.QueryParameters().Encode(),
 		payloadLengthBytes,
 		gatewayEndpoint.DataTransferChainIDs,
 	)
+	if workloadParameters := workload.QueryParameters(); len(workloadParameters) > 0 {
+		request.URL.RawQuery += "&" + workloadParameters.Encode()
+	}
 
 	switch provider {
 	case "aws":
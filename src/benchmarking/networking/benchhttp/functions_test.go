@@ -0,0 +1,53 @@
+// MIT License
+//
+// Copyright (c) 2020 Theodor Amariucai and EASE Lab
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package benchhttp
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestExtractProducerConsumerResponseServerTiming(t *testing.T) {
+	response := ExtractProducerConsumerResponse([]byte(`{"RequestID": "id", "TimestampChain": ["1700000000000"],
+		"ServerTiming": {"HandlerStartMs": 1700000000000, "HandlerEndMs": 1700000000012.5, "ExecutionDurationMs": 12.5,
+		"SpinDurationMs": 10.2, "InitDurationMs": 250, "ColdStart": true, "Runtime": "python3.9.18", "MemoryLimitMB": 128}}`))
+
+	require.Equal(t, "id", response.RequestID)
+	require.Equal(t, []string{"250", "12.5", "10.2", "true", "1700000000000", "1700000000012.5", "python3.9.18", "128"},
+		response.ServerTiming.Fields())
+}
+
+func TestExtractProducerConsumerResponseWithoutServerTiming(t *testing.T) {
+	response := ExtractProducerConsumerResponse([]byte(`{"RequestID": "id", "TimestampChain": []}`))
+
+	require.Nil(t, response.ServerTiming)
+	require.Equal(t, []string{"", "", "", "", "", "", "", ""}, response.ServerTiming.Fields())
+}
+
+func TestExtractServerTiming(t *testing.T) {
+	timing := ExtractServerTiming(`{"ExecutionDurationMs": 3, "ColdStart": false, "Runtime": "go1.19", "MemoryLimitMB": 256}`)
+
+	require.Equal(t, []string{"0", "3", "0", "false", "0", "0", "go1.19", "256"}, timing.Fields())
+	require.Nil(t, ExtractServerTiming(""))
+	require.Nil(t, ExtractServerTiming("not json"))
+}
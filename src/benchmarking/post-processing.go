@@ -26,10 +26,13 @@ import (
 	"encoding/csv"
 	"fmt"
 	"github.com/go-gota/gota/dataframe"
+	"github.com/go-gota/gota/series"
 	log "github.com/sirupsen/logrus"
 	"gonum.org/v1/gonum/stat"
 	"io"
+	"math"
 	"os"
+	"path/filepath"
 	"sort"
 	"strconv"
 	"time"
@@ -37,7 +40,7 @@ import (
 	"stellar/setup"
 )
 
-func postProcessing(experiment setup.SubExperiment, latenciesFile *os.File, burstDeltas []time.Duration, experimentDirectoryPath string, statisticsFile *os.File) {
+func postProcessing(experiment setup.SubExperiment, pricing setup.Pricing, latenciesFile *os.File, burstDeltas []time.Duration, experimentDirectoryPath string, statisticsFile *os.File) ([]float64, setup.CostEstimate) {
 	log.Debugf("[sub-experiment %d] Reading written latencies from file %s", experiment.ID, latenciesFile.Name())
 
 	_, err := latenciesFile.Seek(0, io.SeekStart)
@@ -52,19 +55,194 @@ func postProcessing(experiment setup.SubExperiment, latenciesFile *os.File, burs
 
 	visualization.Generate(experiment, burstDeltas, latenciesDF, sortedLatencies, experimentDirectoryPath)
 	generateStatistics(statisticsFile, experiment.ID, sortedLatencies)
+	postProcessServerTimings(experiment, latenciesDF, experimentDirectoryPath)
+	recordedCost := postProcessCost(experiment, pricing, latenciesDF, burstDeltas, experimentDirectoryPath)
+	postProcessImageSize(experiment, experimentDirectoryPath)
+
+	return sortedLatencies, recordedCost
+}
+
+// postProcessCost estimates the cost of the sub-experiment from the recorded durations and writes its breakdown next
+// to the other results. Requests are billed for their server-side execution and init durations, or for their client
+// latency if the function does not report them.
+func postProcessCost(experiment setup.SubExperiment, pricing setup.Pricing, latenciesDF dataframe.DataFrame, burstDeltas []time.Duration, experimentDirectoryPath string) setup.CostEstimate {
+	billedDurations := latenciesDF.Col("Client Latency (ms)").Float()
+	if executionDurations := latenciesDF.Col("Execution Duration (ms)"); executionDurations.Err == nil {
+		initDurations := latenciesDF.Col("Init Duration (ms)").Float()
+		for i, executionDuration := range executionDurations.Float() {
+			if math.IsNaN(executionDuration) || executionDuration < 0 {
+				continue
+			}
+			billedDurations[i] = executionDuration
+			if !math.IsNaN(initDurations[i]) {
+				billedDurations[i] += initDurations[i]
+			}
+		}
+	}
+
+	var runDuration time.Duration
+	for _, burstDelta := range burstDeltas {
+		runDuration += burstDelta
+	}
+	recordedCost := experiment.RecordedCost(pricing, billedDurations, runDuration)
+	plannedCost := experiment.PlannedCost(pricing)
+	log.Infof("[sub-experiment %d] Estimated cost from the recorded durations is $%.4f (estimated $%.4f before the run).",
+		experiment.ID, recordedCost.TotalUSD(), plannedCost.TotalUSD())
+
+	costPath := filepath.Join(experimentDirectoryPath, "cost.csv")
+	costFile, err := os.Create(costPath)
+	if err != nil {
+		log.Errorf("[sub-experiment %d] Could not create cost file: %s", experiment.ID, err.Error())
+		return recordedCost
+	}
+	defer costFile.Close()
+
+	costWriter := csv.NewWriter(costFile)
+	rows := [][]string{
+		{"Component", "Usage", "Unit", "Recorded Cost (USD)", "Planned Cost (USD)"},
+		costRow("Requests", recordedCost.Usage.Requests, "requests", recordedCost.RequestsUSD, plannedCost.RequestsUSD),
+		costRow("Compute", recordedCost.Usage.GBSeconds, "GB-s", recordedCost.ComputeUSD, plannedCost.ComputeUSD),
+		costRow("Gateway", recordedCost.Usage.GatewayRequests, "requests", recordedCost.GatewayUSD, plannedCost.GatewayUSD),
+		costRow("Transfer", recordedCost.Usage.TransferGB, "GB", recordedCost.TransferUSD, plannedCost.TransferUSD),
+		costRow("Registry", recordedCost.Usage.RegistryGBMonths, "GB-months", recordedCost.RegistryUSD, plannedCost.RegistryUSD),
+		{"Total", "", "", strconv.FormatFloat(recordedCost.TotalUSD(), 'f', 6, 64), strconv.FormatFloat(plannedCost.TotalUSD(), 'f', 6, 64)},
+	}
+	if err := costWriter.WriteAll(rows); err != nil {
+		log.Errorf("[sub-experiment %d] Could not write cost file: %s", experiment.ID, err.Error())
+	}
+	return recordedCost
+}
+
+// postProcessImageSize writes the achieved size of the container image of the sub-experiment next to the other results,
+// since the pulled image size is the parameter of the image-size cold-start experiments.
+func postProcessImageSize(experiment setup.SubExperiment, experimentDirectoryPath string) {
+	if experiment.ImageSize.CompressedBytes == 0 {
+		return
+	}
+
+	imageSizePath := filepath.Join(experimentDirectoryPath, "image-size.csv")
+	imageSizeFile, err := os.Create(imageSizePath)
+	if err != nil {
+		log.Errorf("[sub-experiment %d] Could not create image size file: %s", experiment.ID, err.Error())
+		return
+	}
+	defer imageSizeFile.Close()
+
+	imageSizeWriter := csv.NewWriter(imageSizeFile)
+	rows := [][]string{
+		{"Target Compressed Size (MB)", "Compressed Size (bytes)", "Uncompressed Size (bytes)"},
+		{
+			strconv.FormatFloat(experiment.FunctionImageSizeMB, 'f', -1, 64),
+			strconv.FormatInt(experiment.ImageSize.CompressedBytes, 10),
+			strconv.FormatInt(experiment.ImageSize.UncompressedBytes, 10),
+		},
+	}
+	if err := imageSizeWriter.WriteAll(rows); err != nil {
+		log.Errorf("[sub-experiment %d] Could not write image size file: %s", experiment.ID, err.Error())
+	}
 }
 
+func costRow(component string, usage float64, unit string, recordedUSD float64, plannedUSD float64) []string {
+	return []string{
+		component,
+		strconv.FormatFloat(usage, 'f', -1, 64),
+		unit,
+		strconv.FormatFloat(recordedUSD, 'f', 6, 64),
+		strconv.FormatFloat(plannedUSD, 'f', 6, 64),
+	}
+}
+
+// postProcessServerTimings separates the platform overhead, i.e., the client latency minus the server-side execution
+// time, from the function execution for the requests answered with the standard timing envelope. The statistics of
+// each component are written next to the other results, split between cold and warm invocations.
+func postProcessServerTimings(experiment setup.SubExperiment, latenciesDF dataframe.DataFrame, experimentDirectoryPath string) {
+	timedDF := latenciesDF.Filter(dataframe.F{Colname: "Execution Duration (ms)", Comparator: series.GreaterEq, Comparando: 0})
+	if timedDF.Err != nil || timedDF.Nrow() == 0 {
+		log.Debugf("[sub-experiment %d] No server-side timings were recorded", experiment.ID)
+		return
+	}
+
+	clientLatencies := timedDF.Col("Client Latency (ms)").Float()
+	executionDurations := timedDF.Col("Execution Duration (ms)").Float()
+	spinDurations := timedDF.Col("Spin Duration (ms)").Float()
+	initDurations := timedDF.Col("Init Duration (ms)").Float()
+	coldStarts := timedDF.Col("Cold Start").Records()
+
+	components := []overheadComponent{
+		{name: "Client Latency", latencies: clientLatencies},
+		{name: "Execution Duration", latencies: executionDurations},
+		{name: "Spin Duration", latencies: spinDurations},
+		{name: "Platform Overhead"},
+		{name: "Cold Platform Overhead"},
+		{name: "Warm Platform Overhead"},
+		{name: "Cold Init Duration"},
+	}
+	for i := range clientLatencies {
+		overhead := clientLatencies[i] - executionDurations[i]
+		components[3].latencies = append(components[3].latencies, overhead)
+		if coldStarts[i] == "true" {
+			components[4].latencies = append(components[4].latencies, overhead)
+			components[6].latencies = append(components[6].latencies, initDurations[i])
+		} else {
+			components[5].latencies = append(components[5].latencies, overhead)
+		}
+	}
+
+	statisticsPath := filepath.Join(experimentDirectoryPath, "overhead-statistics.csv")
+	log.Infof("[sub-experiment %d] Creating platform overhead statistics file at `%s`", experiment.ID, statisticsPath)
+	statisticsFile, err := os.Create(statisticsPath)
+	if err != nil {
+		log.Errorf("[sub-experiment %d] Could not create platform overhead statistics file: %s", experiment.ID, err.Error())
+		return
+	}
+	defer statisticsFile.Close()
+
+	statisticsWriter := csv.NewWriter(statisticsFile)
+	if err := statisticsWriter.Write(append([]string{"Component"}, statisticsHeader...)); err != nil {
+		log.Errorf("[sub-experiment %d] Could not write platform overhead statistics header: %s", experiment.ID, err.Error())
+	}
+	for _, component := range components {
+		if len(component.latencies) == 0 {
+			continue
+		}
+		sort.Float64s(component.latencies)
+		if err := statisticsWriter.Write(append([]string{component.name}, statisticsRow(component.latencies)...)); err != nil {
+			log.Errorf("[sub-experiment %d] Could not write platform overhead statistics: %s", experiment.ID, err.Error())
+		}
+	}
+	statisticsWriter.Flush()
+
+	log.Infof("[sub-experiment %d] Median platform overhead is %.2fms across %d requests with server-side timings.",
+		experiment.ID, stat.Quantile(0.5, stat.Empirical, components[3].latencies, nil), len(clientLatencies))
+}
+
+// overheadComponent holds the latencies (ms) of one component of the client latency
+type overheadComponent struct {
+	name      string
+	latencies []float64
+}
+
+// statisticsHeader names the columns produced by statisticsRow
+var statisticsHeader = []string{"Count", "Mean", "Standard Deviation", "Min", "25%ile", "50%ile", "75%ile", "95%ile", "Max"}
+
 func generateStatistics(file *os.File, experimentID int, sortedLatencies []float64) {
 	log.Debugf("[sub-experiment %d] Generating result statistics...", experimentID)
 
 	statisticsWriter := csv.NewWriter(file)
 
-	if err := statisticsWriter.Write([]string{"Count", "Mean", "Standard Deviation", "Min", "25%ile", "50%ile",
-		"75%ile", "95%ile", "Max"}); err != nil {
+	if err := statisticsWriter.Write(statisticsHeader); err != nil {
 		log.Errorf("[sub-experiment %d] Could not write statistics header to file: %s", experimentID, err.Error())
 	}
 
-	if err := statisticsWriter.Write([]string{
+	if err := statisticsWriter.Write(statisticsRow(sortedLatencies)); err != nil {
+		log.Errorf("[sub-experiment %d] Could not write statistics to file: %s", experimentID, err.Error())
+	}
+
+	statisticsWriter.Flush()
+}
+
+func statisticsRow(sortedLatencies []float64) []string {
+	return []string{
 		strconv.Itoa(len(sortedLatencies)),
 		fmt.Sprintf("%.2f", stat.Mean(sortedLatencies, nil)),
 		fmt.Sprintf("%.2f", stat.StdDev(sortedLatencies, nil)),
@@ -74,9 +252,129 @@ func generateStatistics(file *os.File, experimentID int, sortedLatencies []float
 		fmt.Sprintf("%.2f", stat.Quantile(0.75, stat.Empirical, sortedLatencies, nil)),
 		fmt.Sprintf("%.2f", stat.Quantile(0.95, stat.Empirical, sortedLatencies, nil)),
 		fmt.Sprintf("%.2f", stat.Quantile(1, stat.Empirical, sortedLatencies, nil)),
-	}); err != nil {
-		log.Errorf("[sub-experiment %d] Could not write statistics to file: %s", experimentID, err.Error())
+	}
+}
+
+// postProcessDataTransfers breaks each recorded data transfer chain down into the time spent from the client to the
+// first function, on every hop between consecutive functions and from the last function back to the client. The
+// statistics of each segment are written next to the other results, together with their CDFs.
+func postProcessDataTransfers(experiment setup.SubExperiment, dataTransfersFile *os.File, experimentDirectoryPath string) {
+	log.Debugf("[sub-experiment %d] Reading written data transfers from file %s", experiment.ID, dataTransfersFile.Name())
+
+	if _, err := dataTransfersFile.Seek(0, io.SeekStart); err != nil {
+		log.Error(err)
+	}
+
+	reader := csv.NewReader(dataTransfersFile)
+	reader.FieldsPerRecord = -1 // functions that do not transfer data may return shorter timestamp chains
+	records, err := reader.ReadAll()
+	if err != nil {
+		log.Errorf("[sub-experiment %d] Could not read data transfers: %s", experiment.ID, err.Error())
+		return
+	}
+
+	segments := ComputeTransferSegments(experiment, records)
+	if len(segments) == 0 {
+		log.Warnf("[sub-experiment %d] No complete data transfer chains were recorded", experiment.ID)
+		return
+	}
+
+	transferMethod := "inline"
+	if experiment.StorageTransfer {
+		transferMethod = "storage"
+	}
+
+	statisticsPath := filepath.Join(experimentDirectoryPath, "data-transfer-statistics.csv")
+	log.Infof("[sub-experiment %d] Creating data transfer statistics file at `%s`", experiment.ID, statisticsPath)
+	statisticsFile, err := os.Create(statisticsPath)
+	if err != nil {
+		log.Errorf("[sub-experiment %d] Could not create data transfer statistics file: %s", experiment.ID, err.Error())
+		return
+	}
+	defer statisticsFile.Close()
+
+	statisticsWriter := csv.NewWriter(statisticsFile)
+	if err := statisticsWriter.Write(append([]string{"Segment", "Transfer Method"}, statisticsHeader...)); err != nil {
+		log.Errorf("[sub-experiment %d] Could not write data transfer statistics header: %s", experiment.ID, err.Error())
 	}
 
+	segmentNames := make([]string, len(segments))
+	sortedSegmentLatencies := make([][]float64, len(segments))
+	for index, segment := range segments {
+		sort.Float64s(segment.Latencies)
+		segmentNames[index] = segment.Name
+		sortedSegmentLatencies[index] = segment.Latencies
+
+		if err := statisticsWriter.Write(append([]string{segment.Name, transferMethod}, statisticsRow(segment.Latencies)...)); err != nil {
+			log.Errorf("[sub-experiment %d] Could not write data transfer statistics: %s", experiment.ID, err.Error())
+		}
+	}
 	statisticsWriter.Flush()
+
+	visualization.GenerateDataTransfers(experiment, transferMethod, segmentNames, sortedSegmentLatencies, experimentDirectoryPath)
+}
+
+// TransferSegment holds the latencies (ms) measured on one part of the data transfer chains
+type TransferSegment struct {
+	Name      string
+	Latencies []float64
+}
+
+// ComputeTransferSegments returns, in chain order, the client-to-first-function latency, the latency of every hop,
+// the last-function-to-client latency and the total time spent within the chain. Note that the segments involving
+// the client compare its clock against the clocks of the functions.
+func ComputeTransferSegments(experiment setup.SubExperiment, records [][]string) []TransferSegment {
+	const timestampsStartColumn = 5 // Request ID, Host, Burst ID, Sent At, Received At
+
+	chainLength := experiment.DataTransferChainLength
+	segments := []TransferSegment{{Name: "Client to Function 0"}}
+	for hop := 0; hop < chainLength-1; hop++ {
+		segments = append(segments, TransferSegment{Name: fmt.Sprintf("Function %d to Function %d", hop, hop+1)})
+	}
+	segments = append(segments,
+		TransferSegment{Name: fmt.Sprintf("Function %d to Client", chainLength-1)},
+		TransferSegment{Name: "Function Chain"},
+	)
+
+	skipped := 0
+	for _, record := range records[1:] { // skip the header
+		if len(record) < timestampsStartColumn+chainLength {
+			skipped++
+			continue
+		}
+
+		sentAt, sentErr := time.Parse(time.RFC3339Nano, record[3])
+		receivedAt, receivedErr := time.Parse(time.RFC3339Nano, record[4])
+		timestamps := make([]float64, chainLength)
+		var timestampErr error
+		for i := range timestamps {
+			if timestamps[i], timestampErr = strconv.ParseFloat(record[timestampsStartColumn+i], 64); timestampErr != nil {
+				break
+			}
+		}
+		if sentErr != nil || receivedErr != nil || timestampErr != nil {
+			skipped++
+			continue
+		}
+
+		segments[0].Latencies = append(segments[0].Latencies, timestamps[0]-unixMilliseconds(sentAt))
+		for hop := 0; hop < chainLength-1; hop++ {
+			segments[hop+1].Latencies = append(segments[hop+1].Latencies, timestamps[hop+1]-timestamps[hop])
+		}
+		segments[chainLength].Latencies = append(segments[chainLength].Latencies, unixMilliseconds(receivedAt)-timestamps[chainLength-1])
+		segments[chainLength+1].Latencies = append(segments[chainLength+1].Latencies, timestamps[chainLength-1]-timestamps[0])
+	}
+
+	if skipped > 0 {
+		log.Warnf("[sub-experiment %d] Skipped %d incomplete data transfer chains", experiment.ID, skipped)
+	}
+	if len(segments[0].Latencies) == 0 {
+		return nil
+	}
+	return segments
+}
+
+// unixMilliseconds converts the milliseconds and their fraction separately, as Unix nanoseconds exceed float64 precision
+func unixMilliseconds(t time.Time) float64 {
+	return float64(t.UnixMilli()) + float64(t.Nanosecond()%int(time.Millisecond))/float64(time.Millisecond)
 }
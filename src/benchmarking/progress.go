@@ -0,0 +1,278 @@
+// MIT License
+//
+// Copyright (c) 2021 Theodor Amariucai and EASE Lab
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package benchmarking
+
+import (
+	"fmt"
+	"gonum.org/v1/gonum/stat"
+	"io"
+	"math"
+	"sort"
+	"stellar/setup"
+	"stellar/util"
+	"strings"
+	"sync"
+	"time"
+)
+
+// failureWorker is the class of the requests lost because a worker could not send its share of a burst
+const failureWorker = "worker"
+
+const progressBarWidth = 30
+
+// runProgress is set when the progress of the run is tracked, for the metrics endpoint or the terminal view
+var runProgress *Progress
+
+// Progress tracks the requests of each sub-experiment while the run is in progress. It is safe for concurrent use,
+// and its recording methods do nothing on a nil Progress.
+type Progress struct {
+	mu             sync.Mutex
+	sequential     bool
+	subExperiments []*subExperimentProgress
+}
+
+type subExperimentProgress struct {
+	id                int
+	title             string
+	plannedBursts     int
+	plannedRequests   int
+	plannedDuration   time.Duration
+	completedBursts   int
+	currentBurst      int
+	sentRequests      int
+	completedRequests int
+	failures          map[string]int
+	latenciesMs       []float64
+	startedAt         time.Time
+	finishedAt        time.Time
+}
+
+// TrackProgress starts tracking the progress of the sub-experiments about to run (all of them if specificExperiment
+// is -1), replacing any previous tracking.
+func TrackProgress(config setup.Configuration, specificExperiment int) *Progress {
+	progress := &Progress{sequential: config.Sequential}
+	for index, subExperiment := range config.SubExperiments {
+		if specificExperiment != -1 && specificExperiment != index {
+			continue
+		}
+
+		parallelism := util.IntegerMax(subExperiment.Parallelism, 1)
+		plannedRequests := 0
+		for burstID := 0; burstID < subExperiment.Bursts; burstID++ {
+			plannedRequests += subExperiment.BurstSizes[(burstID/parallelism)%len(subExperiment.BurstSizes)]
+		}
+
+		progress.subExperiments = append(progress.subExperiments, &subExperimentProgress{
+			id:              subExperiment.ID,
+			title:           subExperiment.Title,
+			plannedBursts:   subExperiment.Bursts,
+			plannedRequests: plannedRequests,
+			plannedDuration: time.Duration(float64(subExperiment.Bursts) / float64(parallelism) * subExperiment.IATSeconds * float64(time.Second)),
+			currentBurst:    -1,
+			failures:        map[string]int{},
+		})
+	}
+
+	runProgress = progress
+	return progress
+}
+
+// update runs the given function on the progress of the sub-experiment, if it is tracked.
+func (p *Progress) update(experimentID int, apply func(subExperiment *subExperimentProgress)) {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, subExperiment := range p.subExperiments {
+		if subExperiment.id == experimentID {
+			apply(subExperiment)
+			return
+		}
+	}
+}
+
+func (p *Progress) subExperimentStarted(experimentID int) {
+	p.update(experimentID, func(subExperiment *subExperimentProgress) {
+		subExperiment.startedAt = time.Now()
+	})
+}
+
+func (p *Progress) subExperimentFinished(experimentID int) {
+	p.update(experimentID, func(subExperiment *subExperimentProgress) {
+		subExperiment.finishedAt = time.Now()
+	})
+}
+
+func (p *Progress) burstStarted(experimentID int, burstID int, requests int) {
+	p.update(experimentID, func(subExperiment *subExperimentProgress) {
+		subExperiment.currentBurst = burstID
+		subExperiment.sentRequests += requests
+	})
+}
+
+func (p *Progress) burstCompleted(experimentID int) {
+	p.update(experimentID, func(subExperiment *subExperimentProgress) {
+		subExperiment.completedBursts++
+	})
+}
+
+func (p *Progress) requestCompleted(experimentID int, latencyMs float64) {
+	p.update(experimentID, func(subExperiment *subExperimentProgress) {
+		subExperiment.completedRequests++
+		subExperiment.latenciesMs = append(subExperiment.latenciesMs, latencyMs)
+	})
+}
+
+func (p *Progress) requestFailed(experimentID int, failure string, requests int) {
+	p.update(experimentID, func(subExperiment *subExperimentProgress) {
+		subExperiment.failures[failure] += requests
+	})
+}
+
+// eta extrapolates the remaining time of the sub-experiment from its completed bursts, falling back to the duration
+// planned from its IAT until the first burst completes.
+func (s *subExperimentProgress) eta(now time.Time) time.Duration {
+	switch {
+	case !s.finishedAt.IsZero():
+		return 0
+	case s.startedAt.IsZero():
+		return s.plannedDuration
+	case s.completedBursts == 0:
+		return time.Duration(math.Max(0, float64(s.plannedDuration-now.Sub(s.startedAt))))
+	}
+	elapsed := now.Sub(s.startedAt)
+	return time.Duration(float64(elapsed) * float64(s.plannedBursts-s.completedBursts) / float64(s.completedBursts))
+}
+
+// runETA is the remaining time of the run: the sum of the sub-experiment ETAs if they run sequentially, their
+// maximum otherwise.
+func (p *Progress) runETA(now time.Time) time.Duration {
+	var eta time.Duration
+	for _, subExperiment := range p.subExperiments {
+		if p.sequential {
+			eta += subExperiment.eta(now)
+		} else if subExperimentETA := subExperiment.eta(now); subExperimentETA > eta {
+			eta = subExperimentETA
+		}
+	}
+	return eta
+}
+
+// latencyPercentiles returns the median and 99th percentile of the latencies received so far.
+func (s *subExperimentProgress) latencyPercentiles() (float64, float64) {
+	if len(s.latenciesMs) == 0 {
+		return math.NaN(), math.NaN()
+	}
+	sortedLatencies := append([]float64(nil), s.latenciesMs...)
+	sort.Float64s(sortedLatencies)
+	return stat.Quantile(0.5, stat.Empirical, sortedLatencies, nil), stat.Quantile(0.99, stat.Empirical, sortedLatencies, nil)
+}
+
+func (s *subExperimentProgress) failedRequests() int {
+	failed := 0
+	for _, count := range s.failures {
+		failed += count
+	}
+	return failed
+}
+
+// Render writes one progress bar per sub-experiment, with its running median and 99th percentile latencies.
+func (p *Progress) Render(writer io.Writer) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	for _, subExperiment := range p.subExperiments {
+		done := 0.
+		if subExperiment.plannedRequests > 0 {
+			done = float64(subExperiment.completedRequests+subExperiment.failedRequests()) / float64(subExperiment.plannedRequests)
+		}
+		filled := int(math.Min(1, done) * progressBarWidth)
+
+		status := "pending"
+		switch {
+		case !subExperiment.finishedAt.IsZero():
+			status = "done"
+		case !subExperiment.startedAt.IsZero():
+			status = fmt.Sprintf("ETA %v", subExperiment.eta(now).Round(time.Second))
+		}
+
+		p50, p99 := subExperiment.latencyPercentiles()
+		fmt.Fprintf(writer, "[%d] %-20.20s [%s%s] %3.0f%%  burst %d/%d  requests %d/%d  errors %d  p50 %s  p99 %s  %s\n",
+			subExperiment.id, subExperiment.title,
+			strings.Repeat("#", filled), strings.Repeat("-", progressBarWidth-filled), 100*math.Min(1, done),
+			subExperiment.completedBursts, subExperiment.plannedBursts,
+			subExperiment.completedRequests, subExperiment.plannedRequests,
+			subExperiment.failedRequests(), formatProgressLatency(p50), formatProgressLatency(p99), status)
+	}
+	fmt.Fprintf(writer, "Run ETA %v\n", p.runETA(now).Round(time.Second))
+}
+
+func formatProgressLatency(latencyMs float64) string {
+	if math.IsNaN(latencyMs) {
+		return "-"
+	}
+	return fmt.Sprintf("%.0fms", latencyMs)
+}
+
+// ShowProgress redraws the progress view on the terminal every interval, until the returned function is called.
+func (p *Progress) ShowProgress(terminal io.Writer, interval time.Duration) func() {
+	stop := make(chan struct{})
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		var view strings.Builder
+		lines := 0
+		draw := func() {
+			view.Reset()
+			p.Render(&view)
+			// Move the cursor back up to the first line of the previous view and clear it before redrawing
+			if lines > 0 {
+				fmt.Fprintf(terminal, "\033[%dA\033[J", lines)
+			}
+			fmt.Fprint(terminal, view.String())
+			lines = strings.Count(view.String(), "\n")
+		}
+
+		for {
+			draw()
+			select {
+			case <-stop:
+				draw()
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return func() {
+		close(stop)
+		<-done
+	}
+}
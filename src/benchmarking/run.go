@@ -23,11 +23,16 @@
 package benchmarking
 
 import (
+	"context"
 	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"stellar/benchmarking/networking/benchgrpc"
 	"stellar/benchmarking/networking/benchhttp"
 	"stellar/benchmarking/writers"
 	"stellar/setup"
+	"stellar/tracing"
 	"stellar/util"
 	"strconv"
 	"strings"
@@ -54,7 +59,8 @@ func (e *ErrorCount) Read() int {
 
 // runSubExperiment will trigger bursts sequentially to each available gateway for a given experiment, then sleep for the
 // selected interval, and repeat.
-func runSubExperiment(experiment setup.SubExperiment, burstDeltas []time.Duration, provider string, latenciesWriter *writers.RTTLatencyWriter, dataTransferWriter *writers.DataTransferWriter) {
+func runSubExperiment(ctx context.Context, experiment setup.SubExperiment, burstDeltas []time.Duration, provider string, workerPool *WorkerPool,
+	latenciesWriter *writers.RTTLatencyWriter, dataTransferWriter *writers.DataTransferWriter) {
 	burstID := 0
 	deltaIndex := 0
 	errorThreshold := (experiment.Bursts) * (experiment.BurstSizes[util.IntegerMin(deltaIndex, len(experiment.BurstSizes)-1)]) / 10
@@ -64,10 +70,10 @@ func runSubExperiment(experiment setup.SubExperiment, burstDeltas []time.Duratio
 		// Send one burst to each available gateway (the more gateways used, the faster the experiment)
 		for gatewayID := 0; gatewayID < len(experiment.Endpoints) && burstID < experiment.Bursts; gatewayID++ {
 			// Every refresh period, we cycle through burst sizes if they're dynamic i.e. more than 1 element
-			incrementLimit := experiment.BusySpinIncrements[util.IntegerMin(deltaIndex, len(experiment.BusySpinIncrements)-1)]
+			busySpin := experiment.BusySpinAt(deltaIndex)
 			burstSize := experiment.BurstSizes[deltaIndex%len(experiment.BurstSizes)]
 			log.Infof("%d", len(experiment.Routes))
-			sendBurst(provider, experiment, burstID, burstSize, experiment.Endpoints[gatewayID], incrementLimit, latenciesWriter, dataTransferWriter, experiment.Routes[gatewayID], &errorCount)
+			sendBurst(ctx, provider, workerPool, experiment, burstID, burstSize, experiment.Endpoints[gatewayID], busySpin, latenciesWriter, dataTransferWriter, experiment.Routes[gatewayID], &errorCount)
 			errs := errorCount.Read()
 			if errorCount.Read() > errorThreshold {
 				log.Fatalf("Too many errors (%d) occurred, aborting experiment.", errs)
@@ -84,47 +90,95 @@ func runSubExperiment(experiment setup.SubExperiment, burstDeltas []time.Duratio
 	}
 }
 
-func sendBurst(provider string, config setup.SubExperiment, burstID int, requests int, gatewayEndpoint setup.EndpointInfo,
-	incrementLimit int64, latenciesWriter *writers.RTTLatencyWriter, dataTransfersWriter *writers.DataTransferWriter, route string, errorCount *ErrorCount) {
+// sendBurst sends the requests of a burst from this client, or splits them across the workers of the pool if not nil
+func sendBurst(ctx context.Context, provider string, workerPool *WorkerPool, config setup.SubExperiment, burstID int, requests int, gatewayEndpoint setup.EndpointInfo,
+	busySpin setup.BusySpin, latenciesWriter *writers.RTTLatencyWriter, dataTransfersWriter *writers.DataTransferWriter, route string, errorCount *ErrorCount) {
 
-	log.Infof("[sub-experiment %d] Starting burst %d, making %d requests with increment limit %d to gateway with ID %q of provider %q.",
+	log.Infof("[sub-experiment %d] Starting burst %d, making %d requests with busy-spin %s to gateway with ID %q of provider %q.",
 		config.ID,
 		burstID,
 		requests,
-		incrementLimit,
+		busySpin,
 		gatewayEndpoint.ID,
 		provider,
 	)
 
+	ctx, span := tracing.Tracer().Start(ctx, "burst", trace.WithAttributes(
+		attribute.Int("stellar.burst", burstID),
+		attribute.Int("stellar.requests", requests),
+		attribute.String("stellar.gateway", gatewayEndpoint.ID),
+	))
+	defer span.End()
+
+	runProgress.burstStarted(config.ID, burstID, requests)
+	defer runProgress.burstCompleted(config.ID)
+
+	if workerPool != nil {
+		workerPool.sendBurst(ctx, provider, config, burstID, requests, gatewayEndpoint, busySpin, latenciesWriter, dataTransfersWriter, route, errorCount)
+		log.Infof("[sub-experiment %d] Received all responses for burst %d from %d workers.", config.ID, burstID, len(workerPool.workers))
+		return
+	}
+
 	var requestsWaitGroup sync.WaitGroup
 	for i := 0; i < requests; i++ {
 		requestsWaitGroup.Add(1)
-		go executeRequestAndWriteResults(&requestsWaitGroup, provider, incrementLimit, latenciesWriter, dataTransfersWriter, burstID,
-			config.PayloadLengthBytes, gatewayEndpoint, config.StorageTransfer, route, errorCount)
+		go executeRequestAndWriteResults(ctx, &requestsWaitGroup, provider, busySpin, latenciesWriter, dataTransfersWriter, config.ID, burstID,
+			config.PayloadLengthBytes, gatewayEndpoint, config.StorageTransfer, route, config.Workload, errorCount)
 	}
 
 	requestsWaitGroup.Wait()
 	log.Infof("[sub-experiment %d] Received all responses for burst %d.", config.ID, burstID)
 }
 
-func executeRequestAndWriteResults(requestsWaitGroup *sync.WaitGroup, provider string, incrementLimit int64,
-	latenciesWriter *writers.RTTLatencyWriter, dataTransfersWriter *writers.DataTransferWriter, burstID int,
-	payloadLengthBytes int, gatewayEndpoint setup.EndpointInfo, storageTransfer bool, route string, errorCount *ErrorCount) {
+// RequestRecord holds the outcome of a single request, as written to the latencies and data transfers files.
+type RequestRecord struct {
+	ResponseID     string
+	Hostname       string
+	BurstID        int
+	SentAt         time.Time
+	ReceivedAt     time.Time
+	TimestampChain []string
+	ServerTiming   *benchhttp.ServerTiming
+}
+
+func executeRequestAndWriteResults(ctx context.Context, requestsWaitGroup *sync.WaitGroup, provider string, busySpin setup.BusySpin,
+	latenciesWriter *writers.RTTLatencyWriter, dataTransfersWriter *writers.DataTransferWriter, experimentID int, burstID int,
+	payloadLengthBytes int, gatewayEndpoint setup.EndpointInfo, storageTransfer bool, route string,
+	workload setup.WorkloadProfile, errorCount *ErrorCount) {
 	defer requestsWaitGroup.Done()
 
-	var reqSentTime, reqReceivedTime time.Time
-	var responseID, hostname string
-	var timestampChain []string
-	var ok bool
+	record, failure := executeRequest(ctx, provider, busySpin, burstID, payloadLengthBytes, gatewayEndpoint, storageTransfer, route, workload)
+	if failure != "" {
+		errorCount.Increment()
+		runProgress.requestFailed(experimentID, failure, 1)
+		return
+	}
+
+	writeRequestRecord(record, latenciesWriter, dataTransfersWriter)
+	runProgress.requestCompleted(experimentID, record.latencyMs())
+}
+
+// executeRequest sends a single request to the given gateway and returns the class of its failure, empty if it
+// succeeded (see benchhttp.ExecuteClassifiedRequest). The request is traced as a child of the span in ctx.
+func executeRequest(ctx context.Context, provider string, busySpin setup.BusySpin, burstID int, payloadLengthBytes int,
+	gatewayEndpoint setup.EndpointInfo, storageTransfer bool, route string, workload setup.WorkloadProfile) (RequestRecord, string) {
+	record := RequestRecord{BurstID: burstID}
+	ctx, span := tracing.Tracer().Start(ctx, "request", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		attribute.String("stellar.provider", provider),
+		attribute.String("stellar.gateway", gatewayEndpoint.ID),
+		attribute.Int("stellar.burst", burstID),
+	))
+	defer span.End()
 
 	switch provider {
 	case "vhive":
-		var stringArrayTimeStampChain string
-		stringArrayTimeStampChain, reqSentTime, reqReceivedTime = benchgrpc.ExecuteRequest(payloadLengthBytes, gatewayEndpoint, incrementLimit, storageTransfer)
+		var stringArrayTimeStampChain, serverTiming string
+		stringArrayTimeStampChain, serverTiming, record.SentAt, record.ReceivedAt = benchgrpc.ExecuteRequest(ctx, payloadLengthBytes, gatewayEndpoint, busySpin.IncrementLimit, storageTransfer)
 
-		timestampChain = stringArrayToArrayOfString(stringArrayTimeStampChain)
-		hostname = gatewayEndpoint.ID
-		responseID = "N/A"
+		record.TimestampChain = stringArrayToArrayOfString(stringArrayTimeStampChain)
+		record.Hostname = gatewayEndpoint.ID
+		record.ResponseID = "N/A"
+		record.ServerTiming = benchhttp.ExtractServerTiming(serverTiming)
 	case "aws":
 		fallthrough
 	case "azure":
@@ -136,41 +190,55 @@ func executeRequestAndWriteResults(requestsWaitGroup *sync.WaitGroup, provider s
 	case "aliyun":
 		fallthrough
 	case "google":
-		request := benchhttp.CreateRequest(provider, payloadLengthBytes, gatewayEndpoint, incrementLimit, storageTransfer, route)
+		request := benchhttp.CreateRequest(provider, payloadLengthBytes, gatewayEndpoint, busySpin, storageTransfer, route, workload)
 		log.Debugf("Created HTTP request with URL (%q), Body (%q)", (*request).URL, (*request).Body)
 
-		var respBody []byte
-		ok, respBody, reqSentTime, reqReceivedTime = benchhttp.ExecuteRequest(*request)
-		if !ok {
+		failure, respBody, reqSentTime, reqReceivedTime := benchhttp.ExecuteClassifiedRequest(ctx, *request)
+		if failure != "" {
 			log.Errorf("Request failed, skipping...")
-			errorCount.Increment()
-			return
+			span.SetAttributes(attribute.String("stellar.failure", failure))
+			span.SetStatus(codes.Error, failure)
+			return record, failure
 		}
 		response := benchhttp.ExtractProducerConsumerResponse(respBody)
 
-		timestampChain = response.TimestampChain
-		hostname = request.URL.Hostname()
-		responseID = response.RequestID
+		record.SentAt, record.ReceivedAt = reqSentTime, reqReceivedTime
+		record.TimestampChain = response.TimestampChain
+		record.Hostname = request.URL.Hostname()
+		record.ResponseID = response.RequestID
+		record.ServerTiming = response.ServerTiming
 	default:
 		log.Fatalf("Unrecognized provider %q, benchmarking module cannot run.", provider)
 	}
 
+	span.SetAttributes(attribute.String("stellar.response_id", record.ResponseID))
+	return record, ""
+}
+
+func (r RequestRecord) latencyMs() float64 {
+	return float64(r.ReceivedAt.Sub(r.SentAt).Microseconds()) / 1000
+}
+
+func writeRequestRecord(record RequestRecord, latenciesWriter *writers.RTTLatencyWriter, dataTransfersWriter *writers.DataTransferWriter) {
 	if dataTransfersWriter != nil {
 		dataTransfersWriter.WriteDataTransferRow(
-			responseID,
-			hostname,
-			strconv.Itoa(burstID),
-			timestampChain...,
+			record.ResponseID,
+			record.Hostname,
+			strconv.Itoa(record.BurstID),
+			record.SentAt.Format(time.RFC3339Nano),
+			record.ReceivedAt.Format(time.RFC3339Nano),
+			record.TimestampChain...,
 		)
 	}
 
 	latenciesWriter.WriteRTTLatencyRow(
-		responseID,
-		hostname,
-		reqSentTime.Format(time.RFC3339),
-		reqReceivedTime.Format(time.RFC3339),
-		strconv.FormatInt(reqReceivedTime.Sub(reqSentTime).Milliseconds(), 10),
-		strconv.Itoa(burstID),
+		record.ResponseID,
+		record.Hostname,
+		record.SentAt.Format(time.RFC3339Nano),
+		record.ReceivedAt.Format(time.RFC3339Nano),
+		strconv.FormatInt(record.ReceivedAt.Sub(record.SentAt).Milliseconds(), 10),
+		strconv.Itoa(record.BurstID),
+		record.ServerTiming.Fields()...,
 	)
 }
 
@@ -0,0 +1,41 @@
+package benchmarking
+
+import (
+	"encoding/csv"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"stellar/benchmarking"
+	"testing"
+	"time"
+)
+
+func TestClientMonitor(t *testing.T) {
+	statsPath := filepath.Join(t.TempDir(), "client-stats.csv")
+	startTime := time.Now()
+	monitor := benchmarking.StartClientMonitor(statsPath, 10*time.Millisecond)
+	time.Sleep(55 * time.Millisecond)
+	monitor.Stop()
+
+	statsFile, err := os.Open(statsPath)
+	require.NoError(t, err)
+	defer statsFile.Close()
+	rows, err := csv.NewReader(statsFile).ReadAll()
+	require.NoError(t, err)
+	require.Equal(t, "Timestamp", rows[0][0])
+	require.GreaterOrEqual(t, len(rows), 1+5)
+
+	samples := monitor.Samples(startTime, time.Now())
+	require.Len(t, samples, len(rows)-1)
+	require.Positive(t, samples[0].Goroutines)
+}
+
+func TestDetectSaturation(t *testing.T) {
+	require.Empty(t, benchmarking.DetectSaturation([]benchmarking.ClientSample{{CPUPercent: 40, TimeWaitSockets: 100}}))
+
+	saturations := benchmarking.DetectSaturation([]benchmarking.ClientSample{
+		{CPUPercent: 40, MemoryPercent: 20},
+		{CPUPercent: 97.5, MemoryPercent: 30},
+	})
+	require.Equal(t, []benchmarking.ClientSaturation{{Metric: "CPU Utilization (%)", Peak: 97.5, Threshold: 90}}, saturations)
+}
@@ -0,0 +1,203 @@
+package benchmarking
+
+import (
+	"encoding/csv"
+	"github.com/stretchr/testify/require"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/rpc"
+	"os"
+	"path/filepath"
+	"stellar/benchmarking"
+	"stellar/setup"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// localFunction records the requests served by the local HTTPS server of newLocalExperiment
+type localFunction struct {
+	mu           sync.Mutex
+	served       int
+	traceParents []string
+}
+
+func (f *localFunction) requests() (int, []string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.served, f.traceParents
+}
+
+// newLocalExperiment returns a configuration sending 2 bursts of 5 requests to a local HTTPS server.
+func newLocalExperiment(t *testing.T) (setup.Configuration, *localFunction) {
+	function := &localFunction{}
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		function.mu.Lock()
+		function.served++
+		function.traceParents = append(function.traceParents, r.Header.Get("traceparent"))
+		function.mu.Unlock()
+		_, _ = w.Write([]byte(`{"RequestID": "request", "TimestampChain": ["1"]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	defaultTransport := http.DefaultTransport
+	http.DefaultTransport = server.Client().Transport
+	t.Cleanup(func() { http.DefaultTransport = defaultTransport })
+
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(configPath, []byte(`{
+		"Provider": "gcr",
+		"SubExperiments": [{"Title": "local", "Bursts": 2, "BurstSizes": [5], "IATSeconds": 0,
+			"IATType": "deterministic", "DesiredServiceTimes": ["0ms"], "Visualization": "none"}]
+	}`), 0644))
+	config := setup.ExtractConfiguration(configPath)
+	setup.FindBusySpinIncrements(&config, nil)
+	config.SubExperiments[0].Endpoints = []setup.EndpointInfo{{ID: strings.TrimPrefix(server.URL, "https://")}}
+	config.SubExperiments[0].Routes = []string{""}
+
+	return config, function
+}
+
+func TestDistributedBursts(t *testing.T) {
+	config, function := newLocalExperiment(t)
+
+	var addresses []string
+	for i := 0; i < 2; i++ {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer listener.Close()
+		go benchmarking.ServeWorker(listener, t.TempDir())
+		addresses = append(addresses, listener.Addr().String())
+	}
+	workerPool := benchmarking.ConnectWorkers(addresses)
+	defer workerPool.Close()
+
+	outputPath := t.TempDir()
+	benchmarking.TriggerSubExperiments(config, outputPath, -1, workerPool)
+
+	served, _ := function.requests()
+	require.Equal(t, 10, served)
+	require.Len(t, readLatencies(t, outputPath), 10)
+}
+
+// readLatencies returns the rows of the latencies file of the single sub-experiment of the run, header excluded
+func readLatencies(t *testing.T, outputPath string) [][]string {
+	latencyFiles, err := filepath.Glob(filepath.Join(outputPath, "*", "latencies.csv"))
+	require.NoError(t, err)
+	require.Len(t, latencyFiles, 1)
+	latenciesFile, err := os.Open(latencyFiles[0])
+	require.NoError(t, err)
+	defer latenciesFile.Close()
+	rows, err := csv.NewReader(latenciesFile).ReadAll()
+	require.NoError(t, err)
+	return rows[1:]
+}
+
+// skewedWorker is a worker whose clock is ahead of the coordinator clock by offset
+type skewedWorker struct {
+	*benchmarking.Worker
+	offset time.Duration
+}
+
+func (w *skewedWorker) Clock(args benchmarking.ClockArgs, reply *benchmarking.ClockReply) error {
+	err := w.Worker.Clock(args, reply)
+	reply.UnixNano += w.offset.Nanoseconds()
+	return err
+}
+
+func (w *skewedWorker) Burst(args benchmarking.BurstArgs, reply *benchmarking.BurstReply) error {
+	args.StartAtUnixNano -= w.offset.Nanoseconds()
+	return w.Worker.Burst(args, reply)
+}
+
+func (w *skewedWorker) Records(args benchmarking.RecordsArgs, reply *benchmarking.RecordsReply) error {
+	err := w.Worker.Records(args, reply)
+	for i := range reply.Records {
+		reply.Records[i].SentAt = reply.Records[i].SentAt.Add(w.offset)
+		reply.Records[i].ReceivedAt = reply.Records[i].ReceivedAt.Add(w.offset)
+	}
+	return err
+}
+
+func TestDistributedBurstsWithClockOffset(t *testing.T) {
+	config, function := newLocalExperiment(t)
+
+	var addresses []string
+	for _, offset := range []time.Duration{time.Hour, -90 * time.Minute} {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer listener.Close()
+		server := rpc.NewServer()
+		require.NoError(t, server.RegisterName(benchmarking.WorkerServiceName, &skewedWorker{Worker: &benchmarking.Worker{}, offset: offset}))
+		go server.Accept(listener)
+		addresses = append(addresses, listener.Addr().String())
+	}
+	workerPool := benchmarking.ConnectWorkers(addresses)
+	defer workerPool.Close()
+
+	outputPath := t.TempDir()
+	startedAt := time.Now()
+	benchmarking.TriggerSubExperiments(config, outputPath, -1, workerPool)
+	finishedAt := time.Now()
+
+	served, _ := function.requests()
+	require.Equal(t, 10, served)
+	latencies := readLatencies(t, outputPath)
+	require.Len(t, latencies, 10)
+	for _, row := range latencies {
+		// The timestamps of both workers are moved back to the coordinator clock
+		sentAt, err := time.Parse(time.RFC3339Nano, row[2])
+		require.NoError(t, err)
+		require.WithinRange(t, sentAt, startedAt, finishedAt)
+		receivedAt, err := time.Parse(time.RFC3339Nano, row[3])
+		require.NoError(t, err)
+		require.WithinRange(t, receivedAt, sentAt, finishedAt)
+	}
+}
+
+func TestWorkerStreamsRecords(t *testing.T) {
+	config, _ := newLocalExperiment(t)
+
+	// The first request is answered straight away, the second one only once its record was collected
+	release := make(chan struct{})
+	var served int32
+	function := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&served, 1) > 1 {
+			<-release
+		}
+		_, _ = w.Write([]byte(`{"RequestID": "request", "TimestampChain": ["1"]}`))
+	}))
+	defer function.Close()
+	defer close(release)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	go benchmarking.ServeWorker(listener, t.TempDir())
+	client, err := rpc.Dial("tcp", listener.Addr().String())
+	require.NoError(t, err)
+	defer client.Close()
+
+	var burst benchmarking.BurstReply
+	require.NoError(t, client.Call(benchmarking.WorkerServiceName+".Burst", benchmarking.BurstArgs{
+		Provider:        "gcr",
+		Requests:        2,
+		StartAtUnixNano: time.Now().UnixNano(),
+		Endpoint:        setup.EndpointInfo{ID: strings.TrimPrefix(function.URL, "https://")},
+		BusySpin:        config.SubExperiments[0].BusySpinAt(0),
+	}, &burst))
+
+	var records benchmarking.RecordsReply
+	require.NoError(t, client.Call(benchmarking.WorkerServiceName+".Records", benchmarking.RecordsArgs{WorkerBurstID: burst.WorkerBurstID}, &records))
+	require.Len(t, records.Records, 1)
+	require.False(t, records.Done)
+
+	release <- struct{}{}
+	records = benchmarking.RecordsReply{}
+	require.NoError(t, client.Call(benchmarking.WorkerServiceName+".Records", benchmarking.RecordsArgs{WorkerBurstID: burst.WorkerBurstID}, &records))
+	require.Len(t, records.Records, 1)
+	require.True(t, records.Done)
+}
@@ -0,0 +1,98 @@
+package benchmarking
+
+import (
+	"github.com/stretchr/testify/require"
+	"stellar/benchmarking"
+	"stellar/setup"
+	"testing"
+)
+
+func TestComputeTransferSegments(t *testing.T) {
+	// the function timestamps are Unix milliseconds, 1609459200000 being 2021-01-01T00:00:00Z
+	header := []string{"Request ID", "Host", "Burst ID", "Sent At", "Received At", "Timestamp 0", "Timestamp 1", "Timestamp 2"}
+
+	testCases := []struct {
+		name        string
+		chainLength int
+		records     [][]string
+		expected    []benchmarking.TransferSegment
+	}{
+		{
+			name:        "single function",
+			chainLength: 1,
+			records: [][]string{
+				{"a", "host", "0", "2021-01-01T00:00:00Z", "2021-01-01T00:00:00.030Z", "1609459200010"},
+			},
+			expected: []benchmarking.TransferSegment{
+				{Name: "Client to Function 0", Latencies: []float64{10}},
+				{Name: "Function 0 to Client", Latencies: []float64{20}},
+				{Name: "Function Chain", Latencies: []float64{0}},
+			},
+		},
+		{
+			name:        "three functions",
+			chainLength: 3,
+			records: [][]string{
+				{"a", "host", "0", "2021-01-01T00:00:00Z", "2021-01-01T00:00:00.100Z", "1609459200005", "1609459200025", "1609459200085"},
+				{"b", "host", "1", "2021-01-01T00:00:01Z", "2021-01-01T00:00:01.050Z", "1609459201001", "1609459201011", "1609459201041.5"},
+			},
+			expected: []benchmarking.TransferSegment{
+				{Name: "Client to Function 0", Latencies: []float64{5, 1}},
+				{Name: "Function 0 to Function 1", Latencies: []float64{20, 10}},
+				{Name: "Function 1 to Function 2", Latencies: []float64{60, 30.5}},
+				{Name: "Function 2 to Client", Latencies: []float64{15, 8.5}},
+				{Name: "Function Chain", Latencies: []float64{80, 40.5}},
+			},
+		},
+		{
+			name:        "client clock ahead of the functions",
+			chainLength: 2,
+			records: [][]string{
+				{"a", "host", "0", "2021-01-01T00:00:00Z", "2021-01-01T00:00:00.040Z", "1609459199997", "1609459200017"},
+			},
+			expected: []benchmarking.TransferSegment{
+				{Name: "Client to Function 0", Latencies: []float64{-3}},
+				{Name: "Function 0 to Function 1", Latencies: []float64{20}},
+				{Name: "Function 1 to Client", Latencies: []float64{23}},
+				{Name: "Function Chain", Latencies: []float64{20}},
+			},
+		},
+		{
+			name:        "incomplete and malformed chains are skipped",
+			chainLength: 2,
+			records: [][]string{
+				{"short", "host", "0", "2021-01-01T00:00:00Z", "2021-01-01T00:00:00.040Z", "1609459200010"},
+				{"bad-sent", "host", "0", "yesterday", "2021-01-01T00:00:00.040Z", "1609459200010", "1609459200020"},
+				{"bad-timestamp", "host", "0", "2021-01-01T00:00:00Z", "2021-01-01T00:00:00.040Z", "1609459200010", "NaN-ish"},
+				{"a", "host", "0", "2021-01-01T00:00:00Z", "2021-01-01T00:00:00.040Z", "1609459200010", "1609459200020"},
+			},
+			expected: []benchmarking.TransferSegment{
+				{Name: "Client to Function 0", Latencies: []float64{10}},
+				{Name: "Function 0 to Function 1", Latencies: []float64{10}},
+				{Name: "Function 1 to Client", Latencies: []float64{20}},
+				{Name: "Function Chain", Latencies: []float64{10}},
+			},
+		},
+		{
+			name:        "no complete chains",
+			chainLength: 2,
+			records: [][]string{
+				{"short", "host", "0", "2021-01-01T00:00:00Z", "2021-01-01T00:00:00.040Z", "1609459200010"},
+			},
+			expected: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			experiment := setup.SubExperiment{ID: 1, DataTransferChainLength: testCase.chainLength}
+			segments := benchmarking.ComputeTransferSegments(experiment, append([][]string{header}, testCase.records...))
+
+			require.Len(t, segments, len(testCase.expected))
+			for i, expected := range testCase.expected {
+				require.Equal(t, expected.Name, segments[i].Name)
+				require.InDeltaSlice(t, expected.Latencies, segments[i].Latencies, 1e-6, expected.Name)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package benchmarking
+
+import (
+	"github.com/stretchr/testify/require"
+	"net/http/httptest"
+	"stellar/benchmarking"
+	"strings"
+	"testing"
+)
+
+func TestProgressMetrics(t *testing.T) {
+	config, _ := newLocalExperiment(t)
+	progress := benchmarking.TrackProgress(config, -1)
+
+	recorder := httptest.NewRecorder()
+	progress.ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	require.Contains(t, recorder.Body.String(), `stellar_requests_planned{sub_experiment="0",title="local"} 10`)
+	require.Contains(t, recorder.Body.String(), `stellar_current_burst{sub_experiment="0",title="local"} -1`)
+
+	benchmarking.TriggerSubExperiments(config, t.TempDir(), -1, nil)
+
+	var metrics strings.Builder
+	progress.WriteMetrics(&metrics)
+	require.Contains(t, metrics.String(), "# TYPE stellar_request_latency_milliseconds histogram")
+	for _, sample := range []string{
+		`stellar_requests_sent_total{sub_experiment="0",title="local"} 10`,
+		`stellar_requests_completed_total{sub_experiment="0",title="local"} 10`,
+		`stellar_request_latency_milliseconds_bucket{sub_experiment="0",title="local",le="+Inf"} 10`,
+		`stellar_request_latency_milliseconds_count{sub_experiment="0",title="local"} 10`,
+		`stellar_bursts_completed_total{sub_experiment="0",title="local"} 2`,
+		`stellar_current_burst{sub_experiment="0",title="local"} 1`,
+		`stellar_eta_seconds{sub_experiment="0",title="local"} 0`,
+		"stellar_run_eta_seconds 0",
+	} {
+		require.Contains(t, metrics.String(), sample+"\n")
+	}
+
+	var view strings.Builder
+	progress.Render(&view)
+	require.Contains(t, view.String(), "[0] local")
+	require.Contains(t, view.String(), "100%  burst 2/2  requests 10/10  errors 0")
+}
@@ -0,0 +1,48 @@
+package benchmarking
+
+import (
+	"bufio"
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"stellar/benchmarking"
+	"stellar/tracing"
+	"strings"
+	"testing"
+)
+
+func TestRequestTracing(t *testing.T) {
+	config, function := newLocalExperiment(t)
+	tracePath := filepath.Join(t.TempDir(), "traces.json")
+	shutdownTracing := tracing.Initialize("stellar run", "", tracePath)
+
+	benchmarking.TriggerSubExperiments(config, t.TempDir(), -1, nil)
+	shutdownTracing()
+
+	type span struct {
+		Name        string
+		SpanContext struct{ TraceID string }
+	}
+	spansByName := map[string][]span{}
+	traceFile, err := os.Open(tracePath)
+	require.NoError(t, err)
+	defer traceFile.Close()
+	for decoder := json.NewDecoder(bufio.NewReader(traceFile)); decoder.More(); {
+		var exported span
+		require.NoError(t, decoder.Decode(&exported))
+		spansByName[exported.Name] = append(spansByName[exported.Name], exported)
+	}
+
+	require.Len(t, spansByName["stellar run"], 1)
+	require.Len(t, spansByName["sub-experiment"], 1)
+	require.Len(t, spansByName["burst"], 2)
+	require.Len(t, spansByName["request"], 10)
+
+	traceID := spansByName["stellar run"][0].SpanContext.TraceID
+	_, traceParents := function.requests()
+	require.Len(t, traceParents, 10)
+	for _, traceParent := range traceParents {
+		require.True(t, strings.HasPrefix(traceParent, "00-"+traceID+"-"), traceParent)
+	}
+}
@@ -0,0 +1,21 @@
+package benchmarking
+
+import (
+	"github.com/stretchr/testify/require"
+	"stellar/benchmarking"
+	"stellar/setup"
+	"testing"
+)
+
+func TestGenerateIATIsSeeded(t *testing.T) {
+	experiment := setup.SubExperiment{ID: 1, Bursts: 20, IATSeconds: 3, IATType: "stochastic"}
+
+	burstDeltas := benchmarking.GenerateIAT(experiment, 42)
+
+	require.Len(t, burstDeltas, 20)
+	require.Equal(t, burstDeltas, benchmarking.GenerateIAT(experiment, 42))
+	require.NotEqual(t, burstDeltas, benchmarking.GenerateIAT(experiment, 43))
+
+	experiment.ID = 2
+	require.NotEqual(t, burstDeltas, benchmarking.GenerateIAT(experiment, 42))
+}
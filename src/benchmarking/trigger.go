@@ -25,26 +25,65 @@ package benchmarking
 import (
 	"fmt"
 	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"math"
 	"math/rand"
 	"os"
 	"path/filepath"
+	"stellar/benchmarking/visualization"
 	"stellar/benchmarking/writers"
 	"stellar/setup"
+	"stellar/tracing"
 	"sync"
 	"time"
 )
 
+// CompletedLatencies records the sorted latencies of each finished sub-experiment. It is safe for concurrent use.
+type CompletedLatencies struct {
+	mu   sync.Mutex
+	byID map[int][]float64
+}
+
+func (c *CompletedLatencies) Record(experimentID int, sortedLatencies []float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.byID == nil {
+		c.byID = make(map[int][]float64)
+	}
+	c.byID[experimentID] = sortedLatencies
+}
+
+// CompletedCosts sums the costs estimated from the recorded durations of the finished sub-experiments.
+// It is safe for concurrent use.
+type CompletedCosts struct {
+	mu    sync.Mutex
+	total setup.CostEstimate
+}
+
+func (c *CompletedCosts) Record(estimate setup.CostEstimate) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.total = c.total.Add(estimate)
+}
+
 // TriggerSubExperiments will run the sub-experiments specified by the passed configuration object. It creates
-// a directory for each sub-experiment, as well as separate visualizations and latency files.
-func TriggerSubExperiments(config setup.Configuration, outputDirectoryPath string, specificExperiment int) {
+// a directory for each sub-experiment, as well as separate visualizations and latency files. Once all
+// sub-experiments have finished, the comparisons across them are rendered. The resources of the client are sampled
+// throughout the run. The bursts are split across the workers of the pool if not nil, and sent by this client otherwise.
+func TriggerSubExperiments(config setup.Configuration, outputDirectoryPath string, specificExperiment int, workerPool *WorkerPool) {
 	var experimentsWaitGroup sync.WaitGroup
+	clientMonitor := StartClientMonitor(filepath.Join(outputDirectoryPath, "client-stats.csv"), clientStatsInterval)
+	defer clientMonitor.Stop()
+	completedLatencies := &CompletedLatencies{}
+	completedCosts := &CompletedCosts{}
+	pricing := config.PricingModel()
 
 	switch specificExperiment {
 	case -1: // run all experiments
 		for experimentIndex := 0; experimentIndex < len(config.SubExperiments); experimentIndex++ {
 			experimentsWaitGroup.Add(1)
-			go triggerSubExperiment(&experimentsWaitGroup, config.Provider, config.SubExperiments[experimentIndex], outputDirectoryPath)
+			go triggerSubExperiment(&experimentsWaitGroup, config.Provider, config.Seed, workerPool, config.SubExperiments[experimentIndex], outputDirectoryPath, pricing, clientMonitor, completedLatencies, completedCosts)
 
 			if config.Sequential {
 				experimentsWaitGroup.Wait()
@@ -56,13 +95,21 @@ func TriggerSubExperiments(config setup.Configuration, outputDirectoryPath strin
 		}
 
 		experimentsWaitGroup.Add(1)
-		go triggerSubExperiment(&experimentsWaitGroup, config.Provider, config.SubExperiments[specificExperiment], outputDirectoryPath)
+		go triggerSubExperiment(&experimentsWaitGroup, config.Provider, config.Seed, workerPool, config.SubExperiments[specificExperiment], outputDirectoryPath, pricing, clientMonitor, completedLatencies, completedCosts)
 	}
 
 	experimentsWaitGroup.Wait()
+
+	log.Infof("Estimated cost of the run from the recorded durations is $%.4f (%.0f requests, %.1f GB-s).",
+		completedCosts.total.TotalUSD(), completedCosts.total.Usage.Requests, completedCosts.total.Usage.GBSeconds)
+
+	if len(config.Comparisons) > 0 {
+		visualization.GenerateComparisons(config.Comparisons, config.SubExperiments, completedLatencies.byID, outputDirectoryPath)
+	}
 }
 
-func triggerSubExperiment(experimentsWaitGroup *sync.WaitGroup, provider string, experiment setup.SubExperiment, outputDirectoryPath string) {
+func triggerSubExperiment(experimentsWaitGroup *sync.WaitGroup, provider string, seed int64, workerPool *WorkerPool, experiment setup.SubExperiment, outputDirectoryPath string,
+	pricing setup.Pricing, clientMonitor *ClientMonitor, completedLatencies *CompletedLatencies, completedCosts *CompletedCosts) {
 	log.Infof("[sub-experiment %d] Starting...", experiment.ID)
 	defer experimentsWaitGroup.Done()
 
@@ -73,7 +120,7 @@ func triggerSubExperiment(experimentsWaitGroup *sync.WaitGroup, provider string,
 		defer dataTransfersFile.Close()
 	}
 
-	burstDeltas := generateIAT(experiment)
+	burstDeltas := GenerateIAT(experiment, seed)
 
 	log.Infof("[sub-experiment %d] Started benchmarking, scheduling %d bursts with IAT ~%vs and %d gateways (bursts/gateways*freq=%v)",
 		experiment.ID, experiment.Bursts, experiment.IATSeconds, len(experiment.Endpoints),
@@ -82,9 +129,23 @@ func triggerSubExperiment(experimentsWaitGroup *sync.WaitGroup, provider string,
 	latenciesWriter := writers.NewRTTLatencyWriter(latenciesFile)
 	dataTransferWriter := writers.NewDataTransferWriter(dataTransfersFile, experiment.DataTransferChainLength)
 
-	runSubExperiment(experiment, burstDeltas, provider, latenciesWriter, dataTransferWriter)
-
-	postProcessing(experiment, latenciesFile, burstDeltas, experimentDirectoryPath, statisticsFile)
+	ctx, span := tracing.Tracer().Start(tracing.RunContext(), "sub-experiment", trace.WithAttributes(
+		attribute.Int("stellar.sub_experiment", experiment.ID),
+		attribute.String("stellar.title", experiment.Title),
+	))
+	startTime := time.Now()
+	runProgress.subExperimentStarted(experiment.ID)
+	runSubExperiment(ctx, experiment, burstDeltas, provider, workerPool, latenciesWriter, dataTransferWriter)
+	runProgress.subExperimentFinished(experiment.ID)
+	span.End()
+	markClientSaturation(clientMonitor, experiment, experimentDirectoryPath, startTime, time.Now())
+
+	sortedLatencies, recordedCost := postProcessing(experiment, pricing, latenciesFile, burstDeltas, experimentDirectoryPath, statisticsFile)
+	if dataTransfersFile != nil {
+		postProcessDataTransfers(experiment, dataTransfersFile, experimentDirectoryPath)
+	}
+	completedLatencies.Record(experiment.ID, sortedLatencies)
+	completedCosts.Record(recordedCost)
 
 	log.Infof("[sub-experiment %d] Successfully finished.", experiment.ID)
 }
@@ -127,7 +188,11 @@ func createSubExperimentOutput(path string, experiment setup.SubExperiment) (str
 	return directoryPath, latenciesFile, statisticsFile, nil
 }
 
-func generateIAT(experiment setup.SubExperiment) []time.Duration {
+// GenerateIAT returns the inter-arrival times of the bursts of the sub-experiment. Each sub-experiment draws from its
+// own source derived from the seed of the run, so that the same seed gives the same times regardless of the order in
+// which sub-experiments run.
+func GenerateIAT(experiment setup.SubExperiment, seed int64) []time.Duration {
+	random := rand.New(rand.NewSource(seed + int64(experiment.ID)<<32))
 	step := 1.0
 	maxStep := experiment.IATSeconds
 	runningDelta := math.Min(maxStep, experiment.IATSeconds)
@@ -137,7 +202,7 @@ func generateIAT(experiment setup.SubExperiment) []time.Duration {
 	for i := range burstDeltas {
 		switch experiment.IATType {
 		case "stochastic":
-			burstDeltas[i] = time.Duration(getSpinTime(experiment.IATSeconds)*1000) * time.Millisecond
+			burstDeltas[i] = time.Duration(getSpinTime(random, experiment.IATSeconds)*1000) * time.Millisecond
 		case "deterministic":
 			burstDeltas[i] = time.Duration(experiment.IATSeconds) * time.Second
 		case "step":
@@ -150,14 +215,14 @@ func generateIAT(experiment setup.SubExperiment) []time.Duration {
 			runningDelta += step
 		default:
 			log.Errorf("[sub-experiment %d] Unrecognized inter-arrival time type %s, using default: stochastic", experiment.ID, experiment.IATType)
-			burstDeltas[i] = time.Duration(getSpinTime(experiment.IATSeconds)*1000) * time.Millisecond
+			burstDeltas[i] = time.Duration(getSpinTime(random, experiment.IATSeconds)*1000) * time.Millisecond
 		}
 	}
 	return burstDeltas
 }
 
 // Use a shifted and scaled exponential distribution to guarantee a minimum sleep time
-func getSpinTime(frequencySeconds float64) float64 {
+func getSpinTime(random *rand.Rand, frequencySeconds float64) float64 {
 	rateParameter := 1 / math.Log(frequencySeconds) // experimentally deduced formula
-	return frequencySeconds + rand.ExpFloat64()/rateParameter
+	return frequencySeconds + random.ExpFloat64()/rateParameter
 }
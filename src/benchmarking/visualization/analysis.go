@@ -0,0 +1,599 @@
+// MIT License
+//
+// Copyright (c) 2021 Theodor Amariucai and EASE Lab
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package visualization
+
+import (
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"gonum.org/v1/gonum/stat"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/plotutil"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"image/color"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	// imageSizeSamplesLimit caps the latencies used per image size experiment, as the original analysis did.
+	imageSizeSamplesLimit = 3000
+	// cpuStatsInfraConstantMs is the fixed infrastructure delay subtracted before fitting the CPU slowdown.
+	cpuStatsInfraConstantMs = 50.
+	tailPercentile          = 0.99
+)
+
+// AnalyzeBurstiness plots the CDF families of warm and cold bursts found under the given path, i.e., one line per
+// burst size with the median and tail latencies marked. Sub-experiments with an IAT below the cold IAT threshold
+// are considered warm. Figures are written next to the results.
+func AnalyzeBurstiness(path string, provider string, coldIATThresholdSeconds float64) {
+	resultDirectories := loadResultDirectoriesOrFatal(path)
+
+	warmLatencies := make(map[int][]float64)
+	coldLatencies := make(map[int][]float64)
+	for _, resultDirectory := range resultDirectories {
+		sortedLatencies, err := resultDirectory.ReadSortedLatencies()
+		if err != nil {
+			log.Errorf("Could not read latencies from `%s`: %s", resultDirectory.Path, err.Error())
+			continue
+		}
+
+		isWarm := resultDirectory.IATSeconds < coldIATThresholdSeconds
+		sortedLatencies = filterProviderLatencies(provider, isWarm, sortedLatencies)
+		if isWarm || resultDirectory.BurstSize == 1 {
+			// the first requests of a warm experiment (or every single-request burst) still hit cold instances
+			sortedLatencies = dropLargestLatencies(sortedLatencies, resultDirectory.BurstSize)
+		}
+		if len(sortedLatencies) == 0 {
+			log.Warnf("No latencies left to analyze in `%s`", resultDirectory.Path)
+			continue
+		}
+
+		plotAnnotatedCDFs(
+			filepath.Join(resultDirectory.Path, "empirical-CDF.png"),
+			fmt.Sprintf("Burst size %d, IAT ~%vs (%s)", resultDirectory.BurstSize, resultDirectory.IATSeconds, provider),
+			[]string{""},
+			[][]float64{sortedLatencies},
+		)
+
+		if isWarm {
+			warmLatencies[resultDirectory.BurstSize] = sortedLatencies
+		} else {
+			coldLatencies[resultDirectory.BurstSize] = sortedLatencies
+		}
+	}
+
+	title := fmt.Sprintf("%s Bursty Behavior Analysis", provider)
+	saveTiledPlots(filepath.Join(path, title+".png"), 10*vg.Inch, 5*vg.Inch,
+		newBurstSizesCDFPlot(fmt.Sprintf("Warm (IAT < %vs)", coldIATThresholdSeconds), warmLatencies),
+		newBurstSizesCDFPlot(fmt.Sprintf("Cold (IAT >= %vs)", coldIATThresholdSeconds), coldLatencies),
+	)
+
+	for _, burstSize := range sortedIntKeys(warmLatencies) {
+		if _, ok := coldLatencies[burstSize]; !ok {
+			continue
+		}
+
+		plotAnnotatedCDFs(
+			filepath.Join(path, fmt.Sprintf("burst%d-dual-IAT-CDF.png", burstSize)),
+			fmt.Sprintf("Burst size %d (%s)", burstSize, provider),
+			[]string{"Warm", "Cold"},
+			[][]float64{warmLatencies[burstSize], coldLatencies[burstSize]},
+		)
+	}
+}
+
+// AnalyzeImageSize plots how the median and tail latencies of cold starts grow with the function image size,
+// one line per burst size, together with the latency CDFs of each image size.
+func AnalyzeImageSize(path string, provider string) {
+	resultDirectories := loadResultDirectoriesOrFatal(path)
+	sort.SliceStable(resultDirectories, func(i, j int) bool {
+		return resultDirectories[i].ImageSizeMB < resultDirectories[j].ImageSizeMB
+	})
+
+	latenciesByBurstSize := make(map[int]map[int][]float64)
+	for _, resultDirectory := range resultDirectories {
+		latencies, err := resultDirectory.ReadLatencies()
+		if err != nil {
+			log.Errorf("Could not read latencies from `%s`: %s", resultDirectory.Path, err.Error())
+			continue
+		}
+		if len(latencies) > imageSizeSamplesLimit {
+			latencies = latencies[:imageSizeSamplesLimit]
+		}
+		if len(latencies) == 0 {
+			continue
+		}
+		sort.Float64s(latencies)
+
+		if _, ok := latenciesByBurstSize[resultDirectory.BurstSize]; !ok {
+			latenciesByBurstSize[resultDirectory.BurstSize] = make(map[int][]float64)
+		}
+		latenciesByBurstSize[resultDirectory.BurstSize][resultDirectory.ImageSizeMB] = latencies
+	}
+	if len(latenciesByBurstSize) == 0 {
+		log.Fatalf("No image size experiments found in `%s`", path)
+	}
+
+	serviceTime := resultDirectories[0].ServiceTime
+	burstSizes := sortedIntKeys(latenciesByBurstSize)
+
+	tailPlot := newLatencyPlot(fmt.Sprintf("%v%% percentile", tailPercentile*100), "Image Size (MB)")
+	medianPlot := newLatencyPlot("Median (50% percentile)", "Image Size (MB)")
+	for index, burstSize := range burstSizes {
+		var tails, medians plotter.XYs
+		for _, imageSize := range sortedIntKeys(latenciesByBurstSize[burstSize]) {
+			sortedLatencies := latenciesByBurstSize[burstSize][imageSize]
+			tails = append(tails, plotter.XY{X: float64(imageSize), Y: percentileOf(sortedLatencies, tailPercentile)})
+			medians = append(medians, plotter.XY{X: float64(imageSize), Y: percentileOf(sortedLatencies, 0.5)})
+		}
+
+		label := fmt.Sprintf("Burst Size %d", burstSize)
+		addAnnotatedLinePoints(tailPlot, tails, index, "", false)
+		addAnnotatedLinePoints(medianPlot, medians, index, label, false)
+	}
+	medianPlot.Legend.Top = true
+	medianPlot.Legend.Left = true
+
+	title := fmt.Sprintf("%s Image Fetch Delay (Service Time %v)", provider, serviceTime)
+	saveTiledPlots(filepath.Join(path, title+".png"), 10*vg.Inch, 5*vg.Inch, tailPlot, medianPlot)
+
+	cdfPlots := make([]*plot.Plot, 0, len(burstSizes))
+	for _, burstSize := range burstSizes {
+		imageSizes := sortedIntKeys(latenciesByBurstSize[burstSize])
+		labels := make([]string, len(imageSizes))
+		latencies := make([][]float64, len(imageSizes))
+		for index, imageSize := range imageSizes {
+			labels[index] = fmt.Sprintf("Image Size %dMB", imageSize)
+			latencies[index] = latenciesByBurstSize[burstSize][imageSize]
+		}
+		cdfPlots = append(cdfPlots, newAnnotatedCDFPlot(fmt.Sprintf("Burst Size %d", burstSize), labels, latencies, false))
+	}
+
+	title = fmt.Sprintf("%s Image Fetch Tail Latencies (Service Time %v)", provider, serviceTime)
+	saveTiledPlots(filepath.Join(path, title+".png"), vg.Length(len(cdfPlots))*5*vg.Inch, 5*vg.Inch, cdfPlots...)
+}
+
+// AnalyzeDataTransfer breaks down the latency of warm data transfer chains, comparing the client round trip time
+// against the time elapsed between the first and the last function timestamps, for each chain length.
+func AnalyzeDataTransfer(path string, provider string, warmIATThresholdSeconds float64) {
+	resultDirectories := loadResultDirectoriesOrFatal(path)
+	sort.SliceStable(resultDirectories, func(i, j int) bool {
+		return resultDirectories[i].PayloadKB < resultDirectories[j].PayloadKB
+	})
+
+	type transferPoints struct{ rttTails, rttMedians, chainTails, chainMedians plotter.XYs }
+	pointsByChainLength := make(map[int]*transferPoints)
+	for _, resultDirectory := range resultDirectories {
+		if resultDirectory.IATSeconds > warmIATThresholdSeconds || resultDirectory.PayloadKB <= 0 {
+			continue
+		}
+
+		sortedLatencies, err := resultDirectory.ReadSortedLatencies()
+		if err != nil || len(sortedLatencies) == 0 {
+			log.Errorf("Could not read latencies from `%s`: %v", resultDirectory.Path, err)
+			continue
+		}
+		chainLength, chainDurations, err := resultDirectory.ReadChainDurations()
+		if err != nil || len(chainDurations) == 0 {
+			log.Errorf("Could not read data transfers from `%s`: %v", resultDirectory.Path, err)
+			continue
+		}
+		sort.Float64s(chainDurations)
+
+		points, ok := pointsByChainLength[chainLength]
+		if !ok {
+			points = &transferPoints{}
+			pointsByChainLength[chainLength] = points
+		}
+		payload := float64(resultDirectory.PayloadKB)
+		points.rttTails = append(points.rttTails, plotter.XY{X: payload, Y: percentileOf(sortedLatencies, tailPercentile)})
+		points.rttMedians = append(points.rttMedians, plotter.XY{X: payload, Y: percentileOf(sortedLatencies, 0.5)})
+		points.chainTails = append(points.chainTails, plotter.XY{X: payload, Y: percentileOf(chainDurations, tailPercentile)})
+		points.chainMedians = append(points.chainMedians, plotter.XY{X: payload, Y: percentileOf(chainDurations, 0.5)})
+	}
+	if len(pointsByChainLength) == 0 {
+		log.Fatalf("No warm data transfer experiments found in `%s`", path)
+	}
+
+	transferType := "Inline"
+	if strings.Contains(strings.ToLower(path), "storage") {
+		transferType = "Storage"
+	}
+
+	tailPlot := newTransferPlot(fmt.Sprintf("%v%% percentile", tailPercentile*100), "Latency (ms)")
+	medianPlot := newTransferPlot("Median (50% percentile)", "Latency (ms)")
+	bandwidthPlot := newTransferPlot("", "Network Bandwidth (MB/s)")
+	for index, chainLength := range sortedIntKeys(pointsByChainLength) {
+		points := pointsByChainLength[chainLength]
+		label := fmt.Sprintf("Chain length %d", chainLength)
+
+		addAnnotatedLinePoints(tailPlot, points.rttTails, index, label+" (round trip)", true)
+		addAnnotatedLinePoints(tailPlot, points.chainTails, index, label+" (internal timestamps)", false)
+		addAnnotatedLinePoints(medianPlot, points.rttMedians, index, "", true)
+		addAnnotatedLinePoints(medianPlot, points.chainMedians, index, "", false)
+		addAnnotatedLinePoints(bandwidthPlot, toBandwidth(points.rttMedians), index, label+" (round trip)", true)
+		addAnnotatedLinePoints(bandwidthPlot, toBandwidth(points.chainMedians), index, label+" (internal timestamps)", false)
+	}
+	tailPlot.Legend.Top = true
+	tailPlot.Legend.Left = true
+	bandwidthPlot.Legend.Top = true
+	bandwidthPlot.Legend.Left = true
+
+	title := fmt.Sprintf("%s %s Transfer Latency", provider, transferType)
+	saveTiledPlots(filepath.Join(path, title+".png"), 10*vg.Inch, 5*vg.Inch, tailPlot, medianPlot)
+
+	bandwidthPlot.Title.Text = fmt.Sprintf("%s %s Transfer Bandwidth", provider, transferType)
+	if err := bandwidthPlot.Save(7*vg.Inch, 5*vg.Inch, filepath.Join(path, bandwidthPlot.Title.Text+".png")); err != nil {
+		log.Errorf("Could not save transfer bandwidth plot: %s", err.Error())
+	}
+}
+
+// AnalyzeCPUStats fits, for each function memory size, the slowdown of the function service time as seen by the
+// client (latency = slowdown x service time + infrastructure constant) and plots the resulting CPU utilization.
+func AnalyzeCPUStats(path string, provider string) {
+	resultDirectories := loadResultDirectoriesOrFatal(path)
+
+	pointsByMemory := make(map[int]plotter.XYs)
+	for _, resultDirectory := range resultDirectories {
+		latencies, err := resultDirectory.ReadLatencies()
+		if err != nil {
+			log.Errorf("Could not read latencies from `%s`: %s", resultDirectory.Path, err.Error())
+			continue
+		}
+
+		memory := int(resultDirectory.FunctionMemoryMB)
+		serviceTimeMs := float64(resultDirectory.ServiceTime.Milliseconds())
+		for _, latency := range latencies {
+			pointsByMemory[memory] = append(pointsByMemory[memory], plotter.XY{X: serviceTimeMs, Y: latency})
+		}
+	}
+	if len(pointsByMemory) == 0 {
+		log.Fatalf("No CPU stats experiments found in `%s`", path)
+	}
+
+	log.Infof("Infrastructure constant: %.2fms", cpuStatsInfraConstantMs)
+
+	slowdownPlot := newLatencyPlot(fmt.Sprintf("%s CPU Slowdown", provider), "Service Time (ms)")
+	utilizationPlot := plot.New()
+	utilizationPlot.Title.Text = fmt.Sprintf("%s CPU Utilization Rates", provider)
+	utilizationPlot.X.Label.Text = "Function Memory (MB)"
+	utilizationPlot.Y.Label.Text = "Fraction"
+	utilizationPlot.Y.Min = 0.
+	utilizationPlot.Y.Max = 1.
+	utilizationPlot.Add(plotter.NewGrid())
+
+	var utilizations plotter.XYs
+	for index, memory := range sortedIntKeys(pointsByMemory) {
+		points := pointsByMemory[memory]
+		slowdown := fitSlowdown(points, cpuStatsInfraConstantMs)
+		log.Infof("Function memory %dMB: slowdown %.2f", memory, slowdown)
+
+		scatter, err := plotter.NewScatter(points)
+		if err != nil {
+			log.Errorf("Could not plot latencies for %dMB: %s", memory, err.Error())
+			continue
+		}
+		scatter.GlyphStyle.Color = plotutil.Color(index)
+		scatter.GlyphStyle.Radius = vg.Points(2)
+
+		maxServiceTime := 0.
+		for _, point := range points {
+			maxServiceTime = math.Max(maxServiceTime, point.X)
+		}
+		fit, err := plotter.NewLine(plotter.XYs{
+			{X: 0, Y: cpuStatsInfraConstantMs},
+			{X: maxServiceTime * 1.25, Y: slowdown*maxServiceTime*1.25 + cpuStatsInfraConstantMs},
+		})
+		if err != nil {
+			log.Errorf("Could not plot slowdown fit for %dMB: %s", memory, err.Error())
+			continue
+		}
+		fit.Color = plotutil.Color(index)
+
+		slowdownPlot.Add(scatter, fit)
+		slowdownPlot.Legend.Add(fmt.Sprintf("Slowdown %.2f (%dMB)", slowdown, memory), fit)
+
+		utilizations = append(utilizations, plotter.XY{X: float64(memory), Y: 1 / slowdown})
+	}
+	slowdownPlot.Legend.Top = true
+	slowdownPlot.Legend.Left = true
+
+	if err := slowdownPlot.Save(10*vg.Inch, 5*vg.Inch, filepath.Join(path, slowdownPlot.Title.Text+".png")); err != nil {
+		log.Errorf("Could not save CPU slowdown plot: %s", err.Error())
+	}
+
+	addAnnotatedLinePoints(utilizationPlot, utilizations, 0, "", true)
+	if err := utilizationPlot.Save(5*vg.Inch, 5*vg.Inch, filepath.Join(path, utilizationPlot.Title.Text+".png")); err != nil {
+		log.Errorf("Could not save CPU utilization plot: %s", err.Error())
+	}
+}
+
+func loadResultDirectoriesOrFatal(path string) []ResultDirectory {
+	resultDirectories, err := LoadResultDirectories(path)
+	if err != nil {
+		log.Fatalf("Could not load results from `%s`: %s", path, err.Error())
+	}
+	if len(resultDirectories) == 0 {
+		log.Fatalf("No sub-experiment results found in `%s`", path)
+	}
+	log.Infof("Found %d sub-experiment results in `%s`", len(resultDirectories), path)
+	return resultDirectories
+}
+
+// filterProviderLatencies separates warm from cold requests on providers where the IAT alone does not guarantee it
+func filterProviderLatencies(provider string, isWarm bool, sortedLatencies []float64) []float64 {
+	keep := func(latency float64) bool { return true }
+	switch strings.ToLower(provider) {
+	case "azure":
+		if !isWarm {
+			keep = func(latency float64) bool { return latency > 200 }
+		}
+	case "google":
+		if isWarm {
+			keep = func(latency float64) bool { return latency < 400 }
+		} else {
+			keep = func(latency float64) bool { return latency > 600 }
+		}
+	}
+
+	filtered := make([]float64, 0, len(sortedLatencies))
+	for _, latency := range sortedLatencies {
+		if keep(latency) {
+			filtered = append(filtered, latency)
+		}
+	}
+	return filtered
+}
+
+func dropLargestLatencies(sortedLatencies []float64, count int) []float64 {
+	if count >= len(sortedLatencies) {
+		return nil
+	}
+	return sortedLatencies[:len(sortedLatencies)-count]
+}
+
+func percentileOf(sortedLatencies []float64, percentile float64) float64 {
+	return stat.Quantile(percentile, stat.Empirical, sortedLatencies, nil)
+}
+
+// fitSlowdown returns the least-squares slope through (0, infraConstant) of latency against service time
+func fitSlowdown(points plotter.XYs, infraConstant float64) float64 {
+	var numerator, denominator float64
+	for _, point := range points {
+		numerator += point.X * (point.Y - infraConstant)
+		denominator += point.X * point.X
+	}
+	if denominator == 0 {
+		return 0
+	}
+	return numerator / denominator
+}
+
+// toBandwidth converts transfer latencies (ms) against payload sizes (KB) into MB/s
+func toBandwidth(points plotter.XYs) plotter.XYs {
+	bandwidth := make(plotter.XYs, len(points))
+	for i, point := range points {
+		bandwidth[i] = plotter.XY{X: point.X, Y: (point.X / 1024) / (point.Y / 1000)}
+	}
+	return bandwidth
+}
+
+func sortedIntKeys[V any](values map[int]V) []int {
+	keys := make([]int, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Ints(keys)
+	return keys
+}
+
+func newLatencyPlot(title string, xLabel string) *plot.Plot {
+	plotInstance := plot.New()
+	plotInstance.Title.Text = title
+	plotInstance.X.Label.Text = xLabel
+	plotInstance.Y.Label.Text = "Latency (ms)"
+	plotInstance.Add(plotter.NewGrid())
+	return plotInstance
+}
+
+func newTransferPlot(title string, yLabel string) *plot.Plot {
+	plotInstance := newLatencyPlot(title, "Transfer Size (KB)")
+	plotInstance.Y.Label.Text = yLabel
+	// log scale for better transfer latencies and bandwidth visualization
+	plotInstance.X.Scale = plot.LogScale{}
+	plotInstance.X.Tick.Marker = plot.LogTicks{Prec: -1}
+	return plotInstance
+}
+
+// addAnnotatedLinePoints adds a line with its Y values written next to each point
+func addAnnotatedLinePoints(plotInstance *plot.Plot, points plotter.XYs, colorIndex int, label string, dashed bool) {
+	if len(points) == 0 {
+		return
+	}
+
+	line, scatter, err := plotter.NewLinePoints(points)
+	if err != nil {
+		log.Errorf("Could not add line points: %s", err.Error())
+		return
+	}
+	line.Color = plotutil.Color(colorIndex)
+	scatter.Color = plotutil.Color(colorIndex)
+	scatter.Shape = plotutil.Shape(colorIndex)
+	if dashed {
+		line.Dashes = plotutil.Dashes(1)
+	}
+
+	annotations := make([]string, len(points))
+	for i, point := range points {
+		annotations[i] = fmt.Sprintf("%.0f", point.Y)
+	}
+	labels, err := plotter.NewLabels(plotter.XYLabels{XYs: points, Labels: annotations})
+	if err != nil {
+		log.Errorf("Could not annotate line points: %s", err.Error())
+		return
+	}
+	for i := range labels.TextStyle {
+		labels.TextStyle[i].XAlign = draw.XLeft
+		labels.Offset = vg.Point{X: vg.Points(4), Y: vg.Points(2)}
+	}
+
+	plotInstance.Add(line, scatter, labels)
+	if label != "" {
+		plotInstance.Legend.Add(label, line, scatter)
+	}
+}
+
+// newBurstSizesCDFPlot creates a CDF plot with one line per burst size
+func newBurstSizesCDFPlot(title string, latenciesByBurstSize map[int][]float64) *plot.Plot {
+	burstSizes := sortedIntKeys(latenciesByBurstSize)
+	labels := make([]string, len(burstSizes))
+	latencies := make([][]float64, len(burstSizes))
+	for index, burstSize := range burstSizes {
+		labels[index] = fmt.Sprintf("Burst Size %d", burstSize)
+		latencies[index] = latenciesByBurstSize[burstSize]
+	}
+	return newAnnotatedCDFPlot(title, labels, latencies, true)
+}
+
+// latencyMarker is a percentile highlighted on a CDF plot
+type latencyMarker struct {
+	percentile  float64
+	labelColor  color.Color
+	labelHeight float64
+}
+
+// newAnnotatedCDFPlot creates a CDF plot of the sorted latencies, marking the tail latency of each line (and the
+// median, if requested) with a vertical dashed line.
+func newAnnotatedCDFPlot(title string, labels []string, sortedLatencies [][]float64, markMedian bool) *plot.Plot {
+	plotInstance := plot.New()
+	plotInstance.Title.Text = title
+	plotInstance.X.Label.Text = "Latency (ms)"
+	plotInstance.X.Min = 0.
+	plotInstance.Y.Label.Text = "Portion of requests"
+	plotInstance.Y.Min = 0.
+	plotInstance.Y.Max = 1.
+	plotInstance.Add(plotter.NewGrid())
+	plotInstance.Legend.Left = false
+	plotInstance.Legend.Top = false
+
+	for index, latencies := range sortedLatencies {
+		if len(latencies) == 0 {
+			continue
+		}
+
+		latenciesToPlot := make(plotter.XYs, len(latencies))
+		for i := range latencies {
+			latenciesToPlot[i].X = latencies[i]
+			latenciesToPlot[i].Y = stat.CDF(latencies[i], stat.Empirical, latencies, nil)
+		}
+		line, err := plotter.NewLine(latenciesToPlot)
+		if err != nil {
+			log.Errorf("Could not add CDF line: %s", err.Error())
+			continue
+		}
+		line.Color = plotutil.Color(index)
+		line.Width = vg.Points(1.5)
+		plotInstance.Add(line)
+		if labels[index] != "" {
+			plotInstance.Legend.Add(labels[index], line)
+		}
+
+		markers := []latencyMarker{{percentile: tailPercentile, labelColor: color.RGBA{R: 200, A: 255}, labelHeight: 0.1}}
+		if markMedian {
+			markers = append(markers, latencyMarker{percentile: 0.5, labelColor: color.Black, labelHeight: 0.5})
+		}
+		for _, marker := range markers {
+			addVerticalMarker(plotInstance, percentileOf(latencies, marker.percentile), plotutil.Color(index),
+				marker.labelHeight+0.1*float64(index+1), marker.labelColor)
+		}
+	}
+
+	return plotInstance
+}
+
+// addVerticalMarker draws a dashed vertical line at the given latency, annotated with its value
+func addVerticalMarker(plotInstance *plot.Plot, latency float64, lineColor color.Color, labelHeight float64, labelColor color.Color) {
+	marker, err := plotter.NewLine(plotter.XYs{{X: latency, Y: 0}, {X: latency, Y: 1}})
+	if err != nil {
+		log.Errorf("Could not add latency marker: %s", err.Error())
+		return
+	}
+	marker.Color = lineColor
+	marker.Dashes = plotutil.Dashes(1)
+
+	label, err := plotter.NewLabels(plotter.XYLabels{
+		XYs:    plotter.XYs{{X: latency, Y: math.Min(labelHeight, 0.95)}},
+		Labels: []string{fmt.Sprintf("%.0fms", latency)},
+	})
+	if err != nil {
+		log.Errorf("Could not annotate latency marker: %s", err.Error())
+		return
+	}
+	label.TextStyle[0].Color = labelColor
+	label.Offset = vg.Point{X: vg.Points(3)}
+
+	plotInstance.Add(marker, label)
+}
+
+func plotAnnotatedCDFs(plotPath string, title string, labels []string, sortedLatencies [][]float64) {
+	plotInstance := newAnnotatedCDFPlot(title, labels, sortedLatencies, true)
+	if err := plotInstance.Save(5*vg.Inch, 5*vg.Inch, plotPath); err != nil {
+		log.Errorf("Could not save CDF plot `%s`: %s", plotPath, err.Error())
+	}
+}
+
+// saveTiledPlots draws the plots side by side in a single figure, in the format given by the file extension
+func saveTiledPlots(plotPath string, width vg.Length, height vg.Length, plots ...*plot.Plot) {
+	if len(plots) == 0 {
+		return
+	}
+
+	canvas, err := draw.NewFormattedCanvas(width, height, strings.TrimPrefix(filepath.Ext(plotPath), "."))
+	if err != nil {
+		log.Errorf("Could not create canvas for `%s`: %s", plotPath, err.Error())
+		return
+	}
+
+	tiles := draw.Tiles{Rows: 1, Cols: len(plots), PadX: vg.Millimeter, PadY: vg.Millimeter,
+		PadTop: vg.Points(2), PadBottom: vg.Points(2), PadLeft: vg.Points(2), PadRight: vg.Points(2)}
+	canvases := plot.Align([][]*plot.Plot{plots}, tiles, draw.New(canvas))
+	for i, plotInstance := range plots {
+		plotInstance.Draw(canvases[0][i])
+	}
+
+	file, err := os.Create(plotPath)
+	if err != nil {
+		log.Errorf("Could not create `%s`: %s", plotPath, err.Error())
+		return
+	}
+	defer file.Close()
+
+	if _, err := canvas.WriteTo(file); err != nil {
+		log.Errorf("Could not write `%s`: %s", plotPath, err.Error())
+	}
+}
@@ -47,6 +47,8 @@ func Generate(experiment setup.SubExperiment, deltas []time.Duration, latenciesD
 		generateCDFs(experiment, sortedLatencies, path)
 		generateHistograms(experiment, latenciesDF, path, deltas)
 		generateBarCharts(experiment, latenciesDF, defaultColdThreshold, path)
+		generateTimelines(experiment, latenciesDF, defaultColdThreshold, path)
+		generateHeatmap(experiment, latenciesDF, path, deltas)
 	case "bar":
 		log.Infof("[sub-experiment %d] Generating burst bar chart visualization", experiment.ID)
 		generateBarCharts(experiment, latenciesDF, defaultColdThreshold, path)
@@ -56,6 +58,12 @@ func Generate(experiment setup.SubExperiment, deltas []time.Duration, latenciesD
 	case "histogram":
 		log.Infof("[sub-experiment %d] Generating histograms visualizations (per-burst)", experiment.ID)
 		generateHistograms(experiment, latenciesDF, path, deltas)
+	case "timeline":
+		log.Infof("[sub-experiment %d] Generating latency timeline visualizations", experiment.ID)
+		generateTimelines(experiment, latenciesDF, defaultColdThreshold, path)
+	case "heatmap":
+		log.Infof("[sub-experiment %d] Generating per-burst latency heatmap visualization", experiment.ID)
+		generateHeatmap(experiment, latenciesDF, path, deltas)
 	case "none":
 		log.Warnf("[sub-experiment %d] No visualization selected, skipping", experiment.ID)
 	default:
@@ -71,6 +79,18 @@ func Generate(experiment setup.SubExperiment, deltas []time.Duration, latenciesD
 				coldThreshold,
 			)
 			generateBarCharts(experiment, latenciesDF, coldThreshold, path)
+		} else if strings.Contains(experiment.Visualization, "timeline") {
+			coldThreshold, err := strconv.ParseFloat(strings.Split(experiment.Visualization, "-")[1], 64)
+			if err != nil {
+				log.Errorf("[sub-experiment %d] Could not parse timeline threshold latency, using default.", experiment.ID)
+				coldThreshold = defaultColdThreshold
+			}
+
+			log.Infof("[sub-experiment %d] Generating latency timeline visualizations (cold threshold %vms)",
+				experiment.ID,
+				coldThreshold,
+			)
+			generateTimelines(experiment, latenciesDF, coldThreshold, path)
 		} else {
 			log.Errorf("[sub-experiment %d] Unrecognized visualization `%s`, skipping", experiment.ID, experiment.Visualization)
 		}
@@ -79,7 +99,18 @@ func Generate(experiment setup.SubExperiment, deltas []time.Duration, latenciesD
 
 func generateBarCharts(experiment setup.SubExperiment, latenciesDF dataframe.DataFrame, coldThreshold float64, path string) {
 	log.Debugf("[sub-experiment %d] Plotting characterization bar chart", experiment.ID)
-	plotBurstsBarChart(filepath.Join(path, "bursts_characterization.png"), experiment, coldThreshold, latenciesDF)
+	plotBurstsBarChart(filepath.Join(path, plotFileName("bursts_characterization", experiment.PlotOptions)), experiment, coldThreshold, latenciesDF)
+}
+
+func generateTimelines(experiment setup.SubExperiment, latenciesDF dataframe.DataFrame, coldThreshold float64, path string) {
+	log.Debugf("[sub-experiment %d] Plotting latency timelines", experiment.ID)
+	plotLatencyTimeline(filepath.Join(path, plotFileName("timeline_bursts", experiment.PlotOptions)), experiment, latenciesDF, coldThreshold, false)
+	plotLatencyTimeline(filepath.Join(path, plotFileName("timeline_cold_warm", experiment.PlotOptions)), experiment, latenciesDF, coldThreshold, true)
+}
+
+func generateHeatmap(experiment setup.SubExperiment, latenciesDF dataframe.DataFrame, path string, deltas []time.Duration) {
+	log.Debugf("[sub-experiment %d] Plotting per-burst latency heatmap", experiment.ID)
+	plotBurstsLatencyHeatmap(filepath.Join(path, plotFileName("bursts_heatmap", experiment.PlotOptions)), experiment, latenciesDF, deltas)
 }
 
 func generateHistograms(experiment setup.SubExperiment, latenciesDF dataframe.DataFrame, path string, deltas []time.Duration) {
@@ -93,10 +124,11 @@ func generateHistograms(experiment setup.SubExperiment, latenciesDF dataframe.Da
 	for burstIndex := 0; burstIndex < experiment.Bursts; burstIndex++ {
 		burstDF := latenciesDF.Filter(dataframe.F{Colname: "Burst ID", Comparator: series.Eq, Comparando: burstIndex})
 		plotBurstLatenciesHistogram(
-			filepath.Join(histogramsDirectoryPath, fmt.Sprintf("burst%d_delta%v.png", burstIndex, deltas[burstIndex])),
+			filepath.Join(histogramsDirectoryPath, plotFileName(fmt.Sprintf("burst%d_delta%v", burstIndex, deltas[burstIndex]), experiment.PlotOptions)),
 			burstDF.Col("Client Latency (ms)").Float(),
 			burstIndex,
 			deltas[burstIndex],
+			experiment.PlotOptions,
 		)
 	}
 }
@@ -104,8 +136,82 @@ func generateHistograms(experiment setup.SubExperiment, latenciesDF dataframe.Da
 func generateCDFs(config setup.SubExperiment, sortedLatencies []float64, path string) {
 	log.Debugf("[sub-experiment %d] Plotting latencies CDF", config.ID)
 	plotLatenciesCDF(
-		filepath.Join(path, "empirical_CDF.png"),
+		filepath.Join(path, plotFileName("empirical_CDF", config.PlotOptions)),
 		sortedLatencies,
 		config,
 	)
 }
+
+//GenerateDataTransfers will plot the CDFs of the data transfer chain segments (e.g., every hop between
+//consecutive functions) of the sub-experiment, unless its visualizations are disabled.
+func GenerateDataTransfers(experiment setup.SubExperiment, transferMethod string, segmentNames []string,
+	sortedSegmentLatencies [][]float64, path string) {
+	if experiment.Visualization == "none" {
+		return
+	}
+
+	log.Infof("[sub-experiment %d] Generating data transfer segments CDF visualization", experiment.ID)
+	plotDataTransferCDFs(
+		filepath.Join(path, plotFileName("data_transfer_CDF", experiment.PlotOptions)),
+		experiment,
+		transferMethod,
+		segmentNames,
+		sortedSegmentLatencies,
+	)
+}
+
+//GenerateComparisons will create the plots comparing several sub-experiments, as described in the
+//configuration-level comparisons, from the sorted latencies of each finished sub-experiment.
+func GenerateComparisons(comparisons []setup.Comparison, subExperiments []setup.SubExperiment,
+	sortedLatenciesByID map[int][]float64, path string) {
+	comparisonsDirectoryPath := filepath.Join(path, "comparisons")
+	log.Infof("Creating directory for comparisons at `%s`", comparisonsDirectoryPath)
+	if err := os.MkdirAll(comparisonsDirectoryPath, os.ModePerm); err != nil {
+		log.Fatal(err)
+	}
+
+	for _, comparison := range comparisons {
+		var compared []setup.SubExperiment
+		for _, title := range comparison.SubExperiments {
+			subExperiment, found := findSubExperimentByTitle(subExperiments, title)
+			if !found {
+				log.Errorf("[comparison %s] Unrecognized sub-experiment `%s`, skipping it", comparison.Title, title)
+				continue
+			}
+			if _, ran := sortedLatenciesByID[subExperiment.ID]; !ran {
+				log.Warnf("[comparison %s] Sub-experiment `%s` did not run, skipping it", comparison.Title, title)
+				continue
+			}
+			compared = append(compared, subExperiment)
+		}
+
+		if len(compared) == 0 {
+			log.Errorf("[comparison %s] No sub-experiments to compare, skipping", comparison.Title)
+			continue
+		}
+
+		plotPath := filepath.Join(comparisonsDirectoryPath, fmt.Sprintf("%s_%s.png", comparison.Title, comparison.PlotType))
+		switch comparison.PlotType {
+		case "cdf":
+			log.Infof("[comparison %s] Generating CDF overlay of %d sub-experiments", comparison.Title, len(compared))
+			plotComparisonCDFs(plotPath, comparison, compared, sortedLatenciesByID)
+		case "box":
+			log.Infof("[comparison %s] Generating box plot of %d sub-experiments", comparison.Title, len(compared))
+			plotComparisonBoxPlot(plotPath, comparison, compared, sortedLatenciesByID)
+		case "percentile":
+			log.Infof("[comparison %s] Generating percentiles against %s of %d sub-experiments", comparison.Title, comparison.Parameter, len(compared))
+			plotComparisonPercentiles(plotPath, comparison, compared, sortedLatenciesByID)
+		default:
+			log.Errorf("[comparison %s] Unrecognized comparison plot type `%s`, skipping", comparison.Title, comparison.PlotType)
+		}
+	}
+}
+
+func findSubExperimentByTitle(subExperiments []setup.SubExperiment, title string) (setup.SubExperiment, bool) {
+	for _, subExperiment := range subExperiments {
+		if subExperiment.Title == title {
+			return subExperiment, true
+		}
+	}
+	return setup.SubExperiment{}, false
+}
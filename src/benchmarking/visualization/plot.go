@@ -29,14 +29,24 @@ import (
 	log "github.com/sirupsen/logrus"
 	"gonum.org/v1/gonum/stat"
 	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/palette"
+	"gonum.org/v1/plot/palette/moreland"
 	"gonum.org/v1/plot/plotter"
 	"gonum.org/v1/plot/plotutil"
 	"gonum.org/v1/plot/vg"
+	"image/color"
+	"math"
+	"sort"
 	"stellar/setup"
 	"strings"
 	"time"
 )
 
+const (
+	heatmapLatencyBins   = 1 << 5
+	heatmapPaletteColors = 1 << 6
+)
+
 func plotBurstsBarChart(plotPath string, experiment setup.SubExperiment, coldThreshold float64, latenciesDF dataframe.DataFrame) {
 	plotInstance := plot.New()
 
@@ -100,12 +110,12 @@ func plotBurstsBarChart(plotPath string, experiment setup.SubExperiment, coldThr
 	}
 	plotInstance.NominalX(strings.Split(strings.Trim(fmt.Sprint(augmentedBurstSizes), "[]"), " ")...)
 
-	if err := plotInstance.Save(10*vg.Inch, 5*vg.Inch, plotPath); err != nil {
+	if err := savePlot(plotInstance, 10*vg.Inch, 5*vg.Inch, plotPath, experiment.PlotOptions); err != nil {
 		log.Errorf("[sub-experiment %d] Could not save bar chart: %s", experiment.ID, err.Error())
 	}
 }
 
-func plotBurstLatenciesHistogram(plotPath string, burstLatencies []float64, burstIndex int, duration time.Duration) {
+func plotBurstLatenciesHistogram(plotPath string, burstLatencies []float64, burstIndex int, duration time.Duration, options setup.PlotOptions) {
 	plotInstance := plot.New()
 
 	plotInstance.Title.Text = fmt.Sprintf("Burst %v Histogram (%v since last)", burstIndex, duration)
@@ -122,7 +132,7 @@ func plotBurstLatenciesHistogram(plotPath string, burstLatencies []float64, burs
 	}
 
 	plotInstance.Add(histogram)
-	if err := plotInstance.Save(5*vg.Inch, 5*vg.Inch, plotPath); err != nil {
+	if err := savePlot(plotInstance, 5*vg.Inch, 5*vg.Inch, plotPath, options); err != nil {
 		log.Errorf("Could not save bursts histogram: %s", err.Error())
 	}
 }
@@ -159,8 +169,349 @@ func plotLatenciesCDF(plotPath string, sortedLatencies []float64, experiment set
 		log.Errorf("[sub-experiment %d] Could not add line points to CDF plot: %s", experiment.ID, err.Error())
 	}
 
+	for index, percentile := range experiment.PlotOptions.AnnotatedPercentiles {
+		addVerticalMarker(plotInstance, percentileOf(sortedLatencies, percentile/100), plotutil.Color(index+1),
+			0.1*float64(index+1), color.Black)
+	}
+
 	// Save the plot to a PNG file.
-	if err := plotInstance.Save(5*vg.Inch, 5*vg.Inch, plotPath); err != nil {
+	if err := savePlot(plotInstance, 5*vg.Inch, 5*vg.Inch, plotPath, experiment.PlotOptions); err != nil {
 		log.Errorf("[sub-experiment %d] Could not save CDF plot: %s", experiment.ID, err.Error())
 	}
 }
+
+func plotLatencyTimeline(plotPath string, experiment setup.SubExperiment, latenciesDF dataframe.DataFrame, coldThreshold float64, colourByColdWarm bool) {
+	plotInstance := plot.New()
+
+	plotInstance.Title.Text = fmt.Sprintf("%v\nLatency over time (IAT ~%vs, Burst sizes %v)", experiment.Title, experiment.IATSeconds, experiment.BurstSizes)
+	plotInstance.X.Label.Text = "Time since first request (s)"
+	plotInstance.Y.Label.Text = "Latency (ms)"
+	plotInstance.Y.Min = 0.
+
+	secondsSinceStart, err := sentAtOffsetsSeconds(latenciesDF)
+	if err != nil {
+		log.Errorf("[sub-experiment %d] Could not parse request send times for timeline: %s", experiment.ID, err.Error())
+		return
+	}
+	latencies := latenciesDF.Col("Client Latency (ms)").Float()
+	burstIDs, err := latenciesDF.Col("Burst ID").Int()
+	if err != nil {
+		log.Errorf("[sub-experiment %d] Could not parse burst IDs for timeline: %s", experiment.ID, err.Error())
+		return
+	}
+
+	if colourByColdWarm {
+		warmPoints := plotter.XYs{}
+		coldPoints := plotter.XYs{}
+		for i := range latencies {
+			point := plotter.XY{X: secondsSinceStart[i], Y: latencies[i]}
+			if latencies[i] >= coldThreshold {
+				coldPoints = append(coldPoints, point)
+			} else {
+				warmPoints = append(warmPoints, point)
+			}
+		}
+
+		warmScatter := newTimelineScatter(experiment, warmPoints, plotutil.Color(3)) // orange
+		coldScatter := newTimelineScatter(experiment, coldPoints, plotutil.Color(2)) // light blue
+		if warmScatter == nil || coldScatter == nil {
+			return
+		}
+
+		plotInstance.Add(warmScatter, coldScatter)
+		plotInstance.Legend.Add("Warm Requests", warmScatter)
+		plotInstance.Legend.Add(fmt.Sprintf("Cold Requests (>= %vms)", coldThreshold), coldScatter)
+		plotInstance.Legend.Top = true
+	} else {
+		burstPoints := make(map[int]plotter.XYs)
+		for i := range latencies {
+			burstPoints[burstIDs[i]] = append(burstPoints[burstIDs[i]], plotter.XY{X: secondsSinceStart[i], Y: latencies[i]})
+		}
+
+		for burstIndex := 0; burstIndex < experiment.Bursts; burstIndex++ {
+			if len(burstPoints[burstIndex]) == 0 {
+				continue
+			}
+
+			burstScatter := newTimelineScatter(experiment, burstPoints[burstIndex], plotutil.Color(burstIndex))
+			if burstScatter == nil {
+				return
+			}
+			plotInstance.Add(burstScatter)
+		}
+	}
+	plotInstance.Add(plotter.NewGrid())
+
+	if err := savePlot(plotInstance, 10*vg.Inch, 5*vg.Inch, plotPath, experiment.PlotOptions); err != nil {
+		log.Errorf("[sub-experiment %d] Could not save timeline plot: %s", experiment.ID, err.Error())
+	}
+}
+
+func newTimelineScatter(experiment setup.SubExperiment, points plotter.XYs, pointColor color.Color) *plotter.Scatter {
+	scatter, err := plotter.NewScatter(points)
+	if err != nil {
+		log.Errorf("[sub-experiment %d] Could not add points to timeline plot: %s", experiment.ID, err.Error())
+		return nil
+	}
+	scatter.GlyphStyle.Color = pointColor
+	scatter.GlyphStyle.Radius = vg.Points(1.5)
+	return scatter
+}
+
+// sentAtOffsetsSeconds returns, for every request, the number of seconds between its `Sent At`
+// timestamp and the earliest `Sent At` timestamp of the sub-experiment.
+func sentAtOffsetsSeconds(latenciesDF dataframe.DataFrame) ([]float64, error) {
+	sentAtRecords := latenciesDF.Col("Sent At").Records()
+
+	sentAtTimes := make([]time.Time, len(sentAtRecords))
+	var earliest time.Time
+	for i, record := range sentAtRecords {
+		sentAt, err := time.Parse(time.RFC3339Nano, record)
+		if err != nil {
+			return nil, err
+		}
+		sentAtTimes[i] = sentAt
+		if i == 0 || sentAt.Before(earliest) {
+			earliest = sentAt
+		}
+	}
+
+	offsets := make([]float64, len(sentAtTimes))
+	for i, sentAt := range sentAtTimes {
+		offsets[i] = sentAt.Sub(earliest).Seconds()
+	}
+	return offsets, nil
+}
+
+// burstLatencyGrid holds, for each burst (column), the portion of its requests falling into each latency bin (row).
+type burstLatencyGrid struct {
+	portions     [][]float64
+	binMin       float64
+	binWidth     float64
+	binsPerBurst int
+}
+
+func (g burstLatencyGrid) Dims() (c, r int)   { return len(g.portions), g.binsPerBurst }
+func (g burstLatencyGrid) Z(c, r int) float64 { return g.portions[c][r] }
+func (g burstLatencyGrid) X(c int) float64    { return float64(c) }
+func (g burstLatencyGrid) Y(r int) float64    { return g.binMin + (float64(r)+0.5)*g.binWidth }
+
+func plotBurstsLatencyHeatmap(plotPath string, experiment setup.SubExperiment, latenciesDF dataframe.DataFrame, deltas []time.Duration) {
+	plotInstance := plot.New()
+
+	plotInstance.Title.Text = fmt.Sprintf("%v\nLatency distribution per burst (colour: portion of burst requests)", experiment.Title)
+	plotInstance.X.Label.Text = "Burst IAT (sorted)"
+	plotInstance.Y.Label.Text = "Latency (ms)"
+
+	latencies := latenciesDF.Col("Client Latency (ms)").Float()
+	if len(latencies) == 0 {
+		log.Errorf("[sub-experiment %d] No latencies recorded, skipping heatmap.", experiment.ID)
+		return
+	}
+	minLatency, maxLatency := math.Inf(1), math.Inf(-1)
+	for _, latency := range latencies {
+		minLatency = math.Min(minLatency, latency)
+		maxLatency = math.Max(maxLatency, latency)
+	}
+
+	binWidth := (maxLatency - minLatency) / heatmapLatencyBins
+	if binWidth == 0 {
+		binWidth = 1
+	}
+
+	// Bursts are ordered by the IAT preceding them so that the effect of longer idle periods is visible left to right
+	burstOrder := make([]int, experiment.Bursts)
+	for i := range burstOrder {
+		burstOrder[i] = i
+	}
+	sort.SliceStable(burstOrder, func(i, j int) bool {
+		return deltas[burstOrder[i]] < deltas[burstOrder[j]]
+	})
+
+	grid := burstLatencyGrid{binMin: minLatency, binWidth: binWidth, binsPerBurst: heatmapLatencyBins}
+	iatLabels := make([]string, 0, len(burstOrder))
+	for _, burstIndex := range burstOrder {
+		burstDF := latenciesDF.Filter(dataframe.F{Colname: "Burst ID", Comparator: series.Eq, Comparando: burstIndex})
+		burstLatencies := burstDF.Col("Client Latency (ms)").Float()
+
+		portions := make([]float64, heatmapLatencyBins)
+		for _, latency := range burstLatencies {
+			bin := int((latency - minLatency) / binWidth)
+			if bin >= heatmapLatencyBins {
+				bin = heatmapLatencyBins - 1
+			}
+			portions[bin] += 1. / float64(len(burstLatencies))
+		}
+
+		grid.portions = append(grid.portions, portions)
+		iatLabels = append(iatLabels, deltas[burstIndex].Round(time.Second).String())
+	}
+
+	// Reversed so that latency bins without any requests are white and dense bins are dark
+	heatmap := plotter.NewHeatMap(grid, palette.Reverse(moreland.ExtendedBlackBody()).Palette(heatmapPaletteColors))
+	heatmap.Min = 0.
+	heatmap.Max = 1.
+
+	plotInstance.Add(heatmap)
+	plotInstance.NominalX(iatLabels...)
+
+	if err := savePlot(plotInstance, 10*vg.Inch, 5*vg.Inch, plotPath, experiment.PlotOptions); err != nil {
+		log.Errorf("[sub-experiment %d] Could not save heatmap: %s", experiment.ID, err.Error())
+	}
+}
+
+func plotDataTransferCDFs(plotPath string, experiment setup.SubExperiment, transferMethod string, segmentNames []string, sortedSegmentLatencies [][]float64) {
+	plotInstance := newAnnotatedCDFPlot(
+		fmt.Sprintf("%v\nData transfer segments (%s, chain length %d)", experiment.Title, transferMethod, experiment.DataTransferChainLength),
+		segmentNames,
+		sortedSegmentLatencies,
+		false,
+	)
+	// segments measured against the client clock can be negative under clock skew, so do not clamp them
+	for _, sortedLatencies := range sortedSegmentLatencies {
+		if len(sortedLatencies) > 0 {
+			plotInstance.X.Min = math.Min(plotInstance.X.Min, sortedLatencies[0])
+		}
+	}
+
+	if err := savePlot(plotInstance, 7*vg.Inch, 5*vg.Inch, plotPath, experiment.PlotOptions); err != nil {
+		log.Errorf("[sub-experiment %d] Could not save data transfer CDF plot: %s", experiment.ID, err.Error())
+	}
+}
+
+func plotComparisonCDFs(plotPath string, comparison setup.Comparison, subExperiments []setup.SubExperiment, sortedLatenciesByID map[int][]float64) {
+	plotInstance := plot.New()
+	plotInstance.Title.Text = comparison.Title
+	plotInstance.Y.Label.Text = "Portion of requests"
+	plotInstance.Y.Min = 0.
+	plotInstance.Y.Max = 1.
+	plotInstance.X.Label.Text = "Latency (ms)"
+	plotInstance.X.Min = 0.
+
+	var linesAndNames []interface{}
+	for _, subExperiment := range subExperiments {
+		sortedLatencies := sortedLatenciesByID[subExperiment.ID]
+		if len(sortedLatencies) == 0 {
+			continue
+		}
+
+		latenciesToPlot := make(plotter.XYs, len(sortedLatencies))
+		for i := 0; i < len(sortedLatencies); i++ {
+			latenciesToPlot[i].X = sortedLatencies[i]
+			latenciesToPlot[i].Y = stat.CDF(sortedLatencies[i], stat.Empirical, sortedLatencies, nil)
+		}
+		linesAndNames = append(linesAndNames, subExperiment.Title, latenciesToPlot)
+	}
+
+	if err := plotutil.AddLines(plotInstance, linesAndNames...); err != nil {
+		log.Errorf("[comparison %s] Could not add lines to CDF overlay: %s", comparison.Title, err.Error())
+		return
+	}
+
+	if err := plotInstance.Save(5*vg.Inch, 5*vg.Inch, plotPath); err != nil {
+		log.Errorf("[comparison %s] Could not save CDF overlay: %s", comparison.Title, err.Error())
+	}
+}
+
+func plotComparisonBoxPlot(plotPath string, comparison setup.Comparison, subExperiments []setup.SubExperiment, sortedLatenciesByID map[int][]float64) {
+	plotInstance := plot.New()
+	plotInstance.Title.Text = comparison.Title
+	plotInstance.Y.Label.Text = "Latency (ms)"
+
+	w := vg.Points(20)
+	titles := make([]string, 0, len(subExperiments))
+	for index, subExperiment := range subExperiments {
+		latencies := make(plotter.Values, len(sortedLatenciesByID[subExperiment.ID]))
+		copy(latencies, sortedLatenciesByID[subExperiment.ID])
+
+		box, err := plotter.NewBoxPlot(w, float64(index), latencies)
+		if err != nil {
+			log.Errorf("[comparison %s] Could not add box of sub-experiment %q: %s", comparison.Title, subExperiment.Title, err.Error())
+			return
+		}
+		plotInstance.Add(box)
+		titles = append(titles, subExperiment.Title)
+	}
+	plotInstance.NominalX(titles...)
+
+	if err := plotInstance.Save(10*vg.Inch, 5*vg.Inch, plotPath); err != nil {
+		log.Errorf("[comparison %s] Could not save box plot: %s", comparison.Title, err.Error())
+	}
+}
+
+func plotComparisonPercentiles(plotPath string, comparison setup.Comparison, subExperiments []setup.SubExperiment, sortedLatenciesByID map[int][]float64) {
+	plotInstance := plot.New()
+	plotInstance.Title.Text = comparison.Title
+	plotInstance.X.Label.Text = comparison.Parameter
+	plotInstance.Y.Label.Text = "Latency (ms)"
+	plotInstance.Y.Min = 0.
+
+	parameterValues := make([]float64, len(subExperiments))
+	for index, subExperiment := range subExperiments {
+		parameterValue, err := comparisonParameterValue(subExperiment, comparison.Parameter)
+		if err != nil {
+			log.Errorf("[comparison %s] %s", comparison.Title, err.Error())
+			return
+		}
+		parameterValues[index] = parameterValue
+	}
+
+	order := make([]int, len(subExperiments))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return parameterValues[order[i]] < parameterValues[order[j]]
+	})
+
+	var linesAndNames []interface{}
+	for _, percentile := range comparison.Percentiles {
+		points := make(plotter.XYs, 0, len(order))
+		for _, index := range order {
+			sortedLatencies := sortedLatenciesByID[subExperiments[index].ID]
+			if len(sortedLatencies) == 0 {
+				continue
+			}
+			points = append(points, plotter.XY{
+				X: parameterValues[index],
+				Y: stat.Quantile(percentile/100, stat.Empirical, sortedLatencies, nil),
+			})
+		}
+		linesAndNames = append(linesAndNames, fmt.Sprintf("p%v", percentile), points)
+	}
+
+	if err := plotutil.AddLinePoints(plotInstance, linesAndNames...); err != nil {
+		log.Errorf("[comparison %s] Could not add percentile lines: %s", comparison.Title, err.Error())
+		return
+	}
+	plotInstance.Legend.Top = true
+
+	if err := plotInstance.Save(5*vg.Inch, 5*vg.Inch, plotPath); err != nil {
+		log.Errorf("[comparison %s] Could not save percentiles plot: %s", comparison.Title, err.Error())
+	}
+}
+
+// comparisonParameterValue returns the numeric value of the named sub-experiment parameter
+func comparisonParameterValue(subExperiment setup.SubExperiment, parameter string) (float64, error) {
+	switch parameter {
+	case "FunctionMemoryMB":
+		return float64(subExperiment.FunctionMemoryMB), nil
+	case "FunctionImageSizeMB":
+		return subExperiment.FunctionImageSizeMB, nil
+	case "IATSeconds":
+		return subExperiment.IATSeconds, nil
+	case "PayloadLengthBytes":
+		return float64(subExperiment.PayloadLengthBytes), nil
+	case "DataTransferChainLength":
+		return float64(subExperiment.DataTransferChainLength), nil
+	case "Parallelism":
+		return float64(subExperiment.Parallelism), nil
+	case "BurstSize":
+		if len(subExperiment.BurstSizes) == 0 {
+			return 0, fmt.Errorf("sub-experiment %q has no burst sizes", subExperiment.Title)
+		}
+		return float64(subExperiment.BurstSizes[0]), nil
+	default:
+		return 0, fmt.Errorf("unrecognized comparison parameter `%s`", parameter)
+	}
+}
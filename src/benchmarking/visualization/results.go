@@ -0,0 +1,205 @@
+// MIT License
+//
+// Copyright (c) 2021 Theodor Amariucai and EASE Lab
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package visualization
+
+import (
+	"fmt"
+	"github.com/go-gota/gota/dataframe"
+	"github.com/go-gota/gota/series"
+	log "github.com/sirupsen/logrus"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// resultDirectoryRegex matches the sub-experiment directory names created by the benchmarking module, e.g.,
+// `parallelism1-memory128MB-img24MB-IAT10s-burst2-st0ms-payload0KB`.
+var resultDirectoryRegex = regexp.MustCompile(
+	`^(?P<title>.*)-memory(?P<memory>\d+)MB-img(?P<img>\d+)MB-IAT(?P<iat>[0-9.e+]+)s-burst(?P<burst>\d+)-st(?P<st>.+)-payload(?P<payload>\d+)KB$`)
+
+// ResultDirectory is a sub-experiment output directory, with the parameters recovered from its name.
+type ResultDirectory struct {
+	Path             string
+	Title            string
+	FunctionMemoryMB int64
+	ImageSizeMB      int
+	IATSeconds       float64
+	BurstSize        int
+	ServiceTime      time.Duration
+	PayloadKB        int
+}
+
+// LoadResultDirectories walks the given path and returns every sub-experiment directory containing
+// latencies, sorted by path.
+func LoadResultDirectories(path string) ([]ResultDirectory, error) {
+	var resultDirectories []ResultDirectory
+
+	err := filepath.WalkDir(path, func(currentPath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() {
+			return nil
+		}
+		if _, err := os.Stat(filepath.Join(currentPath, "latencies.csv")); err != nil {
+			return nil
+		}
+
+		resultDirectory, err := parseResultDirectoryName(currentPath)
+		if err != nil {
+			log.Warnf("Skipping results directory `%s`: %s", currentPath, err.Error())
+			return nil
+		}
+		resultDirectories = append(resultDirectories, resultDirectory)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(resultDirectories, func(i, j int) bool {
+		return resultDirectories[i].Path < resultDirectories[j].Path
+	})
+	return resultDirectories, nil
+}
+
+func parseResultDirectoryName(path string) (ResultDirectory, error) {
+	matches := resultDirectoryRegex.FindStringSubmatch(filepath.Base(path))
+	if matches == nil {
+		return ResultDirectory{}, fmt.Errorf("directory name does not follow the sub-experiment naming scheme")
+	}
+	group := func(name string) string {
+		return matches[resultDirectoryRegex.SubexpIndex(name)]
+	}
+
+	memory, _ := strconv.ParseInt(group("memory"), 10, 64)
+	imageSize, _ := strconv.Atoi(group("img"))
+	burstSize, _ := strconv.Atoi(group("burst"))
+	payload, _ := strconv.Atoi(group("payload"))
+	iat, err := strconv.ParseFloat(group("iat"), 64)
+	if err != nil {
+		return ResultDirectory{}, fmt.Errorf("could not parse IAT: %s", err.Error())
+	}
+	serviceTime, err := time.ParseDuration(group("st"))
+	if err != nil {
+		return ResultDirectory{}, fmt.Errorf("could not parse service time: %s", err.Error())
+	}
+
+	return ResultDirectory{
+		Path:             path,
+		Title:            group("title"),
+		FunctionMemoryMB: memory,
+		ImageSizeMB:      imageSize,
+		IATSeconds:       iat,
+		BurstSize:        burstSize,
+		ServiceTime:      serviceTime,
+		PayloadKB:        payload,
+	}, nil
+}
+
+// ReadLatencies reads the client latencies recorded in the directory in the order they were written,
+// skipping requests that did not receive a response ID (e.g., timed out).
+func (r ResultDirectory) ReadLatencies() ([]float64, error) {
+	latenciesFile, err := os.Open(filepath.Join(r.Path, "latencies.csv"))
+	if err != nil {
+		return nil, err
+	}
+	defer latenciesFile.Close()
+
+	latenciesDF := dataframe.ReadCSV(latenciesFile, dataframe.WithTypes(map[string]series.Type{
+		"Request ID": series.String,
+	}))
+	if latenciesDF.Err != nil {
+		return nil, latenciesDF.Err
+	}
+	latenciesDF = latenciesDF.Filter(dataframe.F{Colname: "Request ID", Comparator: series.Neq, Comparando: ""})
+
+	return latenciesDF.Col("Client Latency (ms)").Float(), nil
+}
+
+// ReadSortedLatencies reads the client latencies recorded in the directory, sorted in ascending order.
+func (r ResultDirectory) ReadSortedLatencies() ([]float64, error) {
+	latencies, err := r.ReadLatencies()
+	if err != nil {
+		return nil, err
+	}
+	sort.Float64s(latencies)
+	return latencies, nil
+}
+
+// ReadChainDurations reads the data transfer timestamps recorded in the directory and returns the chain length
+// together with the time (ms) elapsed between the first and the last function in each chain.
+func (r ResultDirectory) ReadChainDurations() (int, []float64, error) {
+	transfersFile, err := os.Open(filepath.Join(r.Path, "data-transfers.csv"))
+	if err != nil {
+		return 0, nil, err
+	}
+	defer transfersFile.Close()
+
+	transfersDF := dataframe.ReadCSV(transfersFile)
+	if transfersDF.Err != nil {
+		return 0, nil, transfersDF.Err
+	}
+
+	chainLength := 0
+	for _, name := range transfersDF.Names() {
+		if strings.HasPrefix(name, "Function ") && strings.HasSuffix(name, " Timestamp") {
+			chainLength++
+		}
+	}
+	if chainLength < 2 {
+		return chainLength, nil, fmt.Errorf("data transfers in `%s` record fewer than two timestamps", r.Path)
+	}
+
+	firstTimestamps := transfersDF.Col("Function 0 Timestamp").Float()
+	lastTimestamps := transfersDF.Col(fmt.Sprintf("Function %d Timestamp", chainLength-1)).Float()
+	durations := make([]float64, len(firstTimestamps))
+	for i := range durations {
+		durations[i] = lastTimestamps[i] - firstTimestamps[i]
+	}
+	return chainLength, durations, nil
+}
+
+// ProviderFromPath guesses the benchmarked provider from a results path, e.g., `latency-samples/aws/...`.
+func ProviderFromPath(path string) string {
+	lowerCasePath := strings.ToLower(path)
+	for _, provider := range []struct{ key, name string }{
+		{"aws", "AWS"},
+		{"vhive", "vHive"},
+		{"azure", "Azure"},
+		{"google", "Google"},
+		{"gcr", "GCR"},
+		{"cloudflare", "Cloudflare"},
+		{"aliyun", "Alibaba"},
+	} {
+		if strings.Contains(lowerCasePath, provider.key) {
+			return provider.name
+		}
+	}
+	return "Unknown provider"
+}
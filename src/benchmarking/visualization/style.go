@@ -0,0 +1,141 @@
+// MIT License
+//
+// Copyright (c) 2021 Theodor Amariucai and EASE Lab
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package visualization
+
+import (
+	log "github.com/sirupsen/logrus"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/font"
+	"gonum.org/v1/plot/vg"
+	"math"
+	"stellar/setup"
+)
+
+const (
+	// defaultTickFontRatio keeps tick labels proportionally smaller than the other texts, as in the plot defaults.
+	defaultTickFontRatio = 10. / 12.
+	// logAxisDecades is how far below the axis maximum a logarithmic axis starts when its minimum is not positive.
+	logAxisDecades = 1e3
+)
+
+// plotFileName returns the file name of a plot in the format requested by the sub-experiment.
+func plotFileName(name string, options setup.PlotOptions) string {
+	format := options.Format
+	if format == "" {
+		format = "png"
+	}
+	return name + "." + format
+}
+
+// savePlot applies the styling requested by the sub-experiment and saves the plot, the file format being given by
+// the plot path extension. The default size is used unless the options override it.
+func savePlot(plotInstance *plot.Plot, defaultWidth vg.Length, defaultHeight vg.Length, plotPath string, options setup.PlotOptions) error {
+	applyPlotOptions(plotInstance, options)
+
+	width, height := defaultWidth, defaultHeight
+	if options.WidthInches > 0 {
+		width = vg.Length(options.WidthInches) * vg.Inch
+	}
+	if options.HeightInches > 0 {
+		height = vg.Length(options.HeightInches) * vg.Inch
+	}
+
+	return plotInstance.Save(width, height, plotPath)
+}
+
+func applyPlotOptions(plotInstance *plot.Plot, options setup.PlotOptions) {
+	if options.Font != "" || options.FontSizePoints > 0 {
+		applyFont(plotInstance, options.Font, options.FontSizePoints)
+	}
+
+	if options.XMin != nil {
+		plotInstance.X.Min = *options.XMin
+	}
+	if options.XMax != nil {
+		plotInstance.X.Max = *options.XMax
+	}
+	if options.YMin != nil {
+		plotInstance.Y.Min = *options.YMin
+	}
+	if options.YMax != nil {
+		plotInstance.Y.Max = *options.YMax
+	}
+
+	if options.LogX {
+		setLogScale(&plotInstance.X, "X")
+	}
+	if options.LogY {
+		setLogScale(&plotInstance.Y, "Y")
+	}
+}
+
+func setLogScale(axis *plot.Axis, name string) {
+	if _, categorical := axis.Tick.Marker.(plot.ConstantTicks); categorical {
+		log.Errorf("Cannot use a logarithmic scale on the categorical %s axis, keeping it linear", name)
+		return
+	}
+
+	if axis.Min <= 0 {
+		axis.Min = axis.Max / logAxisDecades
+	}
+	if axis.Max <= 0 {
+		log.Errorf("Cannot use a logarithmic scale on an axis without positive values, keeping it linear")
+		return
+	}
+
+	axis.Scale = clippedLogScale{}
+	axis.Tick.Marker = plot.LogTicks{Prec: -1}
+}
+
+// clippedLogScale is a logarithmic scale drawing the values below the axis minimum at the minimum, such as
+// timeline offsets or bar bottoms at 0, which plot.LogScale cannot normalize.
+type clippedLogScale struct{}
+
+func (clippedLogScale) Normalize(min, max, x float64) float64 {
+	return plot.LogScale{}.Normalize(min, max, math.Max(x, min))
+}
+
+// applyFont sets the Liberation font variant (Serif, Sans or Mono) and size of every text in the plot
+func applyFont(plotInstance *plot.Plot, variant string, sizePoints float64) {
+	if variant != "" && !font.DefaultCache.Has(font.Font{Typeface: "Liberation", Variant: font.Variant(variant)}) {
+		log.Errorf("Unrecognized plot font `%s` (expected Serif, Sans or Mono), using the default", variant)
+		variant = ""
+	}
+
+	setFont := func(textFont *font.Font, size vg.Length) {
+		if variant != "" {
+			textFont.Variant = font.Variant(variant)
+		}
+		if sizePoints > 0 {
+			textFont.Size = size
+		}
+	}
+
+	size := vg.Points(sizePoints)
+	setFont(&plotInstance.Title.TextStyle.Font, size)
+	setFont(&plotInstance.Legend.TextStyle.Font, size)
+	for _, axis := range []*plot.Axis{&plotInstance.X, &plotInstance.Y} {
+		setFont(&axis.Label.TextStyle.Font, size)
+		setFont(&axis.Tick.Label.Font, size*defaultTickFontRatio)
+	}
+}
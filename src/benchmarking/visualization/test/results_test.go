@@ -0,0 +1,49 @@
+package visualization
+
+import (
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"stellar/benchmarking/visualization"
+	"testing"
+	"time"
+)
+
+func writeResultDirectory(t *testing.T, root string, name string, latencies string) string {
+	path := filepath.Join(root, name)
+	require.NoError(t, os.MkdirAll(path, os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(path, "latencies.csv"),
+		[]byte("Request ID,Host,Sent At,Received At,Client Latency (ms),Burst ID\n"+latencies), 0644))
+	return path
+}
+
+func TestLoadResultDirectories(t *testing.T) {
+	root := t.TempDir()
+	path := writeResultDirectory(t, root, "parallelism1-memory128MB-img24MB-IAT600s-burst10-st1s-payload16KB",
+		"a,host,t0,t1,300,0\n,host,t0,t1,999,0\nb,host,t0,t1,100,0\n")
+	writeResultDirectory(t, root, "not-a-sub-experiment", "a,host,t0,t1,300,0\n")
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "empty-memory128MB-img0MB-IAT1s-burst1-st0s-payload0KB"), os.ModePerm))
+
+	resultDirectories, err := visualization.LoadResultDirectories(root)
+	require.NoError(t, err)
+	require.Equal(t, []visualization.ResultDirectory{{
+		Path:             path,
+		Title:            "parallelism1",
+		FunctionMemoryMB: 128,
+		ImageSizeMB:      24,
+		IATSeconds:       600,
+		BurstSize:        10,
+		ServiceTime:      time.Second,
+		PayloadKB:        16,
+	}}, resultDirectories)
+
+	sortedLatencies, err := resultDirectories[0].ReadSortedLatencies()
+	require.NoError(t, err)
+	require.Equal(t, []float64{100, 300}, sortedLatencies)
+}
+
+func TestProviderFromPath(t *testing.T) {
+	require.Equal(t, "AWS", visualization.ProviderFromPath("latency-samples/AWS/burstiness"))
+	require.Equal(t, "vHive", visualization.ProviderFromPath("../vhive/image-size"))
+	require.Equal(t, "Unknown provider", visualization.ProviderFromPath("results"))
+}
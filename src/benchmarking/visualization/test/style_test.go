@@ -0,0 +1,56 @@
+package visualization
+
+import (
+	"github.com/go-gota/gota/dataframe"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"stellar/benchmarking/visualization"
+	"stellar/setup"
+	"testing"
+	"time"
+)
+
+func TestGenerateWithLogScales(t *testing.T) {
+	latenciesDF := dataframe.LoadRecords([][]string{
+		{"Request ID", "Host", "Sent At", "Received At", "Client Latency (ms)", "Burst ID"},
+		{"a", "host", "2021-01-01T00:00:00Z", "2021-01-01T00:00:01Z", "0", "0"},
+		{"b", "host", "2021-01-01T00:00:00.5Z", "2021-01-01T00:00:01Z", "120", "0"},
+		{"c", "host", "2021-01-01T00:00:10Z", "2021-01-01T00:00:11Z", "450", "1"},
+		{"d", "host", "2021-01-01T00:00:10.2Z", "2021-01-01T00:00:11Z", "80", "1"},
+	})
+	sortedLatencies := []float64{0, 80, 120, 450}
+	deltas := []time.Duration{0, 10 * time.Second}
+
+	for _, options := range []setup.PlotOptions{
+		{Format: "png", LogX: true},
+		{Format: "png", LogY: true},
+		{Format: "svg", LogX: true, LogY: true},
+	} {
+		path := t.TempDir()
+		experiment := setup.SubExperiment{
+			ID:                      1,
+			Title:                   "log-scales",
+			Bursts:                  2,
+			BurstSizes:              []int{2},
+			IATSeconds:              10,
+			Visualization:           "all",
+			DataTransferChainLength: 2,
+			PlotOptions:             options,
+		}
+
+		require.NotPanics(t, func() {
+			visualization.Generate(experiment, deltas, latenciesDF, sortedLatencies, path)
+			visualization.GenerateDataTransfers(experiment, "inline", []string{"client to function 1", "function 1 to 2"},
+				[][]float64{{-3, 5, 12}, {0, 4, 9}}, path)
+		}, "options %+v", options)
+
+		for _, name := range []string{"empirical_CDF", "bursts_characterization", "timeline_bursts",
+			"timeline_cold_warm", "bursts_heatmap", "data_transfer_CDF", "histograms/burst0_delta0s"} {
+			require.FileExists(t, filepath.Join(path, name+"."+options.Format), "options %+v", options)
+		}
+		histograms, err := os.ReadDir(filepath.Join(path, "histograms"))
+		require.NoError(t, err)
+		require.Len(t, histograms, 2)
+	}
+}
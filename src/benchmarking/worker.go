@@ -0,0 +1,200 @@
+// MIT License
+//
+// Copyright (c) 2021 Theodor Amariucai and EASE Lab
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package benchmarking
+
+import (
+	"context"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"net"
+	"net/rpc"
+	"stellar/setup"
+	"stellar/setup/deployment/connection"
+	"stellar/tracing"
+	"sync"
+	"time"
+)
+
+// WorkerServiceName is the name under which workers register their RPC service
+const WorkerServiceName = "Worker"
+
+// Worker sends the share of the bursts assigned to it by a coordinator and streams the records back.
+type Worker struct {
+	endpointsDirectoryPath string
+	initializeOnce         sync.Once
+
+	mu         sync.Mutex
+	lastBurst  uint64
+	burstsByID map[uint64]*workerBurst
+}
+
+// workerBurst holds the outcomes of the requests of a burst that the coordinator has not collected yet
+type workerBurst struct {
+	mu       sync.Mutex
+	updated  *sync.Cond
+	records  []RequestRecord
+	failures map[string]int
+	pending  int
+}
+
+// ClockArgs is the (empty) request of Worker.Clock
+type ClockArgs struct{}
+
+// ClockReply carries the time of the worker clock when the request was served
+type ClockReply struct {
+	UnixNano int64
+}
+
+// BurstArgs describes the share of a burst a worker should send, starting at StartAtUnixNano on the worker clock.
+type BurstArgs struct {
+	Provider           string
+	SubExperimentID    int
+	BurstID            int
+	Requests           int
+	StartAtUnixNano    int64
+	Endpoint           setup.EndpointInfo
+	Route              string
+	BusySpin           setup.BusySpin
+	PayloadLengthBytes int
+	StorageTransfer    bool
+	Workload           setup.WorkloadProfile
+	// TraceContext propagates the span of the burst on the coordinator, the parent of the request spans
+	TraceContext map[string]string
+}
+
+// BurstReply identifies the burst scheduled by Worker.Burst, whose records are then collected with Worker.Records
+type BurstReply struct {
+	WorkerBurstID uint64
+}
+
+// RecordsArgs identifies the burst to collect the records of
+type RecordsArgs struct {
+	WorkerBurstID uint64
+}
+
+// RecordsReply carries the records of the requests that succeeded since the previous call, timed on the worker clock,
+// and the number of those that failed by class of failure. Done is set once all requests of the burst are reported.
+type RecordsReply struct {
+	Records  []RequestRecord
+	Failures map[string]int
+	Done     bool
+}
+
+// Clock reports the current time of the worker, used by the coordinator to estimate the clock offset.
+func (w *Worker) Clock(_ ClockArgs, reply *ClockReply) error {
+	reply.UnixNano = time.Now().UnixNano()
+	return nil
+}
+
+// Burst schedules the burst to send all its requests at once at its start time, and returns without waiting for them.
+func (w *Worker) Burst(args BurstArgs, reply *BurstReply) error {
+	w.initializeOnce.Do(func() {
+		// Only AWS requests need a provider connection, to be signed
+		if args.Provider == "aws" {
+			connection.Initialize(args.Provider, w.endpointsDirectoryPath, "./setup/deployment/raw-code/functions/producer-consumer/api-template.json")
+		}
+	})
+
+	burst := &workerBurst{failures: map[string]int{}, pending: args.Requests}
+	burst.updated = sync.NewCond(&burst.mu)
+
+	w.mu.Lock()
+	w.lastBurst++
+	reply.WorkerBurstID = w.lastBurst
+	if w.burstsByID == nil {
+		w.burstsByID = make(map[uint64]*workerBurst)
+	}
+	w.burstsByID[reply.WorkerBurstID] = burst
+	w.mu.Unlock()
+
+	go burst.send(args)
+	return nil
+}
+
+// Records waits until requests of the burst completed since the previous call, then returns their outcomes.
+func (w *Worker) Records(args RecordsArgs, reply *RecordsReply) error {
+	w.mu.Lock()
+	burst, ok := w.burstsByID[args.WorkerBurstID]
+	w.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("unknown burst %d", args.WorkerBurstID)
+	}
+
+	burst.mu.Lock()
+	for len(burst.records) == 0 && len(burst.failures) == 0 && burst.pending > 0 {
+		burst.updated.Wait()
+	}
+	reply.Records, burst.records = burst.records, nil
+	reply.Failures, burst.failures = burst.failures, map[string]int{}
+	reply.Done = burst.pending == 0
+	burst.mu.Unlock()
+
+	if reply.Done {
+		w.mu.Lock()
+		delete(w.burstsByID, args.WorkerBurstID)
+		w.mu.Unlock()
+	}
+	return nil
+}
+
+// send waits for the start time of the burst, then sends all its requests at once.
+func (b *workerBurst) send(args BurstArgs) {
+	startAt := time.Unix(0, args.StartAtUnixNano)
+	if delay := time.Until(startAt); delay > 0 {
+		time.Sleep(delay)
+	} else {
+		log.Warnf("[sub-experiment %d] Burst %d started %v late on this worker.", args.SubExperimentID, args.BurstID, -delay)
+	}
+
+	log.Infof("[sub-experiment %d] Starting burst %d, making %d requests to gateway with ID %q of provider %q.",
+		args.SubExperimentID, args.BurstID, args.Requests, args.Endpoint.ID, args.Provider)
+
+	ctx := tracing.Extract(context.Background(), args.TraceContext)
+	for i := 0; i < args.Requests; i++ {
+		go func() {
+			record, failure := executeRequest(ctx, args.Provider, args.BusySpin, args.BurstID, args.PayloadLengthBytes, args.Endpoint,
+				args.StorageTransfer, args.Route, args.Workload)
+
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			if failure != "" {
+				b.failures[failure]++
+			} else {
+				b.records = append(b.records, record)
+			}
+			b.pending--
+			b.updated.Broadcast()
+		}()
+	}
+}
+
+// ServeWorker serves the bursts of coordinators connecting to the listener until it is closed.
+func ServeWorker(listener net.Listener, endpointsDirectoryPath string) {
+	server := rpc.NewServer()
+	if err := server.RegisterName(WorkerServiceName, &Worker{endpointsDirectoryPath: endpointsDirectoryPath}); err != nil {
+		log.Fatalf("Could not register worker service: %s", err.Error())
+	}
+
+	log.Infof("Worker listening for coordinators on %s.", listener.Addr())
+	server.Accept(listener)
+}
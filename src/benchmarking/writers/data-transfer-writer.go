@@ -54,6 +54,8 @@ func NewDataTransferWriter(file *os.File, chainLength int) *DataTransferWriter {
 		"Request ID",
 		"Host",
 		"Burst ID",
+		"Sent At",
+		"Received At",
 		timestampTitles...,
 	)
 
@@ -61,9 +63,10 @@ func NewDataTransferWriter(file *os.File, chainLength int) *DataTransferWriter {
 }
 
 //WriteDataTransferRow records a data transfer timestamp chain to disk.
-func (writer *DataTransferWriter) WriteDataTransferRow(awsRequestID string, host string, burstID string, timestamps ...string) {
+func (writer *DataTransferWriter) WriteDataTransferRow(awsRequestID string, host string, burstID string, sentAt string,
+	receivedAt string, timestamps ...string) {
 	writer.mux.Lock()
-	if err := writer.Writer.Write(append([]string{awsRequestID, host, burstID}, timestamps...)); err != nil {
+	if err := writer.Writer.Write(append([]string{awsRequestID, host, burstID, sentAt, receivedAt}, timestamps...)); err != nil {
 		log.Fatal(err)
 	}
 	writer.mux.Unlock()
@@ -29,6 +29,18 @@ import (
 	"sync"
 )
 
+// ServerTimingHeader names the columns recording the server-side timing envelope returned by the functions
+var ServerTimingHeader = []string{
+	"Init Duration (ms)",
+	"Execution Duration (ms)",
+	"Spin Duration (ms)",
+	"Cold Start",
+	"Handler Start (ms)",
+	"Handler End (ms)",
+	"Runtime",
+	"Memory Limit (MB)",
+}
+
 //RTTLatencyWriter records serverless RTT latencies. It is safe for concurrent use as it uses a mutual exclusion lock.
 type RTTLatencyWriter struct {
 	Writer *csv.Writer
@@ -47,15 +59,16 @@ func NewRTTLatencyWriter(file *os.File) *RTTLatencyWriter {
 		"Received At",
 		"Client Latency (ms)",
 		"Burst ID",
+		ServerTimingHeader...,
 	)
 
 	return safeExperimentWriter
 }
 
 //WriteRTTLatencyRow records round-trip time information of a request to disk.
-func (writer *RTTLatencyWriter) WriteRTTLatencyRow(awsRequestID string, host string, sentAt string, receivedAt string, clientLatencyMs string, burstID string) {
+func (writer *RTTLatencyWriter) WriteRTTLatencyRow(awsRequestID string, host string, sentAt string, receivedAt string, clientLatencyMs string, burstID string, serverTimings ...string) {
 	writer.mux.Lock()
-	if err := writer.Writer.Write([]string{awsRequestID, host, sentAt, receivedAt, clientLatencyMs, burstID}); err != nil {
+	if err := writer.Writer.Write(append([]string{awsRequestID, host, sentAt, receivedAt, clientLatencyMs, burstID}, serverTimings...)); err != nil {
 		log.Fatal(err)
 	}
 	writer.mux.Unlock()
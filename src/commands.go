@@ -0,0 +1,202 @@
+// MIT License
+//
+// Copyright (c) 2021 Theodor Amariucai and EASE Lab
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"net"
+	"os"
+	"os/signal"
+	"sort"
+	"stellar/benchmarking"
+	"stellar/benchmarking/visualization"
+	"stellar/setup"
+	"stellar/setup/deployment/connection"
+	"stellar/setup/deployment/connection/amazon"
+	"stellar/tracing"
+	"stellar/util"
+	"strings"
+	"syscall"
+)
+
+const defaultBusySpinCachePath = "busy-spin-cache.json"
+
+// subcommand runs on results that already exist, instead of benchmarking
+type subcommand struct {
+	description string
+	run         func(arguments []string)
+}
+
+var subcommands = map[string]subcommand{
+	"burstiness": {"Plot warm and cold CDF families per burst size.", runBurstinessAnalysis},
+	"imgsize":    {"Plot cold start latencies against function image sizes.", runImageSizeAnalysis},
+	"transfer":   {"Plot data transfer latency and bandwidth per chain length.", runTransferAnalysis},
+	"cpustats":   {"Plot CPU slowdown and utilization per function memory size.", runCPUStatsAnalysis},
+	"calibrate":  {"Calibrate busy-spin increments on the target platform and cache them.", runBusySpinCalibration},
+	"worker":     {"Send the bursts distributed by a coordinator run (see -workers).", runWorker},
+}
+
+// runSubcommand runs the subcommand named by the first argument, if any, and reports whether it did.
+func runSubcommand(arguments []string) bool {
+	if len(arguments) == 0 || strings.HasPrefix(arguments[0], "-") {
+		return false
+	}
+
+	command, ok := subcommands[arguments[0]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Unknown command `%s`. Available commands:\n", arguments[0])
+		printSubcommands()
+		os.Exit(2)
+	}
+
+	command.run(arguments[1:])
+	return true
+}
+
+func printSubcommands() {
+	names := make([]string, 0, len(subcommands))
+	for name := range subcommands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-12s %s\n", name, subcommands[name].description)
+	}
+}
+
+// newAnalysisFlagSet returns the flags shared by all analysis commands: the results path and the provider name.
+func newAnalysisFlagSet(name string) (*flag.FlagSet, *string, *string) {
+	flagSet := flag.NewFlagSet(name, flag.ExitOnError)
+	path := flagSet.String("path", ".", "Path where the experiment results are located.")
+	provider := flagSet.String("provider", "", "Provider name used in the plot titles (inferred from the path if empty).")
+	return flagSet, path, provider
+}
+
+func resolveProvider(path string, provider string) string {
+	if provider == "" {
+		provider = visualization.ProviderFromPath(path)
+	}
+	log.Infof("Analyzing results in `%s`, identified provider is %s", path, provider)
+	return provider
+}
+
+func runBurstinessAnalysis(arguments []string) {
+	flagSet, path, provider := newAnalysisFlagSet("burstiness")
+	coldIAT := flagSet.Float64("cold-iat", 600, "IAT (seconds) from which sub-experiments are considered cold.")
+	_ = flagSet.Parse(arguments)
+
+	visualization.AnalyzeBurstiness(*path, resolveProvider(*path, *provider), *coldIAT)
+}
+
+func runImageSizeAnalysis(arguments []string) {
+	flagSet, path, provider := newAnalysisFlagSet("imgsize")
+	_ = flagSet.Parse(arguments)
+
+	visualization.AnalyzeImageSize(*path, resolveProvider(*path, *provider))
+}
+
+func runTransferAnalysis(arguments []string) {
+	flagSet, path, provider := newAnalysisFlagSet("transfer")
+	warmIAT := flagSet.Float64("warm-iat", 50, "IAT (seconds) up to which sub-experiments are considered warm.")
+	_ = flagSet.Parse(arguments)
+
+	visualization.AnalyzeDataTransfer(*path, resolveProvider(*path, *provider), *warmIAT)
+}
+
+func runCPUStatsAnalysis(arguments []string) {
+	flagSet, path, provider := newAnalysisFlagSet("cpustats")
+	_ = flagSet.Parse(arguments)
+
+	visualization.AnalyzeCPUStats(*path, resolveProvider(*path, *provider))
+}
+
+// runBusySpinCalibration deploys a calibration function per runtime and memory size of the given configuration,
+// calibrates the busy-spin increments of all desired service times against the execution duration the functions
+// report, and persists them in the busy-spin cache for later runs.
+func runBusySpinCalibration(arguments []string) {
+	flagSet := flag.NewFlagSet("calibrate", flag.ExitOnError)
+	configPath := flagSet.String("c", "../experiments/tests/aws/hellopy.json", "Configuration file with the service times to calibrate.")
+	endpointsDirectoryPath := flagSet.String("g", "endpoints", "Directory containing provider endpoints to be used.")
+	cachePath := flagSet.String("k", defaultBusySpinCachePath, "Cache file where the calibrated busy-spin increments are stored.")
+	awsUserARNNumber := flagSet.String("a", "356764711652", "This is used in AWS benchmarking for client authentication.")
+	awsSDK := flagSet.Bool("aws-sdk", false, "Deploy AWS calibration functions through the AWS SDK instead of the serverless.com framework.")
+	awsRegion := flagSet.String("aws-region", amazon.AWSRegion, "Region of the AWS services used by the client.")
+	awsEndpointURL := flagSet.String("aws-endpoint-url", "", "Endpoint URL overriding those of the AWS services, e.g., of a local AWS emulator.")
+	_ = flagSet.Parse(arguments)
+	amazon.UserARNNumber = *awsUserARNNumber
+	amazon.AWSRegion = *awsRegion
+	amazon.EndpointURL = *awsEndpointURL
+	setup.DeployAWSWithSDK = *awsSDK
+
+	config := setup.ExtractConfiguration(*configPath)
+	if config.Provider == "vhive" {
+		log.Fatalf("Busy-spin calibration is not supported for provider %s.", config.Provider)
+	}
+	cache := setup.LoadBusySpinCache(*cachePath)
+
+	calibrationConfig := benchmarking.CalibrationConfiguration(config)
+	log.Infof("Calibrating busy-spin increments on %s with %d calibration functions.", config.Provider, len(calibrationConfig.SubExperiments))
+
+	connection.Initialize(config.Provider, *endpointsDirectoryPath, "./setup/deployment/raw-code/functions/producer-consumer/api-template.json")
+	serverlessDirPath := fmt.Sprintf("setup/deployment/raw-code/serverless/%s/", config.Provider)
+	setup.ProvisionFunctionsServerless(&calibrationConfig, serverlessDirPath, util.ExecRunner{})
+
+	benchmarking.CalibrateBusySpin(calibrationConfig, cache)
+	cache.Save()
+
+	log.Info("Starting calibration functions removal from cloud.")
+	setup.RemoveService(&calibrationConfig, serverlessDirPath, util.ExecRunner{})
+}
+
+// runWorker serves the shares of bursts assigned by coordinator runs started with -workers, until interrupted.
+func runWorker(arguments []string) {
+	flagSet := flag.NewFlagSet("worker", flag.ExitOnError)
+	listenAddress := flagSet.String("listen", "127.0.0.1:7946", "Address to listen on for coordinators.")
+	endpointsDirectoryPath := flagSet.String("g", "endpoints", "Directory containing provider endpoints to be used.")
+	otlpEndpoint := flagSet.String("otlp-endpoint", "", "OTLP/HTTP endpoint URL (e.g., http://localhost:4318) to export trace spans to.")
+	traceFile := flagSet.String("trace-file", "", "JSON file to write trace spans to, unless -otlp-endpoint is set.")
+	awsRegion := flagSet.String("aws-region", amazon.AWSRegion, "Region of the AWS services used by the client.")
+	_ = flagSet.Parse(arguments)
+	amazon.AWSRegion = *awsRegion
+
+	shutdownTracing := tracing.Initialize("stellar worker", *otlpEndpoint, *traceFile)
+	defer shutdownTracing()
+
+	listener, err := net.Listen("tcp", *listenAddress)
+	if err != nil {
+		log.Fatalf("Could not listen on %s: %s", *listenAddress, err.Error())
+	}
+
+	// Stop accepting coordinators on interrupt, so that the remaining spans are flushed
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-interrupts
+		listener.Close()
+	}()
+
+	benchmarking.ServeWorker(listener, *endpointsDirectoryPath)
+}
@@ -27,14 +27,17 @@ import (
 	"fmt"
 	log "github.com/sirupsen/logrus"
 	"io"
-	"math/rand"
 	"os"
 	"path/filepath"
 	"stellar/benchmarking"
 	"stellar/setup"
 	"stellar/setup/deployment/connection"
 	"stellar/setup/deployment/connection/amazon"
+	"stellar/setup/deployment/packaging"
+	"stellar/tracing"
+	"stellar/util"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -45,14 +48,28 @@ var endpointsDirectoryPathFlag = flag.String("g", "endpoints", "Directory contai
 var specificExperimentFlag = flag.Int("r", -1, "Only run this particular experiment.")
 var logLevelFlag = flag.String("l", "info", "Select logging level.")
 var serverlessDeployment = flag.Bool("s", true, "Use serverless.com framework for deployment. ")
+var awsRegionFlag = flag.String("aws-region", amazon.AWSRegion, "Region of the AWS services used by the client.")
+var awsEndpointURLFlag = flag.String("aws-endpoint-url", "", "Endpoint URL overriding those of the AWS services, e.g., of a local AWS emulator.")
+var awsSDKFlag = flag.Bool("aws-sdk", false, "With -s, deploy AWS functions through the AWS SDK instead of the serverless.com framework.")
+var seedFlag = flag.Int64("seed", 0, "Seed of the inter-arrival times, service names and filler contents, overriding that of the configuration (0 picks one from the current time).")
+var imageRegistryFlag = flag.String("image-registry", "", "Registry (e.g., localhost:5000) to push container images to instead of that of the provider.")
+var forceBudgetFlag = flag.Bool("force", false, "Run even if the estimated cost exceeds the configured MaxBudgetUSD.")
+var assumeYesFlag = flag.Bool("yes", false, "Continue without prompting on safety warnings configured to prompt.")
+var noPromptFlag = flag.Bool("no-prompt", false, "Abort without prompting on safety warnings configured to prompt.")
+var busySpinCachePathFlag = flag.String("k", defaultBusySpinCachePath, "Cache file with busy-spin increments calibrated on the target platforms.")
+var metricsAddressFlag = flag.String("metrics-addr", "", "Address (e.g., :9090) to serve Prometheus metrics on while the run is in progress.")
+var progressFlag = flag.Bool("progress", false, "Show per-sub-experiment progress on the terminal, logging to the run log file only.")
+var otlpEndpointFlag = flag.String("otlp-endpoint", "", "OTLP/HTTP endpoint URL (e.g., http://localhost:4318) to export trace spans to.")
+var traceFileFlag = flag.String("trace-file", "", "JSON file to write trace spans to, unless -otlp-endpoint is set.")
+var recordCommandsFlag = flag.String("record-commands", "", "Directory to save the outputs of the deployment commands to, e.g., as test fixtures.")
+var workersFlag = flag.String("workers", "", "Comma-separated addresses of the workers to distribute the bursts across (see the `worker` command).")
 
 func main() {
+	if runSubcommand(os.Args[1:]) {
+		return
+	}
+
 	startTime := time.Now()
-	randomSeed := startTime.Unix()
-	// 25.09 Change for go linter syntax check errors 
-	// rand.Seed(randomSeed) // comment line for reproducible inter-arrival times
-	r := rand.New(rand.NewSource(randomSeed)) // comment line for reproducible inter-arrival times
-	fmt.Println(r.Intn(100))
 	flag.Parse()
 
 	outputDirectoryPath := filepath.Join(*outputPathFlag, strconv.FormatInt(time.Now().Unix(), 10))
@@ -64,38 +81,94 @@ func main() {
 	logFile := setupLogging(outputDirectoryPath)
 	defer logFile.Close()
 
-	log.Infof("Started benchmarking HTTP client on %v with random seed %d.",
-		time.Now().UTC().Format(time.RFC850), randomSeed)
+	shutdownTracing := tracing.Initialize("stellar run", *otlpEndpointFlag, *traceFileFlag)
+	defer shutdownTracing()
+
+	log.Infof("Started benchmarking HTTP client on %v.", time.Now().UTC().Format(time.RFC850))
 	log.Infof("Selected endpoints directory path: %s", *endpointsDirectoryPathFlag)
 	log.Infof("Selected config path: %s", *configPathFlag)
 	log.Infof("Selected output path: %s", *outputPathFlag)
 	log.Infof("Selected experiment (-1 for all): %d", *specificExperimentFlag)
 
 	config := setup.ExtractConfiguration(*configPathFlag)
+	setup.ResolveSeed(&config, *seedFlag)
+	log.Infof("Using random seed %d, pass -seed %d to reproduce this run.", config.Seed, config.Seed)
+	util.SeedRandomNames(config.Seed)
+	packaging.FillerSeed = config.FillerSeed
+
+	var runner util.CommandRunner = util.ExecRunner{}
+	if *recordCommandsFlag != "" {
+		if err := os.MkdirAll(*recordCommandsFlag, os.ModePerm); err != nil {
+			log.Fatalf("Could not create the directory to record the commands to: %s", err.Error())
+		}
+		runner = util.RecordingRunner{Runner: runner, FixturesDirectoryPath: *recordCommandsFlag}
+	}
 
 	amazon.UserARNNumber = *awsUserArnNumber
-
-	// We find the busy-spinning time based on the host where the tool is run, i.e., not AWS or other providers
-	setup.FindBusySpinIncrements(&config)
+	amazon.AWSRegion = *awsRegionFlag
+	amazon.EndpointURL = *awsEndpointURLFlag
+	setup.DeployAWSWithSDK = *awsSDKFlag
+	packaging.ImageRegistry = *imageRegistryFlag
+
+	// Increments calibrated on the target platform (see the `calibrate` command) are preferred, otherwise we find the
+	// busy-spinning time based on the host where the tool is run, i.e., not AWS or other providers
+	setup.FindBusySpinIncrements(&config, setup.LoadBusySpinCache(*busySpinCachePathFlag))
+
+	setup.EnforceBudget(&config, *specificExperimentFlag, *forceBudgetFlag)
+	clientHosts := 1
+	var workerPool *benchmarking.WorkerPool
+	if *workersFlag != "" {
+		workerAddresses := strings.Split(*workersFlag, ",")
+		workerPool = benchmarking.ConnectWorkers(workerAddresses)
+		defer workerPool.Close()
+		clientHosts = len(workerAddresses)
+	}
+	setup.CheckSafety(&config, promptMode(), clientHosts)
+
+	if *metricsAddressFlag != "" || *progressFlag {
+		progress := benchmarking.TrackProgress(config, *specificExperimentFlag)
+		if *metricsAddressFlag != "" {
+			metricsServer := progress.ServeMetrics(*metricsAddressFlag)
+			defer metricsServer.Close()
+		}
+		if *progressFlag {
+			stopProgress := progress.ShowProgress(os.Stdout, time.Second)
+			defer stopProgress()
+		}
+	}
 
 	// Pick between deployment methods
 	connection.Initialize(config.Provider, *endpointsDirectoryPathFlag, "./setup/deployment/raw-code/functions/producer-consumer/api-template.json")
 	if *serverlessDeployment {
 		serverlessDirPath := fmt.Sprintf("setup/deployment/raw-code/serverless/%s/", config.Provider)
-		setup.ProvisionFunctionsServerless(&config, serverlessDirPath)
+		setup.ProvisionFunctionsServerless(&config, serverlessDirPath, runner)
 		log.Infof("number of routes %d, numebr of endpoints %d", len(config.SubExperiments[0].Routes), len(config.SubExperiments[0].Endpoints))
-		benchmarking.TriggerSubExperiments(config, outputDirectoryPath, *specificExperimentFlag)
+		setup.NewRunManifest(config, startTime).Write(outputDirectoryPath)
+		benchmarking.TriggerSubExperiments(config, outputDirectoryPath, *specificExperimentFlag, workerPool)
 
 		log.Info("Starting functions removal from cloud.")
-		setup.RemoveService(&config, serverlessDirPath)
+		setup.RemoveService(&config, serverlessDirPath, runner)
 	} else {
-		setup.ProvisionFunctions(config)
-		benchmarking.TriggerSubExperiments(config, outputDirectoryPath, *specificExperimentFlag)
+		setup.ProvisionFunctions(config, runner)
+		setup.NewRunManifest(config, startTime).Write(outputDirectoryPath)
+		benchmarking.TriggerSubExperiments(config, outputDirectoryPath, *specificExperimentFlag, workerPool)
 	}
 
 	log.Infof("Done in %v, exiting...", time.Since(startTime))
 }
 
+func promptMode() setup.PromptMode {
+	switch {
+	case *assumeYesFlag && *noPromptFlag:
+		log.Fatalf("Flags -yes and -no-prompt cannot be used together.")
+	case *assumeYesFlag:
+		return setup.PromptAssumeYes
+	case *noPromptFlag:
+		return setup.PromptDisabled
+	}
+	return setup.PromptInteractive
+}
+
 func setupLogging(path string) *os.File {
 	loggingPath := filepath.Join(path, "run_logs.txt")
 	log.Debugf("Creating log file for this run at `%s`", loggingPath)
@@ -113,6 +186,12 @@ func setupLogging(path string) *os.File {
 		log.SetLevel(log.ErrorLevel)
 	}
 
+	if *progressFlag {
+		// The terminal is left to the progress view
+		log.SetOutput(logFile)
+		return logFile
+	}
+
 	stdoutFileMultiWriter := io.MultiWriter(os.Stdout, logFile)
 	log.SetOutput(stdoutFileMultiWriter)
 
@@ -24,7 +24,7 @@ package setup
 
 import (
 	"fmt"
-	"github.com/aws/aws-sdk-go/service/lambda"
+	lambdatypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
 	log "github.com/sirupsen/logrus"
 	"math"
 	"stellar/setup/deployment"
@@ -32,7 +32,7 @@ import (
 	"stellar/util"
 )
 
-func assignEndpoints(availableEndpoints []connection.Endpoint, experiment *SubExperiment, provider string) []connection.Endpoint {
+func assignEndpoints(availableEndpoints []connection.Endpoint, experiment *SubExperiment, provider string, runner util.CommandRunner) []connection.Endpoint {
 	log.Infof("[sub-experiment %d] Setting up deployment...", experiment.ID)
 	log.Infof("[sub-experiment %d] Experiment configuration: %vMB memory, %vMB image size, %vs IAT, %q package.",
 		experiment.ID, experiment.FunctionMemoryMB, experiment.FunctionImageSizeMB, experiment.IATSeconds,
@@ -40,13 +40,14 @@ func assignEndpoints(availableEndpoints []connection.Endpoint, experiment *SubEx
 	var assignedHandler string
 
 	if provider == "aws" { // deployment has only been automated for AWS so far
-		experiment.FunctionImageSizeMB, assignedHandler = deployment.SetupDeployment(
+		experiment.FunctionImageSizeMB, assignedHandler, experiment.ImageSize = deployment.SetupDeployment(
 			fmt.Sprintf("setup/deployment/raw-code/functions/%s/%s", experiment.Function, provider),
 			provider,
 			util.MebibyteToBytes(experiment.FunctionImageSizeMB),
 			experiment.PackageType,
 			experiment.ID,
 			experiment.Function,
+			runner,
 		)
 	}
 
@@ -129,7 +130,7 @@ func specsMatch(endpoint connection.Endpoint, experiment *SubExperiment) bool {
 	}
 
 	// Image sizes are ignored for PackageTypeImage because AWS does not reveal this information
-	if experiment.PackageType == lambda.PackageTypeImage {
+	if experiment.PackageType == string(lambdatypes.PackageTypeImage) {
 		return true
 	}
 
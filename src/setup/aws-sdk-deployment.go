@@ -0,0 +1,107 @@
+// MIT License
+//
+// Copyright (c) 2021 Theodor Amariucai and EASE Lab
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package setup
+
+import (
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"stellar/setup/building"
+	code_generation "stellar/setup/code-generation"
+	"stellar/setup/deployment/connection/amazon"
+	"stellar/setup/deployment/packaging"
+	"stellar/tracing"
+	"stellar/util"
+)
+
+// DeployAWSWithSDK makes AWS deployments go through the AWS SDK rather than the serverless.com framework.
+var DeployAWSWithSDK bool
+
+// awsHTTPAPIDeployment holds the resources created by ProvisionFunctionsAWSSDK, used for their removal
+var awsHTTPAPIDeployment *amazon.HTTPAPIDeployment
+
+// ProvisionFunctionsAWSSDK builds and packages the functions of the sub-experiments like ProvisionFunctionsServerlessAWS,
+// then deploys them behind a single HTTP API through the AWS SDK, without the serverless.com framework.
+func ProvisionFunctionsAWSSDK(config *Configuration, serverlessDirPath string, runner util.CommandRunner) {
+	builder := &building.Builder{Runner: runner}
+	randomTag := util.GenerateRandLowercaseLetters(5)
+
+	// the archives are only needed until they are uploaded
+	archiveDirectory, removeArchiveDirectory := packaging.NewArchiveDirectory()
+	defer removeArchiveDirectory()
+
+	var functions []amazon.HTTPAPIFunction
+	for index, subExperiment := range config.SubExperiments {
+		code_generation.GenerateCode(subExperiment.Function, config.Provider)
+
+		builder.BuildFunction(config.Provider, subExperiment.Function, subExperiment.Runtime)
+		artifactPath := packaging.GenerateServerlessZIPArtifacts(subExperiment.ID, config.Provider, subExperiment.Runtime, subExperiment.Function,
+			subExperiment.FunctionImageSizeMB, archiveDirectory)
+		functions = append(functions, AWSHTTPAPIFunctions(&config.SubExperiments[index], index, randomTag, artifactPath)...)
+	}
+
+	log.Infof("Starting functions deployment. Deploying %d functions to %s through the AWS SDK.", len(functions), config.Provider)
+	deploySpan := tracing.StartStep("deploy", attribute.String("stellar.provider", config.Provider))
+	deployment := amazon.AWSSingletonInstance.DeployHTTPAPI(tracing.RunContext(), fmt.Sprintf("STeLLAR-%s", randomTag), functions)
+	awsHTTPAPIDeployment = &deployment
+	deploySpan.End()
+
+	endpointID := deployment.EndpointID()
+	for i := range config.SubExperiments {
+		config.SubExperiments[i].AssignEndpointIDs(endpointID)
+	}
+}
+
+// AWSHTTPAPIFunctions describes the functions to deploy for a sub-experiment, one per unit of parallelism, each behind
+// its own route of the HTTP API. The routes are added to the sub-experiment.
+func AWSHTTPAPIFunctions(subex *SubExperiment, index int, randomTag string, zipPath string) []amazon.HTTPAPIFunction {
+	runtime := subex.Runtime
+	if runtime == "go1.x" {
+		log.Warnf("`go1.x` runtime is deprecated. Using `provided.al2023` runtime instead...")
+		runtime = "provided.al2023"
+	}
+
+	functions := make([]amazon.HTTPAPIFunction, 0, subex.Parallelism)
+	for i := 0; i < subex.Parallelism; i++ {
+		name := fmt.Sprintf("%s-%s", randomTag, createName(subex, index, i))
+		functions = append(functions, amazon.HTTPAPIFunction{
+			Name:        name,
+			Route:       name,
+			Handler:     subex.Handler,
+			Runtime:     runtime,
+			MemoryMB:    subex.FunctionMemoryMB,
+			ZipPath:     zipPath,
+			SnapStart:   subex.SnapStartEnabled,
+			Environment: subex.FunctionEnvironment(),
+		})
+		subex.AddRoute(name)
+	}
+	return functions
+}
+
+// RemoveAWSSDKService removes the HTTP API and functions deployed by ProvisionFunctionsAWSSDK.
+func RemoveAWSSDKService() string {
+	amazon.AWSSingletonInstance.RemoveHTTPAPI(tracing.RunContext(), *awsHTTPAPIDeployment)
+	awsHTTPAPIDeployment = nil
+	return "AWS HTTP API and functions removed."
+}
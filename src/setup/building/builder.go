@@ -3,17 +3,24 @@ package building
 import (
 	"fmt"
 	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
 	"os"
 	"os/exec"
+	"stellar/tracing"
 	"stellar/util"
 )
 
 // Builder struct keeps track of the functions built by stellar
 type Builder struct {
+	// Runner runs the build commands, an ExecRunner being used if nil
+	Runner         util.CommandRunner
 	functionsBuilt map[string]bool
 }
 
 func (b *Builder) BuildFunction(provider string, functionName string, runtime string) string {
+	defer tracing.StartStep("build", attribute.String("stellar.provider", provider),
+		attribute.String("stellar.function", functionName), attribute.String("stellar.runtime", runtime)).End()
+
 	artifactDir := fmt.Sprintf("setup/deployment/raw-code/serverless/%s/artifacts/%s", provider, functionName)
 
 	// First we check whether the function has not been built already
@@ -35,19 +42,19 @@ func (b *Builder) BuildFunction(provider string, functionName string, runtime st
 
 	switch runtime {
 	case "java11":
-		buildJava(functionName, functionDir, artifactDir)
+		buildJava(functionName, functionDir, artifactDir, b.Runner)
 	case "go1.x":
-		buildGolang(functionName, functionDir, artifactDir)
+		buildGolang(functionName, functionDir, artifactDir, b.Runner)
 	case "nodejs18":
 		fallthrough
 	case "nodejs18.x":
-		copyNodeJSFile(functionName, functionDir, artifactDir)
+		copyNodeJSFile(functionName, functionDir, artifactDir, b.Runner)
 	case "ruby3.2":
-		copyRubyFile(functionName, functionDir, artifactDir)
+		copyRubyFile(functionName, functionDir, artifactDir, b.Runner)
 	case "python3.8":
 		fallthrough
 	case "python3.9":
-		copyPythonFile(functionName, functionDir, artifactDir)
+		copyPythonFile(functionName, functionDir, artifactDir, b.Runner)
 	default:
 		log.Warnf("Building runtime %s is not necessary, or not supported. Continuing without building.", runtime)
 	}
@@ -56,45 +63,45 @@ func (b *Builder) BuildFunction(provider string, functionName string, runtime st
 }
 
 // buildJava builds the java zip artifact for serverless deployment using Gradle
-func buildJava(functionName string, functionDir string, artifactDir string) string {
+func buildJava(functionName string, functionDir string, artifactDir string, runner util.CommandRunner) string {
 	log.Infof("Building Java from the source code at %s directory", functionDir)
 	artifactPath := fmt.Sprintf("%s/%s.zip", artifactDir, functionName)
-	util.RunCommandAndLog(exec.Command("gradle", "buildZip", "-p", functionDir))
-	util.RunCommandAndLog(exec.Command("mv", fmt.Sprintf("%s/build/distributions/%s.zip", functionDir, functionName), artifactPath))
+	util.RunCommandAndLog(exec.Command("gradle", "buildZip", "-p", functionDir), runner)
+	util.RunCommandAndLog(exec.Command("mv", fmt.Sprintf("%s/build/distributions/%s.zip", functionDir, functionName), artifactPath), runner)
 	return artifactPath
 }
 
 // buildGolang builds the Golang binary for serverless deployment
-func buildGolang(functionName string, functionDir string, artifactDir string) string {
+func buildGolang(functionName string, functionDir string, artifactDir string, runner util.CommandRunner) string {
 	log.Infof("Building Go from the source code at %s directory", functionDir)
 	artifactPath := fmt.Sprintf("%s/bootstrap", artifactDir)
-	util.RunCommandAndLog(exec.Command("env", "GOOS=linux", "GOARCH=amd64", "CGO_ENABLED=0", "go", "build", "-C", functionDir, "-o", "bootstrap"))
-	util.RunCommandAndLog(exec.Command("mv", fmt.Sprintf("%s/bootstrap", functionDir), artifactPath))
+	util.RunCommandAndLog(exec.Command("env", "GOOS=linux", "GOARCH=amd64", "CGO_ENABLED=0", "go", "build", "-C", functionDir, "-o", "bootstrap"), runner)
+	util.RunCommandAndLog(exec.Command("mv", fmt.Sprintf("%s/bootstrap", functionDir), artifactPath), runner)
 	return artifactPath
 }
 
 // copyPythonFile copies the main Python file into the artifacts directory
-func copyPythonFile(functionName string, functionDir string, artifactDir string) string {
+func copyPythonFile(functionName string, functionDir string, artifactDir string, runner util.CommandRunner) string {
 	log.Infof("Copying Python source code from the %s directory", functionDir)
 	functionPath := fmt.Sprintf("%s/main.py", functionDir)
 	artifactPath := fmt.Sprintf("%s/main.py", artifactDir)
-	util.RunCommandAndLog(exec.Command("cp", functionPath, artifactPath))
+	util.RunCommandAndLog(exec.Command("cp", functionPath, artifactPath), runner)
 	return artifactPath
 }
 
 // copyNodeJSFile copies the main NodeJS file into the artifacts directory
-func copyNodeJSFile(functioName string, functionDir string, artifactDir string) string {
+func copyNodeJSFile(functioName string, functionDir string, artifactDir string, runner util.CommandRunner) string {
 	log.Infof("Copying Node source code from the %s directory", functionDir)
 	functionPath := fmt.Sprintf("%s/index.js", functionDir)
 	artifactPath := fmt.Sprintf("%s/index.js", artifactDir)
-	util.RunCommandAndLog(exec.Command("cp", functionPath, artifactPath))
+	util.RunCommandAndLog(exec.Command("cp", functionPath, artifactPath), runner)
 	return artifactPath
 }
 
-func copyRubyFile(functionName string, functionDir string, artifactDir string) string {
+func copyRubyFile(functionName string, functionDir string, artifactDir string, runner util.CommandRunner) string {
 	log.Infof("Copying Ruby source code from the %s directory", functionDir)
 	functionPath := fmt.Sprintf("%s/function.rb", functionDir)
 	artifactPath := fmt.Sprintf("%s/function.rb", artifactDir)
-	util.RunCommandAndLog(exec.Command("cp", functionPath, artifactPath))
+	util.RunCommandAndLog(exec.Command("cp", functionPath, artifactPath), runner)
 	return artifactPath
 }
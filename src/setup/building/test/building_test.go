@@ -4,6 +4,7 @@ import (
 	"log"
 	"os"
 	"stellar/setup/building"
+	"stellar/util"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -26,6 +27,20 @@ func (s *BuildingTestSuite) TestBuildFunctionJava() {
 	assert.FileExists(s.T(), "setup/deployment/raw-code/serverless/aws/artifacts/hellojava/hellojava.zip")
 }
 
+func (s *BuildingTestSuite) TestBuildFunctionJavaCommands() {
+	fake := &util.FakeRunner{}
+
+	b := &building.Builder{Runner: fake}
+	artifactPath := b.BuildFunction("aws", "hellojava", "java11")
+
+	assert.Equal(s.T(), "artifacts/hellojava/hellojava.zip", artifactPath)
+	assert.Equal(s.T(), []util.RecordedCommand{
+		{Args: []string{"gradle", "buildZip", "-p", "setup/deployment/raw-code/serverless/aws/hellojava"}},
+		{Args: []string{"mv", "setup/deployment/raw-code/serverless/aws/hellojava/build/distributions/hellojava.zip",
+			"setup/deployment/raw-code/serverless/aws/artifacts/hellojava/hellojava.zip"}},
+	}, fake.Commands())
+}
+
 func (s *BuildingTestSuite) TestBuildFunctionGolang() {
 	b := &building.Builder{}
 	b.BuildFunction("aws", "hellogo", "go1.x")
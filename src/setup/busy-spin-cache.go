@@ -0,0 +1,115 @@
+// MIT License
+//
+// Copyright (c) 2021 Theodor Amariucai and EASE Lab
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package setup
+
+import (
+	"encoding/json"
+	"errors"
+	log "github.com/sirupsen/logrus"
+	"io/fs"
+	"os"
+	"time"
+)
+
+// BusySpinCalibration is a busy-spin increment limit calibrated on a target platform for a desired service time
+type BusySpinCalibration struct {
+	Provider         string    `json:"Provider"`
+	Runtime          string    `json:"Runtime"`
+	FunctionMemoryMB int64     `json:"FunctionMemoryMB"`
+	ServiceTime      string    `json:"ServiceTime"`
+	IncrementLimit   int64     `json:"IncrementLimit"`
+	MeasuredMs       float64   `json:"MeasuredMs"`
+	CalibratedAt     time.Time `json:"CalibratedAt"`
+}
+
+// BusySpinCache persists the busy-spin calibrations made on the target platforms, so that later runs can reuse them
+type BusySpinCache struct {
+	path         string
+	Calibrations []BusySpinCalibration `json:"Calibrations"`
+}
+
+// LoadBusySpinCache reads the busy-spin cache file at the given path, returning an empty cache if it does not exist yet
+func LoadBusySpinCache(path string) *BusySpinCache {
+	cache := &BusySpinCache{path: path}
+
+	contents, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		log.Debugf("Busy-spin cache `%s` does not exist yet.", path)
+		return cache
+	}
+	if err != nil {
+		log.Fatalf("Could not read busy-spin cache `%s`: %s", path, err.Error())
+	}
+	if err := json.Unmarshal(contents, cache); err != nil {
+		log.Fatalf("Could not parse busy-spin cache `%s`: %s", path, err.Error())
+	}
+
+	log.Debugf("Loaded %d busy-spin calibrations from `%s`.", len(cache.Calibrations), path)
+	return cache
+}
+
+// Lookup returns the calibration of the given service time (e.g., `10ms`) on the given provider, runtime and memory size
+func (c *BusySpinCache) Lookup(provider string, runtime string, functionMemoryMB int64, serviceTime string) (BusySpinCalibration, bool) {
+	serviceTime = normalizeServiceTime(serviceTime)
+	for _, calibration := range c.Calibrations {
+		if calibration.Provider == provider && calibration.Runtime == runtime &&
+			calibration.FunctionMemoryMB == functionMemoryMB && calibration.ServiceTime == serviceTime {
+			return calibration, true
+		}
+	}
+	return BusySpinCalibration{}, false
+}
+
+// Store adds the given calibration to the cache, replacing any previous calibration of the same service time and platform
+func (c *BusySpinCache) Store(calibration BusySpinCalibration) {
+	calibration.ServiceTime = normalizeServiceTime(calibration.ServiceTime)
+	for index, cached := range c.Calibrations {
+		if cached.Provider == calibration.Provider && cached.Runtime == calibration.Runtime &&
+			cached.FunctionMemoryMB == calibration.FunctionMemoryMB && cached.ServiceTime == calibration.ServiceTime {
+			c.Calibrations[index] = calibration
+			return
+		}
+	}
+	c.Calibrations = append(c.Calibrations, calibration)
+}
+
+// Save writes the cache back to the file it was loaded from
+func (c *BusySpinCache) Save() {
+	contents, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		log.Fatalf("Could not serialize busy-spin cache: %s", err.Error())
+	}
+	if err := os.WriteFile(c.path, contents, 0644); err != nil {
+		log.Fatalf("Could not write busy-spin cache `%s`: %s", c.path, err.Error())
+	}
+	log.Infof("Saved %d busy-spin calibrations to `%s`.", len(c.Calibrations), c.path)
+}
+
+// normalizeServiceTime formats equivalent service times (e.g., `1s` and `1000ms`) the same way
+func normalizeServiceTime(serviceTime string) string {
+	parsed, err := time.ParseDuration(serviceTime)
+	if err != nil {
+		return serviceTime
+	}
+	return parsed.String()
+}
@@ -1,20 +1,119 @@
 package code_generation
 
-import log "github.com/sirupsen/logrus"
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates
+var templates embed.FS
+
+// languageSuffixes maps function name suffixes (e.g., `hellopy`) to the language of the template to render
+var languageSuffixes = []struct {
+	suffix   string
+	language string
+}{
+	{"py", "python"},
+	{"node", "node"},
+	{"go", "go"},
+	{"java", "java"},
+	{"ruby", "ruby"},
+	{"rust", "rust"},
+}
+
+// handlerFiles maps each language and provider to the handler file expected by the builder and deployment,
+// relative to the function directory. The function name replaces `%s` where present.
+var handlerFiles = map[string]map[string]string{
+	"python": {"aws": "main.py", "azure": "main.py", "aliyun": "main.py", "gcr": "app.py"},
+	"node":   {"aws": "index.js", "azure": "index.js", "gcr": "index.js"},
+	"go":     {"aws": "main.go", "gcr": "main.go"},
+	"java":   {"aws": "src/main/java/org/%s/Handler.java", "gcr": "src/main/java/com/%s/HelloJava.java"},
+	"ruby":   {"aws": "function.rb"},
+	"rust":   {"gcr": "src/main.rs"},
+}
+
+// templateData is passed to the function templates when rendering
+type templateData struct {
+	FunctionName string
+	Provider     string
+}
 
 // GenerateCode generates source code of the given function for the given provider
 func GenerateCode(functionName string, provider string) {
+	functionDir := fmt.Sprintf("setup/deployment/raw-code/serverless/%s/%s", provider, functionName)
+
+	handlerPath, err := RenderFunction(functionName, provider, functionDir)
+	if err != nil {
+		log.Warnf("Code generation of %s function for %s is not supported (%s), using the existing source code.",
+			functionName, provider, err.Error())
+		return
+	}
+	log.Infof("Generated source code of %s function for %s at %s", functionName, provider, handlerPath)
+}
+
+// RenderFunction renders the handler of the given function for the given provider into the given directory
+// and returns the path of the rendered file.
+func RenderFunction(functionName string, provider string, functionDir string) (string, error) {
+	baseName, language, err := splitFunctionName(functionName)
+	if err != nil {
+		return "", err
+	}
+
+	handlerFile, ok := handlerFiles[language][provider]
+	if !ok {
+		return "", fmt.Errorf("no %s handler is defined for provider %s", language, provider)
+	}
+	if strings.Contains(handlerFile, "%s") {
+		handlerFile = fmt.Sprintf(handlerFile, functionName)
+	}
+
+	templatePath := fmt.Sprintf("templates/%s/%s.tmpl", baseName, language)
+	functionTemplate, err := template.ParseFS(templates, templatePath)
+	if err != nil {
+		return "", fmt.Errorf("could not load template %s: %s", templatePath, err.Error())
+	}
 
-	//TODO: Implement function.
+	var rendered bytes.Buffer
+	if err := functionTemplate.Execute(&rendered, templateData{FunctionName: functionName, Provider: provider}); err != nil {
+		return "", fmt.Errorf("could not render template %s: %s", templatePath, err.Error())
+	}
+
+	handlerPath := filepath.Join(functionDir, handlerFile)
+	if err := os.MkdirAll(filepath.Dir(handlerPath), os.ModePerm); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(handlerPath, rendered.Bytes(), 0644); err != nil {
+		return "", err
+	}
+	return handlerPath, nil
+}
+
+// IsGenerated returns whether the source code of the given function for the given provider is rendered from a template
+func IsGenerated(functionName string, provider string) bool {
+	baseName, language, err := splitFunctionName(functionName)
+	if err != nil {
+		return false
+	}
+	if _, ok := handlerFiles[language][provider]; !ok {
+		return false
+	}
+	_, err = fs.Stat(templates, fmt.Sprintf("templates/%s/%s.tmpl", baseName, language))
+	return err == nil
+}
 
-	switch provider {
-	case "aws":
-		fallthrough
-	case "azure":
-		fallthrough
-	case "google":
-		fallthrough
-	default:
-		log.Warnf("Code generation of %s function for %s is not supported.", functionName, provider)
+// splitFunctionName splits a function name such as `hellopy` into its template name and language
+func splitFunctionName(functionName string) (string, string, error) {
+	for _, entry := range languageSuffixes {
+		if baseName := strings.TrimSuffix(functionName, entry.suffix); baseName != functionName && baseName != "" {
+			return baseName, entry.language, nil
+		}
 	}
+	return "", "", fmt.Errorf("could not infer the language of function %s", functionName)
 }
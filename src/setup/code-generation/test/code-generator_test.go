@@ -1,10 +1,41 @@
 package code_generation
 
 import (
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
 	code_generation "stellar/setup/code-generation"
 	"testing"
 )
 
-func TestAddFunctionConfig(t *testing.T) {
-	code_generation.GenerateCode("hellopy", "aws")
+func TestRenderFunction(t *testing.T) {
+	functionDir := t.TempDir()
+
+	handlerPath, err := code_generation.RenderFunction("hellopy", "aws", functionDir)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(functionDir, "main.py"), handlerPath)
+
+	source, err := os.ReadFile(handlerPath)
+	require.NoError(t, err)
+	require.Contains(t, string(source), "def lambda_handler(request, context):")
+	require.Contains(t, string(source), "def simulate_work(parameters):")
+	require.NotContains(t, string(source), "azure")
+}
+
+func TestRenderFunctionJavaPackagePath(t *testing.T) {
+	functionDir := t.TempDir()
+
+	handlerPath, err := code_generation.RenderFunction("hellojava", "gcr", functionDir)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(functionDir, "src/main/java/com/hellojava/HelloJava.java"), handlerPath)
+}
+
+func TestRenderFunctionUnsupported(t *testing.T) {
+	functionDir := t.TempDir()
+
+	_, err := code_generation.RenderFunction("hellorust", "aws", functionDir)
+	require.Error(t, err)
+
+	_, err = code_generation.RenderFunction("hellopy-read-random", "aws", functionDir)
+	require.Error(t, err)
 }
@@ -0,0 +1,237 @@
+// MIT License
+//
+// Copyright (c) 2021 Theodor Amariucai and EASE Lab
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package setup
+
+import (
+	log "github.com/sirupsen/logrus"
+	"math"
+	"stellar/util"
+	"time"
+)
+
+// Pricing lists the prices (USD) used to estimate the cost of an experiment on a provider
+type Pricing struct {
+	RequestsPerMillionUSD        float64 `json:"RequestsPerMillionUSD"`
+	GBSecondUSD                  float64 `json:"GBSecondUSD"`
+	VCPUSecondUSD                float64 `json:"VCPUSecondUSD"`
+	GatewayRequestsPerMillionUSD float64 `json:"GatewayRequestsPerMillionUSD"`
+	TransferGBUSD                float64 `json:"TransferGBUSD"`
+	RegistryGBMonthUSD           float64 `json:"RegistryGBMonthUSD"`
+	MinimumBilledMs              float64 `json:"MinimumBilledMs"`
+	BillingGranularityMs         float64 `json:"BillingGranularityMs"`
+}
+
+// defaultPricing holds the public on-demand list prices of the US regions, used unless the configuration sets its own
+var defaultPricing = map[string]Pricing{
+	"aws": {RequestsPerMillionUSD: 0.20, GBSecondUSD: 0.0000166667, GatewayRequestsPerMillionUSD: 3.50,
+		TransferGBUSD: 0.09, RegistryGBMonthUSD: 0.10, MinimumBilledMs: 1, BillingGranularityMs: 1},
+	"azure": {RequestsPerMillionUSD: 0.20, GBSecondUSD: 0.000016,
+		TransferGBUSD: 0.087, RegistryGBMonthUSD: 0.10, MinimumBilledMs: 100, BillingGranularityMs: 1},
+	"gcr": {RequestsPerMillionUSD: 0.40, GBSecondUSD: 0.0000025, VCPUSecondUSD: 0.000024,
+		TransferGBUSD: 0.12, RegistryGBMonthUSD: 0.10, MinimumBilledMs: 100, BillingGranularityMs: 100},
+	"google": {RequestsPerMillionUSD: 0.40, GBSecondUSD: 0.0000025, VCPUSecondUSD: 0.00001,
+		TransferGBUSD: 0.12, RegistryGBMonthUSD: 0.10, MinimumBilledMs: 100, BillingGranularityMs: 100},
+	"cloudflare": {RequestsPerMillionUSD: 0.30, VCPUSecondUSD: 0.00002, MinimumBilledMs: 1, BillingGranularityMs: 1},
+	"aliyun": {RequestsPerMillionUSD: 0.20, GBSecondUSD: 0.000016384, GatewayRequestsPerMillionUSD: 0.90,
+		TransferGBUSD: 0.117, RegistryGBMonthUSD: 0.10, MinimumBilledMs: 1, BillingGranularityMs: 1},
+	"vhive": {},
+}
+
+// PricingModel returns the prices of the configured provider, or the prices set in the configuration if any
+func (c *Configuration) PricingModel() Pricing {
+	if c.Pricing != nil {
+		return *c.Pricing
+	}
+	pricing, ok := defaultPricing[c.Provider]
+	if !ok {
+		log.Warnf("No pricing is known for provider %s, costs will be estimated as zero.", c.Provider)
+	}
+	return pricing
+}
+
+// CostUsage is the billable usage of one or more sub-experiments
+type CostUsage struct {
+	Requests         float64
+	GatewayRequests  float64
+	GBSeconds        float64
+	VCPUSeconds      float64
+	TransferGB       float64
+	RegistryGBMonths float64
+}
+
+// CostEstimate breaks the estimated cost (USD) of a usage down into its components
+type CostEstimate struct {
+	Usage       CostUsage
+	RequestsUSD float64
+	ComputeUSD  float64
+	GatewayUSD  float64
+	TransferUSD float64
+	RegistryUSD float64
+}
+
+// TotalUSD returns the sum of all cost components
+func (e CostEstimate) TotalUSD() float64 {
+	return e.RequestsUSD + e.ComputeUSD + e.GatewayUSD + e.TransferUSD + e.RegistryUSD
+}
+
+// Add returns the sum of both estimates
+func (e CostEstimate) Add(other CostEstimate) CostEstimate {
+	return CostEstimate{
+		Usage: CostUsage{
+			Requests:         e.Usage.Requests + other.Usage.Requests,
+			GatewayRequests:  e.Usage.GatewayRequests + other.Usage.GatewayRequests,
+			GBSeconds:        e.Usage.GBSeconds + other.Usage.GBSeconds,
+			VCPUSeconds:      e.Usage.VCPUSeconds + other.Usage.VCPUSeconds,
+			TransferGB:       e.Usage.TransferGB + other.Usage.TransferGB,
+			RegistryGBMonths: e.Usage.RegistryGBMonths + other.Usage.RegistryGBMonths,
+		},
+		RequestsUSD: e.RequestsUSD + other.RequestsUSD,
+		ComputeUSD:  e.ComputeUSD + other.ComputeUSD,
+		GatewayUSD:  e.GatewayUSD + other.GatewayUSD,
+		TransferUSD: e.TransferUSD + other.TransferUSD,
+		RegistryUSD: e.RegistryUSD + other.RegistryUSD,
+	}
+}
+
+// Estimate prices the given usage
+func (p Pricing) Estimate(usage CostUsage) CostEstimate {
+	return CostEstimate{
+		Usage:       usage,
+		RequestsUSD: usage.Requests / 1e6 * p.RequestsPerMillionUSD,
+		ComputeUSD:  usage.GBSeconds*p.GBSecondUSD + usage.VCPUSeconds*p.VCPUSecondUSD,
+		GatewayUSD:  usage.GatewayRequests / 1e6 * p.GatewayRequestsPerMillionUSD,
+		TransferUSD: usage.TransferGB * p.TransferGBUSD,
+		RegistryUSD: usage.RegistryGBMonths * p.RegistryGBMonthUSD,
+	}
+}
+
+// billedSeconds rounds the given duration up to the billing granularity and minimum of the provider
+func (p Pricing) billedSeconds(durationMs float64) float64 {
+	if p.BillingGranularityMs > 0 {
+		durationMs = math.Ceil(durationMs/p.BillingGranularityMs) * p.BillingGranularityMs
+	}
+	return math.Max(durationMs, p.MinimumBilledMs) / 1000
+}
+
+// addRequests adds the given number of client requests, each running the functions of the data transfer chain
+// for the given duration
+func (u *CostUsage) addRequests(pricing Pricing, subExperiment *SubExperiment, requests float64, durationMs float64) {
+	invocations := requests * float64(util.IntegerMax(subExperiment.DataTransferChainLength, 1))
+	u.Requests += invocations
+	u.GatewayRequests += requests
+	u.addComputeSeconds(subExperiment, invocations*pricing.billedSeconds(durationMs))
+
+	payloadGB := float64(subExperiment.PayloadLengthBytes) / (1 << 30)
+	u.TransferGB += requests * payloadGB
+	if subExperiment.StorageTransfer {
+		u.TransferGB += (invocations - requests) * payloadGB
+	}
+}
+
+func (u *CostUsage) addComputeSeconds(subExperiment *SubExperiment, seconds float64) {
+	u.GBSeconds += seconds * float64(subExperiment.FunctionMemoryMB) / 1024
+	u.VCPUSeconds += seconds
+}
+
+// addRegistryStorage adds the storage of the function image in the container registry for the given duration
+func (u *CostUsage) addRegistryStorage(subExperiment *SubExperiment, duration time.Duration) {
+	if subExperiment.PackageType == defaultPackageType {
+		return
+	}
+	const month = 30 * 24 * time.Hour
+	imageSizeMB := subExperiment.FunctionImageSizeMB
+	if subExperiment.ImageSize.CompressedBytes > 0 {
+		imageSizeMB = util.BytesToMebibyte(subExperiment.ImageSize.CompressedBytes)
+	}
+	u.RegistryGBMonths += imageSizeMB / 1024 * duration.Hours() / month.Hours()
+}
+
+// PlannedCost estimates the cost of the sub-experiment from its configuration, before it runs. Each instance serving
+// the largest burst of each endpoint is assumed to go through one cold start.
+func (s *SubExperiment) PlannedCost(pricing Pricing) CostEstimate {
+	var usage CostUsage
+	parallelism := util.IntegerMax(s.Parallelism, 1)
+
+	for burstID := 0; burstID < s.Bursts; burstID++ {
+		deltaIndex := burstID / parallelism
+		serviceTime := time.Duration(0)
+		if len(s.DesiredServiceTimes) > 0 {
+			serviceTime, _ = time.ParseDuration(s.DesiredServiceTimes[util.IntegerMin(deltaIndex, len(s.DesiredServiceTimes)-1)])
+		}
+		burstSize := s.BurstSizes[deltaIndex%len(s.BurstSizes)]
+		usage.addRequests(pricing, s, float64(burstSize), float64(serviceTime.Microseconds())/1000)
+	}
+
+	if initTime, err := s.InitTime(); err == nil && initTime > 0 {
+		maxBurstSize := 0
+		for _, burstSize := range s.BurstSizes {
+			maxBurstSize = util.IntegerMax(maxBurstSize, burstSize)
+		}
+		coldStarts := float64(parallelism * maxBurstSize * util.IntegerMax(s.DataTransferChainLength, 1))
+		usage.addComputeSeconds(s, coldStarts*initTime.Seconds())
+	}
+
+	plannedDuration := time.Duration(float64(s.Bursts) / float64(parallelism) * s.IATSeconds * float64(time.Second))
+	usage.addRegistryStorage(s, plannedDuration)
+	return pricing.Estimate(usage)
+}
+
+// RecordedCost estimates the cost of the sub-experiment from the billed duration (ms) of each request it made
+// and the time it ran for.
+func (s *SubExperiment) RecordedCost(pricing Pricing, requestDurationsMs []float64, runDuration time.Duration) CostEstimate {
+	var usage CostUsage
+	for _, durationMs := range requestDurationsMs {
+		usage.addRequests(pricing, s, 1, durationMs)
+	}
+	usage.addRegistryStorage(s, runDuration)
+	return pricing.Estimate(usage)
+}
+
+// EnforceBudget estimates the cost of the sub-experiments about to run (all of them if specificExperiment is -1)
+// and stops the run if it exceeds the configured MaxBudgetUSD, unless forced.
+func EnforceBudget(config *Configuration, specificExperiment int, force bool) CostEstimate {
+	pricing := config.PricingModel()
+
+	var total CostEstimate
+	for index := range config.SubExperiments {
+		if specificExperiment != -1 && specificExperiment != index {
+			continue
+		}
+		estimate := config.SubExperiments[index].PlannedCost(pricing)
+		log.Infof("[sub-experiment %d] Estimated cost is $%.4f (%.0f requests, %.1f GB-s).",
+			index, estimate.TotalUSD(), estimate.Usage.Requests, estimate.Usage.GBSeconds)
+		total = total.Add(estimate)
+	}
+	log.Infof("Estimated cost of the experiment on %s is $%.4f (requests $%.4f, compute $%.4f, gateway $%.4f, transfer $%.4f, registry $%.4f).",
+		config.Provider, total.TotalUSD(), total.RequestsUSD, total.ComputeUSD, total.GatewayUSD, total.TransferUSD, total.RegistryUSD)
+
+	if config.MaxBudgetUSD > 0 && total.TotalUSD() > config.MaxBudgetUSD {
+		if !force {
+			log.Fatalf("Estimated cost $%.4f exceeds the budget of $%.2f (MaxBudgetUSD), aborting. Use -force to run anyway.",
+				total.TotalUSD(), config.MaxBudgetUSD)
+		}
+		log.Warnf("Estimated cost $%.4f exceeds the budget of $%.2f (MaxBudgetUSD), running anyway as forced.",
+			total.TotalUSD(), config.MaxBudgetUSD)
+	}
+	return total
+}
@@ -23,89 +23,90 @@
 package amazon
 
 import (
+	"context"
 	"fmt"
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/service/apigateway"
-	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigateway"
+	apigatewaytypes "github.com/aws/aws-sdk-go-v2/service/apigateway/types"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambdatypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
 	log "github.com/sirupsen/logrus"
 	"strings"
 )
 
-func (instance awsSingleton) DeployFunction(binaryPath string, packageType string, language string, memoryAssigned int64) string {
-	apiConfig := instance.createRESTAPI()
+func (instance awsSingleton) DeployFunction(ctx context.Context, binaryPath string, packageType string, language string, memoryAssigned int64) string {
+	apiConfig := instance.createRESTAPI(ctx)
 
 	functionName := fmt.Sprintf("%s%s", namingPrefix, *apiConfig.Id)
-	functionConfig := instance.createFunction(binaryPath, packageType, functionName, language, memoryAssigned)
+	functionConfig := instance.createFunction(ctx, binaryPath, packageType, functionName, language, memoryAssigned)
 
-	resourceID := instance.getResourceID(*apiConfig.Name, *apiConfig.Id)
-	instance.createAPIFunctionIntegration(*apiConfig.Name, functionName, *apiConfig.Id, resourceID, *functionConfig.FunctionArn)
-	instance.createAPIDeployment(*apiConfig.Name, *apiConfig.Id)
-	instance.addExecutionPermissions(functionName)
+	resourceID := instance.getResourceID(ctx, *apiConfig.Name, *apiConfig.Id)
+	instance.createAPIFunctionIntegration(ctx, *apiConfig.Name, functionName, *apiConfig.Id, resourceID, *functionConfig.FunctionArn)
+	instance.createAPIDeployment(ctx, *apiConfig.Name, *apiConfig.Id)
+	instance.addExecutionPermissions(ctx, functionName)
 
 	return *apiConfig.Id
 }
 
-func (instance awsSingleton) createRESTAPI() *apigateway.RestApi {
+func (instance awsSingleton) createRESTAPI(ctx context.Context) *apigateway.PutRestApiOutput {
 	log.Info("Creating REST API...")
 
 	createArgs := &apigateway.CreateRestApiInput{
 		Name:                  aws.String("vHive-API"),
-		EndpointConfiguration: &apigateway.EndpointConfiguration{Types: aws.StringSlice([]string{"REGIONAL"})},
+		EndpointConfiguration: &apigatewaytypes.EndpointConfiguration{Types: []apigatewaytypes.EndpointType{apigatewaytypes.EndpointTypeRegional}},
 	}
 
-	result, err := instance.apiGatewaySvc.CreateRestApi(createArgs)
+	result, err := instance.apiGatewaySvc.CreateRestApi(ctx, createArgs)
 	if err != nil {
 		if strings.Contains(err.Error(), "TooManyRequestsException") {
 			log.Warnf("Facing AWS rate-limiting error, retrying...")
-			return instance.createRESTAPI()
+			return instance.createRESTAPI(ctx)
 		}
 
 		log.Fatalf("Cannot create REST API: %s", err.Error())
 	}
-	log.Debugf("Create REST API result: %s", result.String())
+	log.Debugf("Create REST API result: %s", describe(result))
 
-	result, _ = instance.updateAPIWithTemplate(*result.Id)
-
-	return result
+	return instance.updateAPIWithTemplate(ctx, *result.Id)
 }
 
-func (instance awsSingleton) updateAPIWithTemplate(apiID string) (*apigateway.RestApi, error) {
+func (instance awsSingleton) updateAPIWithTemplate(ctx context.Context, apiID string) *apigateway.PutRestApiOutput {
 	putAPIArgs := &apigateway.PutRestApiInput{
 		Body:      instance.apiTemplateFileContents,
-		Mode:      aws.String("merge"),
+		Mode:      apigatewaytypes.PutModeMerge,
 		RestApiId: aws.String(apiID),
 	}
 
-	result, err := instance.apiGatewaySvc.PutRestApi(putAPIArgs)
+	result, err := instance.apiGatewaySvc.PutRestApi(ctx, putAPIArgs)
 	if err != nil {
 		if strings.Contains(err.Error(), "TooManyRequestsException") {
 			log.Warnf("Facing AWS rate-limiting error, retrying...")
-			return instance.updateAPIWithTemplate(apiID)
+			return instance.updateAPIWithTemplate(ctx, apiID)
 		}
 
 		log.Fatalf("Cannot update REST API with template: %s", err.Error())
 	}
-	log.Debugf("Update REST API with template result: %s", result.String())
-	return result, nil
+	log.Debugf("Update REST API with template result: %s", describe(result))
+	return result
 }
 
-func (instance awsSingleton) getResourceID(APIName string, apiID string) string {
+func (instance awsSingleton) getResourceID(ctx context.Context, APIName string, apiID string) string {
 	args := &apigateway.GetResourcesInput{
 		RestApiId: aws.String(apiID),
 	}
 
-	result, err := instance.apiGatewaySvc.GetResources(args)
+	result, err := instance.apiGatewaySvc.GetResources(ctx, args)
 	if err != nil {
 		if strings.Contains(err.Error(), "TooManyRequestsException") {
 			log.Warnf("Facing AWS rate-limiting error, retrying...")
-			return instance.getResourceID(APIName, apiID)
+			return instance.getResourceID(ctx, APIName, apiID)
 		}
 
 		log.Fatalf("Cannot get API resources: %s", err.Error())
 	}
-	log.Debugf("Get API resources result: %s", result.String())
+	log.Debugf("Get API resources result: %s", describe(result))
 
-	for _, resource := range (*result).Items {
+	for _, resource := range result.Items {
 		if resource.ParentId != nil {
 			log.Infof("RESOURCEID of %s is %s", APIName, *resource.Id)
 			return *resource.Id
@@ -116,99 +117,99 @@ func (instance awsSingleton) getResourceID(APIName string, apiID string) string
 	return ""
 }
 
-func (instance awsSingleton) createFunction(binaryPath string, packageType string, functionName string, language string, memoryAssigned int64) *lambda.FunctionConfiguration {
+func (instance awsSingleton) createFunction(ctx context.Context, binaryPath string, packageType string, functionName string, language string, memoryAssigned int64) *lambda.CreateFunctionOutput {
 	var lambdaExecutionRole = fmt.Sprintf("arn:aws:iam::%s:role/LambdaProducerConsumer", UserARNNumber)
 	log.Infof("Creating producer function %s with role ARN %s", functionName, lambdaExecutionRole)
 
 	var createArgs *lambda.CreateFunctionInput
 	switch packageType {
 	case "Zip":
-		var createCode *lambda.FunctionCode
+		var createCode *lambdatypes.FunctionCode
 		if instance.S3Key != "" {
-			createCode = &lambda.FunctionCode{
+			createCode = &lambdatypes.FunctionCode{
 				S3Bucket: aws.String(AWSSingletonInstance.S3Bucket),
 				S3Key:    aws.String(instance.S3Key),
 			}
 		} else {
-			createCode = &lambda.FunctionCode{
+			createCode = &lambdatypes.FunctionCode{
 				ZipFile: instance.localZipFileContents,
 			}
 		}
 
 		createArgs = &lambda.CreateFunctionInput{
-			PackageType:   aws.String(lambda.PackageTypeZip),
+			PackageType:   lambdatypes.PackageTypeZip,
 			Code:          createCode,
 			Description:   aws.String("Benchmarking function managed and used by vHive-bench."),
 			Role:          aws.String(lambdaExecutionRole),
 			FunctionName:  aws.String(functionName),
 			Handler:       aws.String(binaryPath),
-			Runtime:       aws.String(language),
-			TracingConfig: &lambda.TracingConfig{Mode: aws.String("PassThrough")},
-			Timeout:       aws.Int64(maxFunctionTimeout),
-			MemorySize:    aws.Int64(memoryAssigned),
+			Runtime:       lambdatypes.Runtime(language),
+			TracingConfig: &lambdatypes.TracingConfig{Mode: lambdatypes.TracingModePassThrough},
+			Timeout:       aws.Int32(maxFunctionTimeout),
+			MemorySize:    aws.Int32(int32(memoryAssigned)),
 		}
 	case "Image":
 		createArgs = &lambda.CreateFunctionInput{
-			PackageType: aws.String(lambda.PackageTypeImage),
-			Code: &lambda.FunctionCode{
+			PackageType: lambdatypes.PackageTypeImage,
+			Code: &lambdatypes.FunctionCode{
 				ImageUri: aws.String(instance.ImageURI),
 			},
 			Description:   aws.String("Benchmarking function managed and used by vHive-bench."),
 			Role:          aws.String(lambdaExecutionRole),
 			FunctionName:  aws.String(functionName),
-			TracingConfig: &lambda.TracingConfig{Mode: aws.String("PassThrough")},
-			Timeout:       aws.Int64(maxFunctionTimeout),
-			MemorySize:    aws.Int64(memoryAssigned),
+			TracingConfig: &lambdatypes.TracingConfig{Mode: lambdatypes.TracingModePassThrough},
+			Timeout:       aws.Int32(maxFunctionTimeout),
+			MemorySize:    aws.Int32(int32(memoryAssigned)),
 		}
 	default:
 		log.Fatalf("Package type %s not supported for function creation.", packageType)
 	}
 
-	result, err := instance.lambdaSvc.CreateFunction(createArgs)
+	result, err := instance.lambdaSvc.CreateFunction(ctx, createArgs)
 	if err != nil {
 		if strings.Contains(err.Error(), "TooManyRequestsException") {
 			log.Warnf("Facing AWS rate-limiting error, retrying...")
-			return instance.createFunction(binaryPath, packageType, functionName, language, memoryAssigned)
+			return instance.createFunction(ctx, binaryPath, packageType, functionName, language, memoryAssigned)
 		}
 
 		log.Fatalf("Cannot create function: %s", err.Error())
 	}
-	log.Debugf("Create function result: %s", result.String())
+	log.Debugf("Create function result: %s", describe(result))
 
 	return result
 }
 
-func (instance awsSingleton) createAPIFunctionIntegration(APIName string, functionName string, apiID string, resourceID string, arn string) *apigateway.Integration {
+func (instance awsSingleton) createAPIFunctionIntegration(ctx context.Context, APIName string, functionName string, apiID string, resourceID string, arn string) *apigateway.PutIntegrationOutput {
 	log.Infof("Creating integration between lambda %s and API %s", APIName, functionName)
 
 	args := &apigateway.PutIntegrationInput{
 		HttpMethod:            aws.String("ANY"),
 		IntegrationHttpMethod: aws.String("ANY"),
-		RequestTemplates: aws.StringMap(map[string]string{
+		RequestTemplates: map[string]string{
 			"application/x-www-form-urlencoded": `{\"body\": $input.json(\"$\`,
-		}),
+		},
 		ResourceId: aws.String(resourceID),
 		RestApiId:  aws.String(apiID),
-		Type:       aws.String("AWS_PROXY"),
+		Type:       apigatewaytypes.IntegrationTypeAwsProxy,
 		Uri: aws.String(fmt.Sprintf("arn:aws:apigateway:%s:lambda:path/2015-03-31/functions/%s/invocations",
 			AWSRegion, arn)),
 	}
 
-	result, err := instance.apiGatewaySvc.PutIntegration(args)
+	result, err := instance.apiGatewaySvc.PutIntegration(ctx, args)
 	if err != nil {
 		if strings.Contains(err.Error(), "TooManyRequestsException") {
 			log.Warnf("Facing AWS rate-limiting error, retrying...")
-			return instance.createAPIFunctionIntegration(APIName, functionName, apiID, resourceID, arn)
+			return instance.createAPIFunctionIntegration(ctx, APIName, functionName, apiID, resourceID, arn)
 		}
 
 		log.Fatalf("Cannot put rest API - lambda function integration: %s", err.Error())
 	}
-	log.Debugf("Put rest API - lambda function integration result: %s", result.String())
+	log.Debugf("Put rest API - lambda function integration result: %s", describe(result))
 
 	return result
 }
 
-func (instance awsSingleton) createAPIDeployment(APIName string, apiID string) *apigateway.Deployment {
+func (instance awsSingleton) createAPIDeployment(ctx context.Context, APIName string, apiID string) *apigateway.CreateDeploymentOutput {
 	log.Infof("Creating deployment for API %s (stage %s)", APIName, deploymentStage)
 
 	args := &apigateway.CreateDeploymentInput{
@@ -216,21 +217,21 @@ func (instance awsSingleton) createAPIDeployment(APIName string, apiID string) *
 		StageName: aws.String(deploymentStage),
 	}
 
-	result, err := instance.apiGatewaySvc.CreateDeployment(args)
+	result, err := instance.apiGatewaySvc.CreateDeployment(ctx, args)
 	if err != nil {
 		if strings.Contains(err.Error(), "TooManyRequestsException") {
 			log.Warnf("Facing AWS rate-limiting error, retrying...")
-			return instance.createAPIDeployment(APIName, apiID)
+			return instance.createAPIDeployment(ctx, APIName, apiID)
 		}
 
 		log.Fatalf("Cannot create API deployment: %s", err.Error())
 	}
-	log.Debugf("Create API deployment result: %s", result.String())
+	log.Debugf("Create API deployment result: %s", describe(result))
 
 	return result
 }
 
-func (instance awsSingleton) addExecutionPermissions(functionName string) *lambda.AddPermissionOutput {
+func (instance awsSingleton) addExecutionPermissions(ctx context.Context, functionName string) *lambda.AddPermissionOutput {
 	log.Infof("Adding permissions to execute lambda function %s", functionName)
 
 	args := &lambda.AddPermissionInput{
@@ -240,16 +241,16 @@ func (instance awsSingleton) addExecutionPermissions(functionName string) *lambd
 		StatementId:  aws.String("apigateway-benchmarking"),
 	}
 
-	result, err := instance.lambdaSvc.AddPermission(args)
+	result, err := instance.lambdaSvc.AddPermission(ctx, args)
 	if err != nil {
 		if strings.Contains(err.Error(), "TooManyRequestsException") {
 			log.Warnf("Facing AWS rate-limiting error, retrying...")
-			return instance.addExecutionPermissions(functionName)
+			return instance.addExecutionPermissions(ctx, functionName)
 		}
 
 		log.Fatalf("Cannot add permission: %s", err.Error())
 	}
-	log.Debugf("Add permission result: %s", result.String())
+	log.Debugf("Add permission result: %s", describe(result))
 
 	return result
 }
@@ -0,0 +1,366 @@
+// MIT License
+//
+// Copyright (c) 2021 Theodor Amariucai and EASE Lab
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package amazon
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	apigatewayv2types "github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambdatypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	log "github.com/sirupsen/logrus"
+	"os"
+	"stellar/util"
+	"strings"
+	"time"
+)
+
+const (
+	// maxDirectUploadBytes is the largest zip that can be passed inline when creating a function, larger ones go through S3
+	maxDirectUploadBytes = 50 * 1024 * 1024
+	httpAPIStage         = "$default"
+	maxFunctionStateWait = 5 * time.Minute
+)
+
+// HTTPAPIFunction describes a Lambda function to be deployed behind a route of an HTTP API.
+type HTTPAPIFunction struct {
+	Name        string
+	Route       string
+	Handler     string
+	Runtime     string
+	MemoryMB    int64
+	ZipPath     string
+	SnapStart   bool
+	Environment map[string]string
+}
+
+// HTTPAPIDeployment records the resources created by DeployHTTPAPI so that they can be removed afterwards.
+type HTTPAPIDeployment struct {
+	APIID         string
+	APIEndpoint   string
+	FunctionNames []string
+	S3Keys        []string
+}
+
+// EndpointID returns the identifier of the API as it appears in its execute-api hostname.
+func (deployment HTTPAPIDeployment) EndpointID() string {
+	hostname := strings.TrimPrefix(deployment.APIEndpoint, "https://")
+	return strings.Split(hostname, ".")[0]
+}
+
+// DeployHTTPAPI creates an HTTP API with one GET route per function, then creates each function, publishes a
+// version for those with SnapStart enabled and integrates it with its route.
+func (instance awsSingleton) DeployHTTPAPI(ctx context.Context, apiName string, functions []HTTPAPIFunction) HTTPAPIDeployment {
+	api := instance.createHTTPAPI(ctx, apiName)
+	instance.createHTTPAPIStage(ctx, *api.ApiId)
+
+	deployment := HTTPAPIDeployment{APIID: *api.ApiId, APIEndpoint: *api.ApiEndpoint}
+	for _, function := range functions {
+		code, s3Key := instance.functionCode(ctx, function)
+		if s3Key != "" {
+			deployment.S3Keys = append(deployment.S3Keys, s3Key)
+		}
+
+		functionConfig := instance.createHTTPAPIFunction(ctx, function, code)
+		deployment.FunctionNames = append(deployment.FunctionNames, function.Name)
+		instance.waitUntilFunctionActive(ctx, function.Name)
+
+		targetARN := *functionConfig.FunctionArn
+		if function.SnapStart {
+			targetARN = *instance.publishVersion(ctx, function.Name).FunctionArn
+		}
+
+		integration := instance.createHTTPAPIIntegration(ctx, *api.ApiId, targetARN)
+		instance.createHTTPAPIRoute(ctx, *api.ApiId, function.Route, *integration.IntegrationId)
+		instance.addHTTPAPIExecutionPermissions(ctx, *api.ApiId, targetARN)
+	}
+
+	log.Infof("Deployed %d functions behind HTTP API %s (%s).", len(functions), deployment.APIID, deployment.APIEndpoint)
+	return deployment
+}
+
+// RemoveHTTPAPI removes the HTTP API, functions and uploaded code recorded in the given deployment.
+func (instance awsSingleton) RemoveHTTPAPI(ctx context.Context, deployment HTTPAPIDeployment) {
+	instance.deleteHTTPAPI(ctx, deployment.APIID)
+	for _, functionName := range deployment.FunctionNames {
+		instance.deleteFunction(ctx, functionName)
+	}
+	for _, s3Key := range deployment.S3Keys {
+		instance.deleteS3Object(ctx, s3Key)
+	}
+}
+
+func (instance awsSingleton) createHTTPAPI(ctx context.Context, apiName string) *apigatewayv2.CreateApiOutput {
+	log.Infof("Creating HTTP API %s...", apiName)
+
+	result, err := instance.httpAPISvc.CreateApi(ctx, &apigatewayv2.CreateApiInput{
+		Name:         aws.String(apiName),
+		ProtocolType: apigatewayv2types.ProtocolTypeHttp,
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "TooManyRequestsException") {
+			log.Warnf("Facing AWS rate-limiting error, retrying...")
+			return instance.createHTTPAPI(ctx, apiName)
+		}
+
+		log.Fatalf("Cannot create HTTP API: %s", err.Error())
+	}
+	log.Debugf("Create HTTP API result: %s", describe(result))
+
+	return result
+}
+
+func (instance awsSingleton) createHTTPAPIStage(ctx context.Context, apiID string) *apigatewayv2.CreateStageOutput {
+	log.Infof("Creating auto-deployed stage %s for HTTP API %s", httpAPIStage, apiID)
+
+	result, err := instance.httpAPISvc.CreateStage(ctx, &apigatewayv2.CreateStageInput{
+		ApiId:      aws.String(apiID),
+		StageName:  aws.String(httpAPIStage),
+		AutoDeploy: aws.Bool(true),
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "TooManyRequestsException") {
+			log.Warnf("Facing AWS rate-limiting error, retrying...")
+			return instance.createHTTPAPIStage(ctx, apiID)
+		}
+
+		log.Fatalf("Cannot create HTTP API stage: %s", err.Error())
+	}
+	log.Debugf("Create HTTP API stage result: %s", describe(result))
+
+	return result
+}
+
+// functionCode reads the zipped function, uploading it to S3 when it is too large to be passed inline.
+func (instance awsSingleton) functionCode(ctx context.Context, function HTTPAPIFunction) (*lambdatypes.FunctionCode, string) {
+	zipBytes, err := os.ReadFile(function.ZipPath)
+	if err != nil {
+		log.Fatalf("Could not read zipped function %s: %s", function.ZipPath, err.Error())
+	}
+
+	if len(zipBytes) <= maxDirectUploadBytes {
+		return &lambdatypes.FunctionCode{ZipFile: zipBytes}, ""
+	}
+
+	s3Key := fmt.Sprintf("%s.zip", function.Name)
+	log.Infof("Package of function %s (~%vMB) > 50 MB, uploading it to Amazon S3.", function.Name, util.BytesToMebibyte(int64(len(zipBytes))))
+	zipFile, err := os.Open(function.ZipPath)
+	if err != nil {
+		log.Fatalf("Failed to open zip file %q: %v", function.ZipPath, err)
+	}
+	defer zipFile.Close()
+
+	if _, err := instance.s3Uploader.Upload(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(instance.S3Bucket),
+		Key:    aws.String(s3Key),
+		Body:   zipFile,
+	}); err != nil {
+		log.Fatalf("Unable to upload %q to %q, %v", s3Key, instance.S3Bucket, err.Error())
+	}
+
+	return &lambdatypes.FunctionCode{S3Bucket: aws.String(instance.S3Bucket), S3Key: aws.String(s3Key)}, s3Key
+}
+
+func (instance awsSingleton) createHTTPAPIFunction(ctx context.Context, function HTTPAPIFunction, code *lambdatypes.FunctionCode) *lambda.CreateFunctionOutput {
+	var lambdaExecutionRole = fmt.Sprintf("arn:aws:iam::%s:role/LambdaProducerConsumer", UserARNNumber)
+	log.Infof("Creating function %s with role ARN %s", function.Name, lambdaExecutionRole)
+
+	createArgs := &lambda.CreateFunctionInput{
+		PackageType:   lambdatypes.PackageTypeZip,
+		Code:          code,
+		Description:   aws.String("Benchmarking function managed and used by vHive-bench."),
+		Role:          aws.String(lambdaExecutionRole),
+		FunctionName:  aws.String(function.Name),
+		Handler:       aws.String(function.Handler),
+		Runtime:       lambdatypes.Runtime(function.Runtime),
+		TracingConfig: &lambdatypes.TracingConfig{Mode: lambdatypes.TracingModePassThrough},
+		Timeout:       aws.Int32(maxFunctionTimeout),
+		MemorySize:    aws.Int32(int32(function.MemoryMB)),
+	}
+	if function.Environment != nil {
+		createArgs.Environment = &lambdatypes.Environment{Variables: function.Environment}
+	}
+	if function.SnapStart {
+		createArgs.SnapStart = &lambdatypes.SnapStart{ApplyOn: lambdatypes.SnapStartApplyOnPublishedVersions}
+	}
+
+	result, err := instance.lambdaSvc.CreateFunction(ctx, createArgs)
+	if err != nil {
+		if strings.Contains(err.Error(), "TooManyRequestsException") {
+			log.Warnf("Facing AWS rate-limiting error, retrying...")
+			return instance.createHTTPAPIFunction(ctx, function, code)
+		}
+
+		log.Fatalf("Cannot create function: %s", err.Error())
+	}
+	log.Debugf("Create function result: %s", describe(result))
+
+	return result
+}
+
+func (instance awsSingleton) waitUntilFunctionActive(ctx context.Context, functionName string) {
+	if err := lambda.NewFunctionActiveV2Waiter(instance.lambdaSvc).Wait(ctx,
+		&lambda.GetFunctionInput{FunctionName: aws.String(functionName)}, maxFunctionStateWait); err != nil {
+		log.Fatalf("Function %s did not become active: %s", functionName, err.Error())
+	}
+}
+
+// publishVersion publishes a version of the function, which is when SnapStart takes its snapshot.
+func (instance awsSingleton) publishVersion(ctx context.Context, functionName string) *lambda.PublishVersionOutput {
+	log.Infof("Publishing a SnapStart version of function %s", functionName)
+
+	result, err := instance.lambdaSvc.PublishVersion(ctx, &lambda.PublishVersionInput{FunctionName: aws.String(functionName)})
+	if err != nil {
+		if strings.Contains(err.Error(), "TooManyRequestsException") {
+			log.Warnf("Facing AWS rate-limiting error, retrying...")
+			return instance.publishVersion(ctx, functionName)
+		}
+
+		log.Fatalf("Cannot publish function version: %s", err.Error())
+	}
+	log.Debugf("Publish function version result: %s", describe(result))
+
+	if err := lambda.NewPublishedVersionActiveWaiter(instance.lambdaSvc).Wait(ctx, &lambda.GetFunctionConfigurationInput{
+		FunctionName: aws.String(functionName),
+		Qualifier:    result.Version,
+	}, maxFunctionStateWait); err != nil {
+		log.Fatalf("Version %s of function %s did not become active: %s", *result.Version, functionName, err.Error())
+	}
+
+	return result
+}
+
+func (instance awsSingleton) createHTTPAPIIntegration(ctx context.Context, apiID string, functionARN string) *apigatewayv2.CreateIntegrationOutput {
+	log.Infof("Creating integration between lambda %s and HTTP API %s", functionARN, apiID)
+
+	result, err := instance.httpAPISvc.CreateIntegration(ctx, &apigatewayv2.CreateIntegrationInput{
+		ApiId:                aws.String(apiID),
+		IntegrationType:      apigatewayv2types.IntegrationTypeAwsProxy,
+		IntegrationUri:       aws.String(functionARN),
+		PayloadFormatVersion: aws.String("2.0"),
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "TooManyRequestsException") {
+			log.Warnf("Facing AWS rate-limiting error, retrying...")
+			return instance.createHTTPAPIIntegration(ctx, apiID, functionARN)
+		}
+
+		log.Fatalf("Cannot create HTTP API - lambda function integration: %s", err.Error())
+	}
+	log.Debugf("Create HTTP API - lambda function integration result: %s", describe(result))
+
+	return result
+}
+
+func (instance awsSingleton) createHTTPAPIRoute(ctx context.Context, apiID string, route string, integrationID string) *apigatewayv2.CreateRouteOutput {
+	routeKey := fmt.Sprintf("GET /%s", route)
+	log.Infof("Creating route %q for HTTP API %s", routeKey, apiID)
+
+	result, err := instance.httpAPISvc.CreateRoute(ctx, &apigatewayv2.CreateRouteInput{
+		ApiId:    aws.String(apiID),
+		RouteKey: aws.String(routeKey),
+		Target:   aws.String(fmt.Sprintf("integrations/%s", integrationID)),
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "TooManyRequestsException") {
+			log.Warnf("Facing AWS rate-limiting error, retrying...")
+			return instance.createHTTPAPIRoute(ctx, apiID, route, integrationID)
+		}
+
+		log.Fatalf("Cannot create HTTP API route: %s", err.Error())
+	}
+	log.Debugf("Create HTTP API route result: %s", describe(result))
+
+	return result
+}
+
+func (instance awsSingleton) addHTTPAPIExecutionPermissions(ctx context.Context, apiID string, functionARN string) *lambda.AddPermissionOutput {
+	log.Infof("Adding permissions for HTTP API %s to execute lambda function %s", apiID, functionARN)
+
+	result, err := instance.lambdaSvc.AddPermission(ctx, &lambda.AddPermissionInput{
+		Action:       aws.String("lambda:InvokeFunction"),
+		FunctionName: aws.String(functionARN),
+		Principal:    aws.String("apigateway.amazonaws.com"),
+		SourceArn:    aws.String(fmt.Sprintf("arn:aws:execute-api:%s:%s:%s/*", AWSRegion, UserARNNumber, apiID)),
+		StatementId:  aws.String("apigateway-benchmarking"),
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "TooManyRequestsException") {
+			log.Warnf("Facing AWS rate-limiting error, retrying...")
+			return instance.addHTTPAPIExecutionPermissions(ctx, apiID, functionARN)
+		}
+
+		log.Fatalf("Cannot add permission: %s", err.Error())
+	}
+	log.Debugf("Add permission result: %s", describe(result))
+
+	return result
+}
+
+func (instance awsSingleton) deleteHTTPAPI(ctx context.Context, apiID string) {
+	log.Infof("Removing HTTP API with ID %q", apiID)
+
+	result, err := instance.httpAPISvc.DeleteApi(ctx, &apigatewayv2.DeleteApiInput{ApiId: aws.String(apiID)})
+	if err != nil {
+		if strings.Contains(err.Error(), "TooManyRequestsException") {
+			log.Warnf("Facing AWS rate-limiting error, retrying...")
+			instance.deleteHTTPAPI(ctx, apiID)
+			return
+		}
+
+		log.Errorf("Cannot remove HTTP API: %s", err.Error())
+		return
+	}
+	log.Debugf("Remove HTTP API result: %s", describe(result))
+}
+
+func (instance awsSingleton) deleteFunction(ctx context.Context, functionName string) {
+	log.Infof("Removing lambda function %q", functionName)
+
+	result, err := instance.lambdaSvc.DeleteFunction(ctx, &lambda.DeleteFunctionInput{FunctionName: aws.String(functionName)})
+	if err != nil {
+		if strings.Contains(err.Error(), "TooManyRequestsException") {
+			log.Warnf("Facing AWS rate-limiting error, retrying...")
+			instance.deleteFunction(ctx, functionName)
+			return
+		}
+
+		log.Errorf("Cannot remove function: %s", err.Error())
+		return
+	}
+	log.Debugf("Remove function result: %s", describe(result))
+}
+
+func (instance awsSingleton) deleteS3Object(ctx context.Context, s3Key string) {
+	log.Infof("Removing object %q from S3 bucket %q", s3Key, instance.S3Bucket)
+
+	if _, err := instance.s3Svc.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(instance.S3Bucket),
+		Key:    aws.String(s3Key),
+	}); err != nil {
+		log.Errorf("Cannot remove S3 object: %s", err.Error())
+	}
+}
@@ -23,37 +23,30 @@
 package amazon
 
 import (
-	"github.com/aws/aws-sdk-go/service/lambda"
+	"context"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambdatypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
 	log "github.com/sirupsen/logrus"
 	"strings"
 )
 
-func (instance awsSingleton) ListFunctions(marker *string) []*lambda.FunctionConfiguration {
+func (instance awsSingleton) ListFunctions(ctx context.Context) []lambdatypes.FunctionConfiguration {
 	log.Info("Querying Lambda functions...")
-	var queriedFunctions []*lambda.FunctionConfiguration
-
-	args := &lambda.ListFunctionsInput{
-		Marker: marker,
-	}
-
-	result, err := instance.lambdaSvc.ListFunctions(args)
-	if err != nil {
-		if strings.Contains(err.Error(), "TooManyRequestsException") {
-			log.Warnf("Facing AWS rate-limiting error, retrying...")
-			return instance.ListFunctions(nil)
+	var queriedFunctions []lambdatypes.FunctionConfiguration
+
+	paginator := lambda.NewListFunctionsPaginator(instance.lambdaSvc, &lambda.ListFunctionsInput{})
+	for paginator.HasMorePages() {
+		result, err := paginator.NextPage(ctx)
+		if err != nil {
+			if strings.Contains(err.Error(), "TooManyRequestsException") {
+				log.Warnf("Facing AWS rate-limiting error, retrying...")
+				return instance.ListFunctions(ctx)
+			}
+
+			log.Fatalf("Cannot list Lambda functions: %s", err.Error())
 		}
 
-		log.Fatalf("Cannot list Lambda functions: %s", err.Error())
-	}
-
-	if result == nil {
-		log.Fatalf("List Lambda functions result was nil.")
-		return nil
-	}
-
-	queriedFunctions = append(queriedFunctions, result.Functions...)
-	if (*result).NextMarker != nil {
-		queriedFunctions = append(queriedFunctions, instance.ListFunctions((*result).NextMarker)...)
+		queriedFunctions = append(queriedFunctions, result.Functions...)
 	}
 
 	return queriedFunctions
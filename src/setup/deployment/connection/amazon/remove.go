@@ -23,15 +23,16 @@
 package amazon
 
 import (
+	"context"
 	"fmt"
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/service/apigateway"
-	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigateway"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
 	log "github.com/sirupsen/logrus"
 	"strings"
 )
 
-func (instance awsSingleton) RemoveFunction(uniqueID string) *lambda.DeleteFunctionOutput {
+func (instance awsSingleton) RemoveFunction(ctx context.Context, uniqueID string) *lambda.DeleteFunctionOutput {
 	functionName := fmt.Sprintf("%s%s", namingPrefix, uniqueID)
 	log.Infof("Removing lambda function %q", functionName)
 
@@ -39,36 +40,36 @@ func (instance awsSingleton) RemoveFunction(uniqueID string) *lambda.DeleteFunct
 		FunctionName: aws.String(functionName),
 	}
 
-	result, err := instance.lambdaSvc.DeleteFunction(args)
+	result, err := instance.lambdaSvc.DeleteFunction(ctx, args)
 	if err != nil {
 		if strings.Contains(err.Error(), "TooManyRequestsException") {
 			log.Warnf("Facing AWS rate-limiting error, retrying...")
-			return instance.RemoveFunction(uniqueID)
+			return instance.RemoveFunction(ctx, uniqueID)
 		}
 
 		log.Errorf("Cannot remove function: %s", err.Error())
 	}
-	log.Debugf("Remove function result: %s", result.String())
+	log.Debugf("Remove function result: %s", describe(result))
 
 	return result
 }
 
 //RemoveAPIGateway will remove the gateway corresponding to the serverless function given ID.
-func (instance awsSingleton) RemoveAPIGateway(uniqueID string) *apigateway.DeleteRestApiOutput {
+func (instance awsSingleton) RemoveAPIGateway(ctx context.Context, uniqueID string) *apigateway.DeleteRestApiOutput {
 	log.Infof("Removing API Gateway with ID %q", uniqueID)
 
 	args := &apigateway.DeleteRestApiInput{RestApiId: aws.String(uniqueID)}
 
-	result, err := instance.apiGatewaySvc.DeleteRestApi(args)
+	result, err := instance.apiGatewaySvc.DeleteRestApi(ctx, args)
 	if err != nil {
 		if strings.Contains(err.Error(), "TooManyRequestsException") {
 			log.Warnf("Facing AWS rate-limiting error, retrying...")
-			return instance.RemoveAPIGateway(uniqueID)
+			return instance.RemoveAPIGateway(ctx, uniqueID)
 		}
 
 		log.Errorf("Cannot remove REST API: %s", err.Error())
 	}
-	log.Debugf("Remove REST API result: %s", result.String())
+	log.Debugf("Remove REST API result: %s", describe(result))
 
 	return result
 }
@@ -23,34 +23,46 @@
 package amazon
 
 import (
+	"context"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/endpoints"
-	"github.com/aws/aws-sdk-go/aws/session"
-	v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
-	"github.com/aws/aws-sdk-go/service/apigateway"
-	"github.com/aws/aws-sdk-go/service/ecr"
-	"github.com/aws/aws-sdk-go/service/lambda"
-	"github.com/aws/aws-sdk-go/service/s3"
-	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/aws/aws-sdk-go-v2/service/apigateway"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	"github.com/aws/aws-sdk-go-v2/service/ecr"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
 	log "github.com/sirupsen/logrus"
 	"io"
+	"net/http"
 	"os"
 	"strings"
 	"stellar/util"
+	"time"
 )
 
 const (
-	//AWSRegion is the region that AWS operates in
-	AWSRegion = endpoints.UsWest1RegionID
 	//AWSBucketName is the name of the bucket where the client operates
 	AWSBucketName      = "stellar"
 	deploymentStage    = "prod"
 	maxFunctionTimeout = 900
 	namingPrefix       = "vHive-bench_"
+	// emptyPayloadHash is the SHA-256 hash of the empty body of the benchmarking requests, used for signing them
+	emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
 )
 
+//AWSRegion is the region that AWS operates in
+var AWSRegion = "us-west-1"
+
+//EndpointURL overrides the endpoints of the AWS services if set, e.g., to point the client to a local AWS emulator.
+var EndpointURL string
+
 //AWSSingletonInstance is an object used to interact with AWS through the methods it exports.
 var AWSSingletonInstance *awsSingleton
 
@@ -58,54 +70,75 @@ var AWSSingletonInstance *awsSingleton
 var UserARNNumber string
 
 type awsSingleton struct {
-	// RequestSigner is the AWS object used for signing HTTP requests
-	RequestSigner *v4.Signer
 	// S3Key is the bucket location in which this specific deployment will be uploaded
 	S3Key string
 	// S3Bucket is the bucket in which this specific deployment will be uploaded
 	S3Bucket string
 	// ImageURI is the location where the docker image is located
 	ImageURI                string
-	s3Uploader              *s3manager.Uploader
-	s3Svc                   *s3.S3
-	lambdaSvc               *lambda.Lambda
-	apiGatewaySvc           *apigateway.APIGateway
-	ecrSvc                  *ecr.ECR
+	credentials             aws.CredentialsProvider
+	requestSigner           *v4.Signer
+	s3Uploader              *manager.Uploader
+	s3Svc                   *s3.Client
+	lambdaSvc               *lambda.Client
+	apiGatewaySvc           *apigateway.Client
+	httpAPISvc              *apigatewayv2.Client
+	ecrSvc                  *ecr.Client
 	apiTemplateFileContents []byte
 	localZipFileContents    []byte
 }
 
 //InitializeSingleton will create a new Amazon awsSingleton to interact with different AWS services.
-func InitializeSingleton(apiTemplatePath string) {
-	sessionInstance := session.Must(session.NewSession(&aws.Config{
-		Region:                         aws.String(AWSRegion),
-		CredentialsChainVerboseErrors:  aws.Bool(true),
-		DisableRestProtocolURICleaning: aws.Bool(true),
-	}))
+func InitializeSingleton(ctx context.Context, apiTemplatePath string) {
+	awsConfig, err := config.LoadDefaultConfig(ctx, config.WithRegion(AWSRegion))
+	if err != nil {
+		log.Fatalf("Could not load AWS configuration: %s", err.Error())
+	}
+	if EndpointURL != "" {
+		log.Infof("Using AWS endpoint %s", EndpointURL)
+		awsConfig.BaseEndpoint = aws.String(EndpointURL)
+	}
 
 	apiTemplateByteValue, err := io.ReadAll(util.ReadFile(apiTemplatePath))
 	if err != nil {
 		log.Fatalf("Could not read API template JSON when initializing AWS connection: %s", err.Error())
 	}
 
+	s3Svc := s3.NewFromConfig(awsConfig, func(options *s3.Options) {
+		// emulators serve the buckets under their path rather than as subdomains
+		options.UsePathStyle = EndpointURL != ""
+	})
+
 	AWSSingletonInstance = &awsSingleton{
-		RequestSigner:           v4.NewSigner(sessionInstance.Config.Credentials),
-		lambdaSvc:               lambda.New(sessionInstance),
-		apiGatewaySvc:           apigateway.New(sessionInstance),
-		s3Svc:                   s3.New(sessionInstance),
-		s3Uploader:              s3manager.NewUploader(sessionInstance),
-		ecrSvc:                  ecr.New(sessionInstance),
+		credentials:             awsConfig.Credentials,
+		requestSigner:           v4.NewSigner(),
+		lambdaSvc:               lambda.NewFromConfig(awsConfig),
+		apiGatewaySvc:           apigateway.NewFromConfig(awsConfig),
+		httpAPISvc:              apigatewayv2.NewFromConfig(awsConfig),
+		s3Svc:                   s3Svc,
+		s3Uploader:              manager.NewUploader(s3Svc),
+		ecrSvc:                  ecr.NewFromConfig(awsConfig),
 		apiTemplateFileContents: apiTemplateByteValue,
 		S3Bucket:                AWSBucketName,
 	}
 }
 
-//UploadZIPToS3 helps get around the 50MB image size limit for AWS functions.
-func UploadZIPToS3(localZipPath string, sizeMB float64) {
+//SignRequest signs the given API Gateway request with the credentials of the client.
+func (instance awsSingleton) SignRequest(request *http.Request) error {
+	credentials, err := instance.credentials.Retrieve(request.Context())
+	if err != nil {
+		return err
+	}
+	return instance.requestSigner.SignHTTP(request.Context(), credentials, request, emptyPayloadHash, "execute-api", AWSRegion, time.Now())
+}
+
+//UploadZIPToS3 helps get around the 50MB image size limit for AWS functions. The key of the object holds the digest of
+//the archive, so that an upload is only skipped if the same archive is already in the bucket.
+func UploadZIPToS3(ctx context.Context, localZipPath string, sizeMB float64) {
 	log.Infof(`Deploying to AWS and package size (~%vMB) > 50 MB, will now attempt to upload to Amazon S3.`, sizeMB)
-	AWSSingletonInstance.S3Key = fmt.Sprintf("benchmarking%vMB.zip", sizeMB)
+	AWSSingletonInstance.S3Key = fmt.Sprintf("benchmarking%vMB-%s.zip", sizeMB, fileDigest(localZipPath))
 
-	if _, err := AWSSingletonInstance.s3Svc.GetObject(&s3.GetObjectInput{
+	if _, err := AWSSingletonInstance.s3Svc.GetObject(ctx, &s3.GetObjectInput{
 		Bucket: aws.String(AWSSingletonInstance.S3Bucket),
 		Key:    aws.String(AWSSingletonInstance.S3Key),
 	}); err == nil {
@@ -117,8 +150,9 @@ func UploadZIPToS3(localZipPath string, sizeMB float64) {
 	if err != nil {
 		log.Fatalf("Failed to open zip file %q: %v", localZipPath, err)
 	}
+	defer zipFile.Close()
 
-	uploadOutput, err := AWSSingletonInstance.s3Uploader.Upload(&s3manager.UploadInput{
+	uploadOutput, err := AWSSingletonInstance.s3Uploader.Upload(ctx, &s3.PutObjectInput{
 		Bucket: aws.String(AWSSingletonInstance.S3Bucket),
 		Key:    aws.String(AWSSingletonInstance.S3Key),
 		Body:   zipFile,
@@ -130,6 +164,21 @@ func UploadZIPToS3(localZipPath string, sizeMB float64) {
 	log.Infof("Successfully uploaded %q to bucket %q (%s)", AWSSingletonInstance.S3Key, AWSSingletonInstance.S3Bucket, uploadOutput.Location)
 }
 
+// fileDigest returns the first 16 hexadecimal digits of the SHA-256 digest of the file
+func fileDigest(path string) string {
+	file, err := os.Open(path)
+	if err != nil {
+		log.Fatalf("Failed to open %q: %v", path, err)
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		log.Fatalf("Failed to read %q: %v", path, err)
+	}
+	return hex.EncodeToString(hash.Sum(nil))[:16]
+}
+
 //SetLocalZip sets the location of the zipped binary file for the function to be deployed.
 func SetLocalZip(path string) {
 	zipBytes, err := os.ReadFile(path)
@@ -140,19 +189,19 @@ func SetLocalZip(path string) {
 }
 
 //GetECRAuthorizationToken helps the client get authorization for container AWS deployment.
-func GetECRAuthorizationToken() string {
+func GetECRAuthorizationToken(ctx context.Context) string {
 	log.Info("Requesting ECR authorization token.")
 
-	result, err := AWSSingletonInstance.ecrSvc.GetAuthorizationToken(&ecr.GetAuthorizationTokenInput{})
+	result, err := AWSSingletonInstance.ecrSvc.GetAuthorizationToken(ctx, &ecr.GetAuthorizationTokenInput{})
 	if err != nil {
 		if strings.Contains(err.Error(), "TooManyRequestsException") {
 			log.Warnf("Facing AWS rate-limiting error, retrying...")
-			return GetECRAuthorizationToken()
+			return GetECRAuthorizationToken(ctx)
 		}
 
 		log.Fatalf("Cannot obtain ECR authorization token: %s", err.Error())
 	}
-	log.Debugf("Get ECR authorization token result: %s", result.String())
+	log.Debugf("Get ECR authorization token result: %s", describe(result))
 
 	authToken, err := base64.StdEncoding.DecodeString(*result.AuthorizationData[0].AuthorizationToken)
 	if err != nil {
@@ -160,3 +209,12 @@ func GetECRAuthorizationToken() string {
 	}
 	return strings.Split(string(authToken), ":")[1]
 }
+
+// describe renders the output of an AWS call for the debug logs.
+func describe(output interface{}) string {
+	description, err := json.Marshal(output)
+	if err != nil {
+		return fmt.Sprintf("%+v", output)
+	}
+	return string(description)
+}
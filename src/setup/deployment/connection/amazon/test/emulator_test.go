@@ -0,0 +1,68 @@
+package amazon
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"stellar/setup/deployment/connection/amazon"
+	"strings"
+	"testing"
+)
+
+const apiTemplatePath = "../../../raw-code/functions/producer-consumer/api-template.json"
+
+// startEmulator serves two pages of Lambda functions and points the AWS connection to it.
+func startEmulator(t *testing.T) *[]string {
+	var markers []string
+	emulator := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		require.True(t, strings.HasPrefix(request.URL.Path, "/2015-03-31/functions"))
+		marker := request.URL.Query().Get("Marker")
+		markers = append(markers, marker)
+
+		writer.Header().Set("Content-Type", "application/json")
+		if marker == "" {
+			_, _ = fmt.Fprint(writer, `{"Functions": [{"FunctionName": "vHive-bench_a", "MemorySize": 128}], "NextMarker": "page2"}`)
+			return
+		}
+		_, _ = fmt.Fprint(writer, `{"Functions": [{"FunctionName": "vHive-bench_b", "MemorySize": 256}]}`)
+	}))
+	pointToEmulator(t, emulator)
+
+	return &markers
+}
+
+// pointToEmulator initializes the AWS connection with test credentials against the given emulator.
+func pointToEmulator(t *testing.T, emulator *httptest.Server) {
+	t.Cleanup(emulator.Close)
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "test")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test")
+	amazon.EndpointURL = emulator.URL
+	t.Cleanup(func() { amazon.EndpointURL = "" })
+	amazon.InitializeSingleton(context.Background(), apiTemplatePath)
+}
+
+func TestListFunctionsFollowsPages(t *testing.T) {
+	markers := startEmulator(t)
+
+	functions := amazon.AWSSingletonInstance.ListFunctions(context.Background())
+
+	require.Len(t, functions, 2)
+	require.Equal(t, "vHive-bench_a", *functions[0].FunctionName)
+	require.Equal(t, int32(256), *functions[1].MemorySize)
+	require.Equal(t, []string{"", "page2"}, *markers)
+}
+
+func TestSignRequest(t *testing.T) {
+	startEmulator(t)
+
+	request, err := http.NewRequest(http.MethodGet, "https://abc.execute-api.us-west-1.amazonaws.com/route?Bucket=stellar", nil)
+	require.NoError(t, err)
+	require.NoError(t, amazon.AWSSingletonInstance.SignRequest(request))
+
+	require.Contains(t, request.Header.Get("Authorization"), "Credential=test/")
+	require.Contains(t, request.Header.Get("Authorization"), "/us-west-1/execute-api/aws4_request")
+	require.NotEmpty(t, request.Header.Get("X-Amz-Date"))
+}
@@ -0,0 +1,195 @@
+package amazon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"stellar/setup/deployment/connection/amazon"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const (
+	emulatedAPIID       = "abc123"
+	emulatedAPIEndpoint = "https://abc123.execute-api.us-west-1.amazonaws.com"
+)
+
+// httpAPIEmulator serves the API Gateway, Lambda and S3 calls made when deploying an HTTP API and records them.
+type httpAPIEmulator struct {
+	mu              sync.Mutex
+	calls           []string
+	createdCode     map[string]map[string]interface{}
+	integrationURIs []string
+	permittedARNs   []string
+	uploadedBytes   int64
+}
+
+func startHTTPAPIEmulator(t *testing.T) *httpAPIEmulator {
+	emulator := &httpAPIEmulator{createdCode: map[string]map[string]interface{}{}}
+	pointToEmulator(t, httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		body, err := io.ReadAll(request.Body)
+		require.NoError(t, err)
+
+		emulator.mu.Lock()
+		defer emulator.mu.Unlock()
+		emulator.serve(t, writer, request, body)
+	})))
+	return emulator
+}
+
+func (emulator *httpAPIEmulator) serve(t *testing.T, writer http.ResponseWriter, request *http.Request, body []byte) {
+	path := request.URL.Path
+	query := request.URL.Query()
+	call := fmt.Sprintf("%s %s", request.Method, path)
+
+	var input map[string]interface{}
+	if len(body) > 0 && strings.Contains(request.Header.Get("Content-Type"), "json") {
+		require.NoError(t, json.Unmarshal(body, &input))
+	}
+
+	switch {
+	case strings.HasPrefix(path, "/v2/apis"):
+		writer.Header().Set("Content-Type", "application/json")
+		switch {
+		case call == "POST /v2/apis":
+			_, _ = fmt.Fprintf(writer, `{"apiId": %q, "apiEndpoint": %q}`, emulatedAPIID, emulatedAPIEndpoint)
+		case strings.HasSuffix(path, "/stages"):
+			_, _ = fmt.Fprint(writer, `{"stageName": "$default"}`)
+		case strings.HasSuffix(path, "/integrations"):
+			emulator.integrationURIs = append(emulator.integrationURIs, input["integrationUri"].(string))
+			_, _ = fmt.Fprintf(writer, `{"integrationId": "integration%d"}`, len(emulator.integrationURIs))
+		case strings.HasSuffix(path, "/routes"):
+			call = fmt.Sprintf("%s %s", call, input["routeKey"])
+			_, _ = fmt.Fprint(writer, `{"routeId": "route"}`)
+		case request.Method == http.MethodDelete:
+			writer.WriteHeader(http.StatusNoContent)
+		}
+	case strings.HasPrefix(path, "/2015-03-31/functions"):
+		writer.Header().Set("Content-Type", "application/json")
+		functionName := strings.Split(strings.TrimPrefix(path, "/2015-03-31/functions/"), "/")[0]
+		switch {
+		case call == "POST /2015-03-31/functions":
+			functionName = input["FunctionName"].(string)
+			emulator.createdCode[functionName] = input["Code"].(map[string]interface{})
+			if _, snapStart := input["SnapStart"]; snapStart {
+				call += " (SnapStart)"
+			}
+			_, _ = fmt.Fprintf(writer, `{"FunctionName": %q, "FunctionArn": %q}`, functionName, emulatedFunctionARN(functionName))
+		case strings.HasSuffix(path, "/versions"):
+			_, _ = fmt.Fprintf(writer, `{"FunctionArn": "%s:1", "Version": "1"}`, emulatedFunctionARN(functionName))
+		case strings.HasSuffix(path, "/configuration"):
+			call = fmt.Sprintf("%s?Qualifier=%s", call, query.Get("Qualifier"))
+			_, _ = fmt.Fprint(writer, `{"State": "Active"}`)
+		case strings.HasSuffix(path, "/policy"):
+			emulator.permittedARNs = append(emulator.permittedARNs, functionName)
+			_, _ = fmt.Fprint(writer, `{"Statement": "{}"}`)
+		case request.Method == http.MethodGet:
+			_, _ = fmt.Fprint(writer, `{"Configuration": {"State": "Active"}}`)
+		case request.Method == http.MethodDelete:
+			writer.WriteHeader(http.StatusNoContent)
+		}
+	default:
+		// S3 is addressed in path style, i.e., /<bucket>/<key>
+		writer.Header().Set("Content-Type", "application/xml")
+		switch {
+		case query.Has("uploads"):
+			call += "?uploads"
+			_, _ = fmt.Fprint(writer, `<InitiateMultipartUploadResult><UploadId>upload</UploadId></InitiateMultipartUploadResult>`)
+		case query.Has("partNumber"):
+			// parts are uploaded concurrently, so only their total size is recorded
+			emulator.uploadedBytes += int64(len(body))
+			writer.Header().Set("ETag", fmt.Sprintf(`"part%s"`, query.Get("partNumber")))
+			return
+		case query.Has("uploadId"):
+			call += "?uploadId"
+			_, _ = fmt.Fprint(writer, `<CompleteMultipartUploadResult><ETag>"object"</ETag></CompleteMultipartUploadResult>`)
+		case request.Method == http.MethodPut:
+			emulator.uploadedBytes += int64(len(body))
+		case request.Method == http.MethodDelete:
+			writer.WriteHeader(http.StatusNoContent)
+		}
+	}
+	emulator.calls = append(emulator.calls, call)
+}
+
+func emulatedFunctionARN(functionName string) string {
+	return fmt.Sprintf("arn:aws:lambda:us-west-1:000000000000:function:%s", functionName)
+}
+
+// writeZip creates a file of the given size standing in for a zipped function.
+func writeZip(t *testing.T, name string, sizeBytes int64) string {
+	path := filepath.Join(t.TempDir(), name+".zip")
+	require.NoError(t, os.WriteFile(path, []byte("PK"), 0644))
+	require.NoError(t, os.Truncate(path, sizeBytes))
+	return path
+}
+
+func TestDeployAndRemoveHTTPAPI(t *testing.T) {
+	emulator := startHTTPAPIEmulator(t)
+	amazon.UserARNNumber = "000000000000"
+	t.Cleanup(func() { amazon.UserARNNumber = "" })
+
+	const largeZipBytes = 50*1024*1024 + 1
+	functions := []amazon.HTTPAPIFunction{
+		{Name: "small", Route: "small", Handler: "main", Runtime: "go1.x", MemoryMB: 128, ZipPath: writeZip(t, "small", 1024)},
+		{Name: "large", Route: "large", Handler: "main", Runtime: "java11", MemoryMB: 2048, ZipPath: writeZip(t, "large", largeZipBytes), SnapStart: true},
+	}
+
+	deployment := amazon.AWSSingletonInstance.DeployHTTPAPI(context.Background(), "stellar", functions)
+
+	require.Equal(t, amazon.HTTPAPIDeployment{
+		APIID:         emulatedAPIID,
+		APIEndpoint:   emulatedAPIEndpoint,
+		FunctionNames: []string{"small", "large"},
+		S3Keys:        []string{"large.zip"},
+	}, deployment)
+	require.Equal(t, emulatedAPIID, deployment.EndpointID())
+
+	// the small zip is passed inline while the large one is uploaded to S3 and referenced by the function
+	require.Contains(t, emulator.createdCode["small"], "ZipFile")
+	require.NotContains(t, emulator.createdCode["small"], "S3Key")
+	require.Equal(t, map[string]interface{}{"S3Bucket": amazon.AWSBucketName, "S3Key": "large.zip"}, emulator.createdCode["large"])
+	require.Equal(t, int64(largeZipBytes), emulator.uploadedBytes)
+
+	// the SnapStart function is integrated through its published version rather than $LATEST
+	smallARN := emulatedFunctionARN("small")
+	largeVersionARN := emulatedFunctionARN("large") + ":1"
+	require.Equal(t, []string{smallARN, largeVersionARN}, emulator.integrationURIs)
+	require.Equal(t, []string{smallARN, largeVersionARN}, emulator.permittedARNs)
+
+	require.Equal(t, []string{
+		"POST /v2/apis",
+		"POST /v2/apis/abc123/stages",
+		"POST /2015-03-31/functions",
+		"GET /2015-03-31/functions/small",
+		"POST /v2/apis/abc123/integrations",
+		"POST /v2/apis/abc123/routes GET /small",
+		"POST /2015-03-31/functions/" + smallARN + "/policy",
+		"POST /stellar/large.zip?uploads",
+		"POST /stellar/large.zip?uploadId",
+		"POST /2015-03-31/functions (SnapStart)",
+		"GET /2015-03-31/functions/large",
+		"POST /2015-03-31/functions/large/versions",
+		"GET /2015-03-31/functions/large/configuration?Qualifier=1",
+		"POST /v2/apis/abc123/integrations",
+		"POST /v2/apis/abc123/routes GET /large",
+		"POST /2015-03-31/functions/" + largeVersionARN + "/policy",
+	}, emulator.calls)
+
+	emulator.calls = nil
+	amazon.AWSSingletonInstance.RemoveHTTPAPI(context.Background(), deployment)
+
+	require.Equal(t, []string{
+		"DELETE /v2/apis/abc123",
+		"DELETE /2015-03-31/functions/small",
+		"DELETE /2015-03-31/functions/large",
+		"DELETE /stellar/large.zip",
+	}, emulator.calls)
+}
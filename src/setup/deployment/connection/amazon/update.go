@@ -23,14 +23,15 @@
 package amazon
 
 import (
+	"context"
 	"fmt"
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
 	log "github.com/sirupsen/logrus"
 	"strings"
 )
 
-func (instance awsSingleton) UpdateFunction(packageType string, uniqueID string) *lambda.FunctionConfiguration {
+func (instance awsSingleton) UpdateFunction(ctx context.Context, packageType string, uniqueID string) *lambda.UpdateFunctionCodeOutput {
 	functionName := fmt.Sprintf("%s%s", namingPrefix, uniqueID)
 	log.Infof("Updating producer lambda code %s", functionName)
 
@@ -46,7 +47,7 @@ func (instance awsSingleton) UpdateFunction(packageType string, uniqueID string)
 		} else {
 			args = &lambda.UpdateFunctionCodeInput{
 				FunctionName: aws.String(functionName),
-				ZipFile:      aws.Uint8ValueSlice(aws.Uint8Slice(instance.localZipFileContents)),
+				ZipFile:      instance.localZipFileContents,
 			}
 		}
 	case "Image":
@@ -58,44 +59,44 @@ func (instance awsSingleton) UpdateFunction(packageType string, uniqueID string)
 		log.Fatalf("Package type %s not supported for function update.", packageType)
 	}
 
-	result, err := instance.lambdaSvc.UpdateFunctionCode(args)
+	result, err := instance.lambdaSvc.UpdateFunctionCode(ctx, args)
 	if err != nil {
 		if strings.Contains(err.Error(), "TooManyRequestsException") {
 			log.Warnf("Facing AWS rate-limiting error, retrying...")
-			return instance.UpdateFunction(packageType, uniqueID)
+			return instance.UpdateFunction(ctx, packageType, uniqueID)
 		}
 
 		log.Fatalf("Cannot update function code: %s", err.Error())
 	}
-	log.Debugf("Update function code result: %s", result.String())
+	log.Debugf("Update function code result: %s", describe(result))
 
 	return result
 }
 
 //UpdateFunctionConfiguration  will update the configuration (e.g. timeout) of the serverless function with id `i`.
-func (instance awsSingleton) UpdateFunctionConfiguration(uniqueID string, assignedMemory int64) {
+func (instance awsSingleton) UpdateFunctionConfiguration(ctx context.Context, uniqueID string, assignedMemory int64) {
 	functionName := fmt.Sprintf("%s%s", namingPrefix, uniqueID)
 	log.Infof("Updating producer lambda configuration %s", functionName)
 
 	args := &lambda.UpdateFunctionConfigurationInput{
 		FunctionName: aws.String(functionName),
-		MemorySize:   aws.Int64(assignedMemory),
-		Timeout:      aws.Int64(600),
+		MemorySize:   aws.Int32(int32(assignedMemory)),
+		Timeout:      aws.Int32(600),
 	}
 
-	result, err := instance.lambdaSvc.UpdateFunctionConfiguration(args)
+	result, err := instance.lambdaSvc.UpdateFunctionConfiguration(ctx, args)
 	if err != nil {
 		if strings.Contains(err.Error(), "TooManyRequestsException") {
 			log.Warnf("Facing AWS rate-limiting error, retrying...")
-			instance.UpdateFunctionConfiguration(uniqueID, assignedMemory)
+			instance.UpdateFunctionConfiguration(ctx, uniqueID, assignedMemory)
 		}
 
 		if strings.Contains(err.Error(), "ResourceConflictException") {
 			log.Warnf("Facing AWS resource conflict error, retrying...")
-			instance.UpdateFunctionConfiguration(uniqueID, assignedMemory)
+			instance.UpdateFunctionConfiguration(ctx, uniqueID, assignedMemory)
 		}
 
 		log.Fatalf("Cannot update function configuration: %s", err.Error())
 	}
-	log.Debugf("Update function configuration result: %s", result.String())
+	log.Debugf("Update function configuration result: %s", describe(result))
 }
@@ -30,6 +30,7 @@ import (
 	"io"
 	"path"
 	"stellar/setup/deployment/connection/amazon"
+	"stellar/tracing"
 	"stellar/util"
 	"strings"
 	"time"
@@ -81,29 +82,29 @@ func Initialize(provider string, endpointsDirectoryPath string, apiTemplatePath
 }
 
 func setupAWSConnection(apiTemplatePath string) {
-	amazon.InitializeSingleton(apiTemplatePath)
+	amazon.InitializeSingleton(tracing.RunContext(), apiTemplatePath)
 
 	Singleton = &ServerlessInterface{
 		ListAPIs: func() []Endpoint {
 			mustRepeatListRequest := true
 			for mustRepeatListRequest {
 				mustRepeatListRequest = false
-				result := amazon.AWSSingletonInstance.ListFunctions(nil)
+				result := amazon.AWSSingletonInstance.ListFunctions(tracing.RunContext())
 				log.Infof("Found %d Lambda functions.", len(result))
 
 				functions := make([]Endpoint, 0)
 				for _, function := range result {
 					if strings.Contains(*function.FunctionName, "vHive-bench") {
-						if function.LastUpdateStatus != nil {
+						if function.LastUpdateStatus != "" {
 							mustRepeatListRequest = true
 							break
 						}
 
 						functions = append(functions, Endpoint{
 							GatewayID:        strings.Split(*function.FunctionName, "_")[1],
-							FunctionMemoryMB: *function.MemorySize,
-							PackageType:      *function.PackageType,
-							ImageSizeMB:      util.BytesToMebibyte(*function.CodeSize),
+							FunctionMemoryMB: int64(*function.MemorySize),
+							PackageType:      string(function.PackageType),
+							ImageSizeMB:      util.BytesToMebibyte(function.CodeSize),
 						})
 					}
 				}
@@ -129,18 +130,18 @@ func setupAWSConnection(apiTemplatePath string) {
 				log.Fatalf("DeployFunction could not recognize function image %s", function)
 			}
 
-			return amazon.AWSSingletonInstance.DeployFunction(binaryPath, packageType, language, memoryAssigned)
+			return amazon.AWSSingletonInstance.DeployFunction(tracing.RunContext(), binaryPath, packageType, language, memoryAssigned)
 		},
 		RemoveFunction: func(uniqueID string) {
-			amazon.AWSSingletonInstance.RemoveFunction(uniqueID)
-			amazon.AWSSingletonInstance.RemoveAPIGateway(uniqueID)
+			amazon.AWSSingletonInstance.RemoveFunction(tracing.RunContext(), uniqueID)
+			amazon.AWSSingletonInstance.RemoveAPIGateway(tracing.RunContext(), uniqueID)
 		},
 		UpdateFunction: func(packageType string, uniqueID string, memoryAssigned int64) {
-			amazon.AWSSingletonInstance.UpdateFunction(packageType, uniqueID)
+			amazon.AWSSingletonInstance.UpdateFunction(tracing.RunContext(), packageType, uniqueID)
 
 			time.Sleep(time.Second * 5) // https://aws.amazon.com/de/blogs/compute/coming-soon-expansion-of-aws-lambda-states-to-all-functions/
 
-			amazon.AWSSingletonInstance.UpdateFunctionConfiguration(uniqueID, memoryAssigned)
+			amazon.AWSSingletonInstance.UpdateFunctionConfiguration(tracing.RunContext(), uniqueID, memoryAssigned)
 		},
 	}
 }
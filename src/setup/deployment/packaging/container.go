@@ -23,61 +23,447 @@
 package packaging
 
 import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/partial"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/google/go-containerregistry/pkg/v1/types"
 	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"io"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"stellar/setup/deployment/connection/amazon"
+	"stellar/tracing"
 	"stellar/util"
 )
 
-var builtImages = make(map[string]bool)
-var privateRepoURI string = ""
-var loggedIn bool = false
+// ImageRegistry, if set, is the registry the container images are pushed to instead of that of the provider,
+// e.g., a local registry
+var ImageRegistry string
+
+var builtImages = make(map[string]builtImage)
+var baseImages = make(map[string]v1.Image)
+
+type builtImage struct {
+	reference string
+	size      ImageSize
+}
+
+// ImageSize is the achieved size of a pushed container image. The compressed size is the sum of the layer sizes in
+// the image manifest, i.e., what the provider pulls on a cold start, and the uncompressed size that of the layer
+// contents.
+type ImageSize struct {
+	CompressedBytes   int64
+	UncompressedBytes int64
+}
+
+// imageManifest holds the parts of an image manifest or of an image index needed to find the size of the image
+type imageManifest struct {
+	Layers []struct {
+		Size int64 `json:"size"`
+	} `json:"layers"`
+	Manifests []struct {
+		Digest string `json:"digest"`
+	} `json:"manifests"`
+}
+
+// SetupContainerImageDeployment will package the function in functionDir using container images and push to registry.
+// A filler layer is added on top of the function image so that its compressed size reaches compressedImageSizeMebibyte,
+// unless the target is 0. The commands building the image, if any, are run through the runner. Returns the
+// content-addressed reference of the pushed image and its achieved size.
+func SetupContainerImageDeployment(experimentID int, function string, provider string, functionDir string, compressedImageSizeMebibyte float64, runner util.CommandRunner) (string, ImageSize) {
+	defer tracing.StartStep("package", attribute.Int("stellar.sub_experiment", experimentID), attribute.String("stellar.provider", provider),
+		attribute.String("stellar.function", function), attribute.Float64("stellar.image_size_mb", compressedImageSizeMebibyte),
+		attribute.String("stellar.package_type", "Container")).End()
+
+	repository, auth := imageRepository(provider, fmt.Sprintf("%s_%v_stellar", function, compressedImageSizeMebibyte))
+	if built, found := builtImages[repository]; found {
+		log.Infof("[sub-experiment %d] Container image %q is already built. Skipping...", experimentID, built.reference)
+		return built.reference, built.size
+	}
+
+	image, removeExportedImage := functionImage(experimentID, functionDir, runner)
+	defer removeExportedImage()
+	baseCompressedBytes := imageCompressedBytes(image)
+	log.Infof("[sub-experiment %d] Function image of %q is %d bytes compressed.", experimentID, function, baseCompressedBytes)
+
+	targetBytes := util.MebibyteToBytes(compressedImageSizeMebibyte)
+	switch {
+	case targetBytes == 0:
+		log.Infof("[sub-experiment %d] Desired image size is set to default (0MB), assigning size of function image (%vMB)...",
+			experimentID, util.BytesToMebibyte(baseCompressedBytes))
+	case targetBytes < baseCompressedBytes:
+		log.Fatalf("[sub-experiment %d] Total size (~%vMB) cannot be smaller than compressed function image size (~%vMB).",
+			experimentID, util.BytesToMebibyte(targetBytes), util.BytesToMebibyte(baseCompressedBytes))
+	default:
+		fillerLayer := sizedFillerLayer(experimentID, image, repository, targetBytes-baseCompressedBytes)
+		var err error
+		if image, err = mutate.AppendLayers(image, fillerLayer); err != nil {
+			log.Fatalf("[sub-experiment %d] Could not add filler layer to the image of %q: %s", experimentID, function, err.Error())
+		}
+	}
+
+	reference := pushImage(experimentID, image, repository, auth)
+	size := ImageSize{CompressedBytes: imageCompressedBytes(image), UncompressedBytes: imageUncompressedBytes(image)}
+	log.Infof("[sub-experiment %d] Container image %q is %d bytes compressed (target %d bytes) and %d bytes uncompressed.",
+		experimentID, reference, size.CompressedBytes, targetBytes, size.UncompressedBytes)
+
+	if provider == "aws" {
+		amazon.AWSSingletonInstance.ImageURI = reference
+	}
+	builtImages[repository] = builtImage{reference: reference, size: size}
+	return reference, size
+}
+
+// imageRepository returns the repository of the image in the registry of the provider, with the credentials to push
+// to it. Docker Hub credentials are taken from the environment, or else from the Docker config and its credential
+// helpers.
+func imageRepository(provider string, imageName string) (string, remote.Option) {
+	if ImageRegistry != "" {
+		return fmt.Sprintf("%s/%s", ImageRegistry, imageName), remote.WithAuthFromKeychain(authn.DefaultKeychain)
+	}
 
-// SetupContainerImageDeployment will package the function using container images and push to registry
-func SetupContainerImageDeployment(function string, provider string, compressedImageSizeMebibyte float64) string {
-	functionDir := fmt.Sprintf("setup/deployment/raw-code/serverless/%s/%s", provider, function)
 	switch provider {
 	case "aws":
-		privateRepoURI = fmt.Sprintf("%s.dkr.ecr.%s.amazonaws.com", amazon.UserARNNumber, amazon.AWSRegion)
-
-		log.Info("Authenticating Docker CLI to the Amazon ECR registry...")
-		util.RunCommandAndLog(exec.Command("docker", "login", "-u", "AWS", "-p",
-			amazon.GetECRAuthorizationToken(), privateRepoURI))
+		log.Info("Authenticating to the Amazon ECR registry...")
+		auth := authn.FromConfig(authn.AuthConfig{Username: "AWS", Password: amazon.GetECRAuthorizationToken(tracing.RunContext())})
+		return fmt.Sprintf("%s.dkr.ecr.%s.amazonaws.com/%s", amazon.UserARNNumber, amazon.AWSRegion, imageName), remote.WithAuth(auth)
 	case "gcr":
 		fallthrough
 	case "vhive":
-		log.Info("Authenticating Docker CLI to the DockerHub registry...")
-
-		if !loggedIn {
-			privateRepoURI = os.Getenv("DOCKER_HUB_USERNAME")
-			privateRepoToken := os.Getenv("DOCKER_HUB_ACCESS_TOKEN")
-			util.RunCommandAndLog(exec.Command("docker", "login", "-u",
-				privateRepoURI, "-p", privateRepoToken))
-			loggedIn = true
+		username := os.Getenv("DOCKER_HUB_USERNAME")
+		if token := os.Getenv("DOCKER_HUB_ACCESS_TOKEN"); token != "" {
+			return fmt.Sprintf("%s/%s", username, imageName), remote.WithAuth(&authn.Basic{Username: username, Password: token})
 		}
+		return fmt.Sprintf("%s/%s", username, imageName), remote.WithAuthFromKeychain(authn.DefaultKeychain)
 	default:
 		log.Fatalf("Provider %s does not support container image deployment.", provider)
 	}
+	return "", nil
+}
 
-	taggedImage := fmt.Sprintf("%s_%v_stellar:latest", function, compressedImageSizeMebibyte)
-	imageName := fmt.Sprintf("%s/%s", privateRepoURI, taggedImage)
-	if builtImages[taggedImage] {
-		log.Infof("Container image for function %q is already built. Skipping...", taggedImage)
-		return imageName
+// functionImage builds the image of the function in functionDir. Dockerfiles which only copy files on top of a base
+// image are built natively by pulling the base image and adding the files as a layer, other Dockerfiles are built
+// with the Docker CLI. The returned function removes the image exported by Docker once it is no longer needed.
+func functionImage(experimentID int, functionDir string, runner util.CommandRunner) (v1.Image, func()) {
+	if image, built := baseImages[functionDir]; built {
+		return image, func() {}
 	}
 
-	util.RunCommandAndLog(exec.Command("docker", "build", "-t", taggedImage, functionDir))
+	stage, err := parseDockerfile(filepath.Join(functionDir, "Dockerfile"))
+	if err != nil {
+		log.Warnf("[sub-experiment %d] Building the image of %s with the Docker CLI: %s", experimentID, functionDir, err.Error())
+		return dockerBuiltImage(functionDir, runner)
+	}
 
-	log.Infof("Pushing container image to %q...", imageName)
+	log.Infof("[sub-experiment %d] Building the image of %s on top of %s...", experimentID, functionDir, stage.BaseImage)
+	image := nativeBuiltImage(stage, functionDir)
 
-	util.RunCommandAndLog(exec.Command("docker", "tag", taggedImage, imageName))
-	util.RunCommandAndLog(exec.Command("docker", "push", imageName))
+	baseImages[functionDir] = image
+	return image, func() {}
+}
 
-	if provider == "aws" {
-		amazon.AWSSingletonInstance.ImageURI = imageName
+func nativeBuiltImage(stage dockerfileStage, functionDir string) v1.Image {
+	baseReference, err := name.ParseReference(stage.BaseImage)
+	if err != nil {
+		log.Fatalf("Could not parse base image %q: %s", stage.BaseImage, err.Error())
+	}
+	image, err := remote.Image(baseReference, remote.WithAuthFromKeychain(authn.DefaultKeychain), remote.WithContext(tracing.RunContext()),
+		remote.WithPlatform(v1.Platform{OS: "linux", Architecture: "amd64"}))
+	if err != nil {
+		log.Fatalf("Could not pull base image %q: %s", stage.BaseImage, err.Error())
+	}
+
+	functionLayer, err := tarball.LayerFromOpener(func() (io.ReadCloser, error) {
+		reader, writer := io.Pipe()
+		go func() { writer.CloseWithError(stage.writeLayer(functionDir, writer)) }()
+		return reader, nil
+	})
+	if err != nil {
+		log.Fatalf("Could not create the layer of %s: %s", functionDir, err.Error())
+	}
+	if image, err = mutate.AppendLayers(image, functionLayer); err != nil {
+		log.Fatalf("Could not add the layer of %s: %s", functionDir, err.Error())
+	}
+
+	configFile, err := image.ConfigFile()
+	if err != nil {
+		log.Fatalf("Could not read the config of base image %q: %s", stage.BaseImage, err.Error())
+	}
+	configFile = configFile.DeepCopy()
+	stage.applyConfig(&configFile.Config)
+	if image, err = mutate.ConfigFile(image, configFile); err != nil {
+		log.Fatalf("Could not set the config of the image of %s: %s", functionDir, err.Error())
+	}
+	return image
+}
+
+// dockerBuiltImage builds the image with the Docker CLI and loads it from an exported tarball, so that it can be
+// extended and pushed like a natively built image. As the tarball is read lazily, it is only removed by the returned
+// function, and the image is not reused across sub-experiments, Docker caching its build instead.
+func dockerBuiltImage(functionDir string, runner util.CommandRunner) (v1.Image, func()) {
+	tag := fmt.Sprintf("%s_stellar:build", filepath.Base(functionDir))
+	util.RunCommandAndLog(exec.Command("docker", "build", "-t", tag, functionDir), runner)
+
+	exportDirectory, err := os.MkdirTemp("", "stellar-image-*")
+	if err != nil {
+		log.Fatalf("Could not create directory to export image %s to: %s", tag, err.Error())
+	}
+	removeExportedImage := func() {
+		if err := os.RemoveAll(exportDirectory); err != nil {
+			log.Errorf("Could not remove image %s exported to %s: %s", tag, exportDirectory, err.Error())
+		}
+	}
+	exportPath := filepath.Join(exportDirectory, "image.tar")
+	util.RunCommandAndLog(exec.Command("docker", "save", "-o", exportPath, tag), runner)
+
+	image, err := tarball.ImageFromPath(exportPath, nil)
+	if err != nil {
+		removeExportedImage()
+		log.Fatalf("Could not load image %s exported by Docker: %s", tag, err.Error())
+	}
+	return image, removeExportedImage
+}
+
+// sizedFillerLayer creates a layer holding a random filler file, placed in the working directory of the function, whose
+// compressed size is exactly layerSizeBytes. The file is sized in whole tar blocks from the computed layer size, and the
+// remaining bytes are stored in the extra field of the gzip header. The filler is named after the repository, so that the
+// layer of the same image is identical across runs with the same FillerSeed and is only pushed once.
+func sizedFillerLayer(experimentID int, image v1.Image, repository string, layerSizeBytes int64) v1.Layer {
+	const maxGzipExtraBytes = 65535
+
+	configFile, err := image.ConfigFile()
+	if err != nil {
+		log.Fatalf("[sub-experiment %d] Could not read the image config: %s", experimentID, err.Error())
+	}
+	fillerPath := filepath.ToSlash(filepath.Join(configFile.Config.WorkingDir, "filler.file"))
+
+	fillerFileSizeBytes := FillerFileSizeForLayer(layerSizeBytes)
+	for attempt := 0; attempt < 3 && fillerFileSizeBytes >= 0; attempt++ {
+		paddingBytes := layerSizeBytes - fillerLayerBytes(fillerPath, fillerFileSizeBytes, -1)
+		if paddingBytes == 0 || (paddingBytes >= 2 && paddingBytes-2 <= maxGzipExtraBytes) {
+			// the extra field takes 2 bytes for its length
+			layer := newFillerLayer(experimentID, fillerPath, fillerFileSizeBytes, repository, paddingBytes-2)
+			if layer.size != layerSizeBytes {
+				log.Fatalf("[sub-experiment %d] The filler layer is %d bytes instead of %d bytes.", experimentID, layer.size, layerSizeBytes)
+			}
+			return layer
+		}
+		// leave between 512 and 1023 bytes to pad
+		fillerFileSizeBytes += (paddingBytes/512 - 1) * 512
+	}
+
+	log.Fatalf("[sub-experiment %d] Could not size the filler layer to %d bytes.", experimentID, layerSizeBytes)
+	return nil
+}
+
+// fillerLayerBytes returns the size of the filler layer without writing it. Between the gzip header and trailer, the tar
+// archive is stored in deflate blocks of at most 64KiB with a 5-byte header each, followed by a 2-byte final block. The
+// extra field of the gzip header, if any, takes 2 bytes for its length on top of gzipExtraBytes.
+func fillerLayerBytes(fillerPath string, fillerFileSizeBytes int64, gzipExtraBytes int64) int64 {
+	const gzipOverheadBytes = 10 + 8
+	const deflateBlockBytes = 65535
+	const deflateBlockHeaderBytes = 5
+	const deflateFinalBlockBytes = 2
+
+	header := &countingWriter{}
+	if err := tar.NewWriter(header).WriteHeader(fillerHeader(fillerPath, fillerFileSizeBytes)); err != nil {
+		log.Fatalf("Could not write the header of filler file %s: %s", fillerPath, err.Error())
+	}
+	archiveBytes := header.written + (fillerFileSizeBytes+511)/512*512 + 2*512
+
+	deflateBlocks := (archiveBytes + deflateBlockBytes - 1) / deflateBlockBytes
+	layerBytes := gzipOverheadBytes + archiveBytes + deflateBlocks*deflateBlockHeaderBytes + deflateFinalBlockBytes
+	if gzipExtraBytes >= 0 {
+		layerBytes += 2 + gzipExtraBytes
+	}
+	return layerBytes
+}
+
+func fillerHeader(fillerPath string, fillerFileSizeBytes int64) *tar.Header {
+	return &tar.Header{Name: fillerPath, Mode: 0644, Size: fillerFileSizeBytes, ModTime: archiveModificationTime}
+}
+
+// fillerLayer is a gzip-compressed layer holding a filler file, stored without compression as it would not compress
+// anyway. Its digests and sizes are computed in a single pass when it is created, and the filler is streamed again
+// each time the layer is read, so that it is never kept in memory or on disk. The gzip header holds gzipExtraBytes zero
+// bytes, or no extra field if negative.
+type fillerLayer struct {
+	fillerPath          string
+	fillerName          string
+	fillerFileSizeBytes int64
+	gzipExtraBytes      int64
+	digest              v1.Hash
+	diffID              v1.Hash
+	size                int64
+	uncompressedSize    int64
+}
+
+func newFillerLayer(experimentID int, fillerPath string, fillerFileSizeBytes int64, fillerName string, gzipExtraBytes int64) *fillerLayer {
+	layer := &fillerLayer{fillerPath: fillerPath, fillerName: fillerName, fillerFileSizeBytes: fillerFileSizeBytes, gzipExtraBytes: gzipExtraBytes}
+
+	compressedHash, compressed := sha256.New(), &countingWriter{}
+	archiveHash, archive := sha256.New(), &countingWriter{}
+	if err := layer.writeCompressed(io.MultiWriter(compressedHash, compressed), io.MultiWriter(archiveHash, archive)); err != nil {
+		log.Fatalf("[sub-experiment %d] Could not create the filler layer: %s", experimentID, err.Error())
+	}
+
+	layer.digest = v1.Hash{Algorithm: "sha256", Hex: hex.EncodeToString(compressedHash.Sum(nil))}
+	layer.diffID = v1.Hash{Algorithm: "sha256", Hex: hex.EncodeToString(archiveHash.Sum(nil))}
+	layer.size, layer.uncompressedSize = compressed.written, archive.written
+	return layer
+}
+
+// writeArchive writes the tar archive holding the filler file
+func (l *fillerLayer) writeArchive(writer io.Writer) error {
+	layerArchive := tar.NewWriter(writer)
+	if err := layerArchive.WriteHeader(fillerHeader(l.fillerPath, l.fillerFileSizeBytes)); err != nil {
+		return err
+	}
+	if _, err := io.CopyN(layerArchive, NewFillerReader(FillerSeed, l.fillerName), l.fillerFileSizeBytes); err != nil {
+		return err
+	}
+	return layerArchive.Close()
+}
+
+// writeCompressed writes the compressed layer, and the archive it compresses to archiveWriter
+func (l *fillerLayer) writeCompressed(writer io.Writer, archiveWriter io.Writer) error {
+	compressedArchive, err := gzip.NewWriterLevel(writer, gzip.NoCompression)
+	if err != nil {
+		return err
+	}
+	if l.gzipExtraBytes >= 0 {
+		compressedArchive.Extra = make([]byte, l.gzipExtraBytes)
+	}
+	if err := l.writeArchive(io.MultiWriter(compressedArchive, archiveWriter)); err != nil {
+		return err
+	}
+	return compressedArchive.Close()
+}
+
+func (l *fillerLayer) Digest() (v1.Hash, error) {
+	return l.digest, nil
+}
+
+func (l *fillerLayer) DiffID() (v1.Hash, error) {
+	return l.diffID, nil
+}
+
+func (l *fillerLayer) Compressed() (io.ReadCloser, error) {
+	reader, writer := io.Pipe()
+	go func() { writer.CloseWithError(l.writeCompressed(writer, io.Discard)) }()
+	return reader, nil
+}
+
+func (l *fillerLayer) Uncompressed() (io.ReadCloser, error) {
+	reader, writer := io.Pipe()
+	go func() { writer.CloseWithError(l.writeArchive(writer)) }()
+	return reader, nil
+}
+
+func (l *fillerLayer) Size() (int64, error) {
+	return l.size, nil
+}
+
+// UncompressedSize spares partial.UncompressedSize from streaming the filler again
+func (l *fillerLayer) UncompressedSize() (int64, error) {
+	return l.uncompressedSize, nil
+}
+
+func (l *fillerLayer) MediaType() (types.MediaType, error) {
+	return types.DockerLayer, nil
+}
+
+// pushImage pushes the image to the repository under the latest tag and returns its content-addressed reference.
+// Blobs already present in the registry are not uploaded again.
+func pushImage(experimentID int, image v1.Image, repository string, auth remote.Option) string {
+	tag, err := name.NewTag(repository + ":latest")
+	if err != nil {
+		log.Fatalf("[sub-experiment %d] Could not parse image repository %q: %s", experimentID, repository, err.Error())
+	}
+	digest, err := image.Digest()
+	if err != nil {
+		log.Fatalf("[sub-experiment %d] Could not compute the digest of image %s: %s", experimentID, tag, err.Error())
+	}
+
+	log.Infof("[sub-experiment %d] Pushing container image to %q...", experimentID, tag)
+	if err := remote.Write(tag, image, auth, remote.WithContext(tracing.RunContext())); err != nil {
+		log.Fatalf("[sub-experiment %d] Could not push image %s: %s", experimentID, tag, err.Error())
+	}
+	return fmt.Sprintf("%s@%s", repository, digest)
+}
+
+// FillerFileSizeForLayer returns the size of the random filler file whose gzip-compressed layer is layerSizeBytes
+// large. Random bytes do not compress, so the layer only adds the tar header and end-of-archive blocks, the gzip
+// header and trailer, and the 5-byte header of each stored deflate block of at most 64KiB.
+func FillerFileSizeForLayer(layerSizeBytes int64) int64 {
+	const tarOverheadBytes = 512 + 2*512
+	const gzipOverheadBytes = 10 + 8
+	const deflateBlockBytes = 65535
+	const deflateBlockHeaderBytes = 5
+
+	// sizing the file in whole tar blocks of 512 bytes avoids padding
+	archiveBytes := (layerSizeBytes - gzipOverheadBytes) * deflateBlockBytes / (deflateBlockBytes + deflateBlockHeaderBytes)
+	fillerBytes := (archiveBytes - tarOverheadBytes) / 512 * 512
+	if fillerBytes < 0 {
+		return 0
+	}
+	return fillerBytes
+}
+
+// ManifestCompressedBytes returns the sum of the compressed layer sizes listed in an image manifest
+func ManifestCompressedBytes(manifest []byte) (int64, error) {
+	var parsed imageManifest
+	if err := json.Unmarshal(manifest, &parsed); err != nil {
+		return 0, err
+	}
+	if len(parsed.Manifests) > 0 {
+		return 0, fmt.Errorf("expected an image manifest but got an image index of %d manifests", len(parsed.Manifests))
+	}
+
+	var compressedBytes int64
+	for _, layer := range parsed.Layers {
+		compressedBytes += layer.Size
+	}
+	return compressedBytes, nil
+}
+
+func imageCompressedBytes(image v1.Image) int64 {
+	manifest, err := image.RawManifest()
+	if err != nil {
+		log.Fatalf("Could not get the manifest of the image: %s", err.Error())
+	}
+	compressedBytes, err := ManifestCompressedBytes(manifest)
+	if err != nil {
+		log.Fatalf("Could not get the compressed size of the image: %s", err.Error())
+	}
+	return compressedBytes
+}
+
+func imageUncompressedBytes(image v1.Image) int64 {
+	layers, err := image.Layers()
+	if err != nil {
+		log.Fatalf("Could not get the layers of the image: %s", err.Error())
+	}
+
+	var uncompressedBytes int64
+	for _, layer := range layers {
+		layerBytes, err := partial.UncompressedSize(layer)
+		if err != nil {
+			log.Fatalf("Could not get the uncompressed size of a layer of the image: %s", err.Error())
+		}
+		uncompressedBytes += layerBytes
 	}
-	builtImages[taggedImage] = true
-	return imageName
+	return uncompressedBytes
 }
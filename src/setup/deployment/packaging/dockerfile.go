@@ -0,0 +1,250 @@
+// MIT License
+//
+// Copyright (c) 2021 Theodor Amariucai and EASE Lab
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package packaging
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// dockerfileStage is the final stage of a Dockerfile which only copies files from the build context on top of a base
+// image, and can therefore be built without a Docker daemon
+type dockerfileStage struct {
+	BaseImage  string
+	WorkingDir string
+	Copies     []dockerfileCopy
+	Env        []string
+	Entrypoint []string
+	Cmd        []string
+	User       string
+}
+
+// dockerfileCopy copies the Sources of the build context to Destination in the image
+type dockerfileCopy struct {
+	Sources     []string
+	Destination string
+}
+
+// parseDockerfile reads the Dockerfile of the function. It returns an error naming the first instruction that cannot
+// be built natively, e.g., RUN or a second build stage.
+func parseDockerfile(dockerfilePath string) (dockerfileStage, error) {
+	contents, err := os.ReadFile(dockerfilePath)
+	if err != nil {
+		return dockerfileStage{}, err
+	}
+
+	var stage dockerfileStage
+	for _, line := range dockerfileInstructions(contents) {
+		instruction, arguments, _ := strings.Cut(line, " ")
+		arguments = strings.TrimSpace(arguments)
+
+		switch strings.ToUpper(instruction) {
+		case "FROM":
+			if stage.BaseImage != "" {
+				return dockerfileStage{}, fmt.Errorf("multi-stage builds are not supported natively")
+			}
+			stage.BaseImage = strings.Fields(arguments)[0]
+		case "WORKDIR":
+			stage.WorkingDir = path.Join("/", stage.WorkingDir, arguments)
+		case "COPY", "ADD":
+			if strings.HasPrefix(arguments, "--") {
+				return dockerfileStage{}, fmt.Errorf("%s options are not supported natively: %s", instruction, line)
+			}
+			paths := dockerfileList(arguments)
+			if len(paths) < 2 {
+				return dockerfileStage{}, fmt.Errorf("%s needs a source and a destination: %s", instruction, line)
+			}
+			stage.Copies = append(stage.Copies, dockerfileCopy{Sources: paths[:len(paths)-1], Destination: paths[len(paths)-1]})
+		case "ENV":
+			if key, value, found := strings.Cut(arguments, "="); found {
+				stage.Env = append(stage.Env, fmt.Sprintf("%s=%s", key, strings.Trim(value, `"`)))
+			} else {
+				key, value, _ := strings.Cut(arguments, " ")
+				stage.Env = append(stage.Env, fmt.Sprintf("%s=%s", key, strings.TrimSpace(value)))
+			}
+		case "ENTRYPOINT":
+			stage.Entrypoint = dockerfileCommand(arguments)
+		case "CMD":
+			stage.Cmd = dockerfileCommand(arguments)
+		case "USER":
+			stage.User = arguments
+		case "EXPOSE", "LABEL":
+			// metadata which does not change how the function runs
+		default:
+			return dockerfileStage{}, fmt.Errorf("instruction %s is not supported natively", strings.ToUpper(instruction))
+		}
+	}
+
+	if stage.BaseImage == "" {
+		return dockerfileStage{}, fmt.Errorf("no FROM instruction")
+	}
+	return stage, nil
+}
+
+// dockerfileInstructions returns the instructions of the Dockerfile without comments, joining continued lines
+func dockerfileInstructions(contents []byte) []string {
+	var instructions []string
+	var continued string
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasSuffix(line, `\`) {
+			continued += strings.TrimSuffix(line, `\`) + " "
+			continue
+		}
+		instructions = append(instructions, continued+line)
+		continued = ""
+	}
+	return instructions
+}
+
+// dockerfileList parses the arguments of an instruction given in JSON form or separated by whitespace
+func dockerfileList(arguments string) []string {
+	var list []string
+	if err := json.Unmarshal([]byte(arguments), &list); err == nil {
+		return list
+	}
+	return strings.Fields(arguments)
+}
+
+// dockerfileCommand parses CMD and ENTRYPOINT, running the shell form through /bin/sh as Docker does
+func dockerfileCommand(arguments string) []string {
+	var command []string
+	if err := json.Unmarshal([]byte(arguments), &command); err == nil {
+		return command
+	}
+	return []string{"/bin/sh", "-c", arguments}
+}
+
+// applyConfig sets the runtime configuration of the stage on top of that of the base image
+func (s dockerfileStage) applyConfig(config *v1.Config) {
+	if s.WorkingDir != "" {
+		config.WorkingDir = s.WorkingDir
+	}
+	config.Env = append(config.Env, s.Env...)
+	if s.Entrypoint != nil {
+		config.Entrypoint = s.Entrypoint
+		config.Cmd = nil // as in Docker, setting the entrypoint resets the command of the base image
+	}
+	if s.Cmd != nil {
+		config.Cmd = s.Cmd
+	}
+	if s.User != "" {
+		config.User = s.User
+	}
+}
+
+// writeLayer writes the files copied from the build context as an uncompressed layer. Entries are sorted and carry a
+// fixed timestamp, so that the layer, and hence its digest, only depends on the copied files.
+func (s dockerfileStage) writeLayer(buildContext string, writer io.Writer) error {
+	layer := tar.NewWriter(writer)
+	for _, copyInstruction := range s.Copies {
+		destination := copyInstruction.Destination
+		if !path.IsAbs(destination) {
+			destination = path.Join("/", s.WorkingDir, destination)
+		}
+
+		for _, source := range copyInstruction.Sources {
+			sourcePath := filepath.Join(buildContext, filepath.FromSlash(source))
+			sourceInfo, err := os.Stat(sourcePath)
+			if err != nil {
+				return err
+			}
+
+			if !sourceInfo.IsDir() {
+				entryPath := destination
+				if strings.HasSuffix(copyInstruction.Destination, "/") || copyInstruction.Destination == "." || len(copyInstruction.Sources) > 1 {
+					entryPath = path.Join(destination, filepath.Base(sourcePath))
+				}
+				if err := addLayerEntry(layer, sourcePath, sourceInfo, entryPath); err != nil {
+					return err
+				}
+				continue
+			}
+
+			// as in Docker, the contents of a directory are copied, not the directory itself
+			err = filepath.WalkDir(sourcePath, func(filePath string, entry fs.DirEntry, err error) error {
+				if err != nil || filePath == sourcePath {
+					return err
+				}
+				relativePath, err := filepath.Rel(sourcePath, filePath)
+				if err != nil {
+					return err
+				}
+				if relativePath == "Dockerfile" || relativePath == ".dockerignore" {
+					return nil
+				}
+				fileInfo, err := entry.Info()
+				if err != nil {
+					return err
+				}
+				return addLayerEntry(layer, filePath, fileInfo, path.Join(destination, filepath.ToSlash(relativePath)))
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return layer.Close()
+}
+
+func addLayerEntry(layer *tar.Writer, filePath string, fileInfo fs.FileInfo, entryPath string) error {
+	header, err := tar.FileInfoHeader(fileInfo, "")
+	if err != nil {
+		return err
+	}
+	header.Name = strings.TrimPrefix(entryPath, "/")
+	header.ModTime = archiveModificationTime
+	header.Uid, header.Gid, header.Uname, header.Gname = 0, 0, "", ""
+	if fileInfo.IsDir() {
+		header.Name += "/"
+		return layer.WriteHeader(header)
+	}
+	if !fileInfo.Mode().IsRegular() {
+		return nil
+	}
+
+	if err := layer.WriteHeader(header); err != nil {
+		return err
+	}
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = io.Copy(layer, file)
+	return err
+}
@@ -0,0 +1,81 @@
+// MIT License
+//
+// Copyright (c) 2021 Theodor Amariucai and EASE Lab
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package packaging
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"io"
+	"os"
+)
+
+// FillerSeed seeds the contents of the filler files, archive entries and image layers. The same seed gives
+// byte-identical artifacts, so that runs are reproducible and registries and buckets only store each artifact once.
+var FillerSeed int64
+
+// NewFillerReader returns an endless stream of incompressible bytes, the AES-CTR keystream of a key derived from the
+// seed and the name of the filler. Fillers of different names, e.g., of different functions, differ, so that their
+// contents cannot be deduplicated by the providers.
+func NewFillerReader(seed int64, name string) io.Reader {
+	key := sha256.Sum256([]byte(fmt.Sprintf("%d/%s", seed, name)))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		log.Fatalf("Could not create the filler stream of %q: %s", name, err.Error())
+	}
+	return &fillerReader{stream: cipher.NewCTR(block, make([]byte, aes.BlockSize))}
+}
+
+type fillerReader struct {
+	stream cipher.Stream
+}
+
+func (f *fillerReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 0
+	}
+	f.stream.XORKeyStream(p, p)
+	return len(p), nil
+}
+
+// GenerateFillerFile streams a filler file of the given size, seeded with FillerSeed and named after its path, using a
+// constant amount of memory
+func GenerateFillerFile(experimentID int, fillerFilePath string, sizeBytes int64) {
+	log.Infof("[sub-experiment %d] Generating filler file to be included in deployment...", experimentID)
+
+	fillerFile, err := os.Create(fillerFilePath)
+	if err != nil {
+		log.Fatalf("[sub-experiment %d] Could not create filler file %s: %s", experimentID, fillerFilePath, err.Error())
+	}
+	if _, err := io.CopyN(fillerFile, NewFillerReader(FillerSeed, fillerFilePath), sizeBytes); err != nil {
+		fillerFile.Close()
+		log.Fatalf("[sub-experiment %d] Could not generate random file with size %d bytes: %v", experimentID, sizeBytes, err)
+	}
+	if err := fillerFile.Close(); err != nil {
+		log.Fatalf("[sub-experiment %d] Could not generate random file with size %d bytes: %v", experimentID, sizeBytes, err)
+	}
+
+	log.Infof("[sub-experiment %d] Successfully generated the filler file.", experimentID)
+}
@@ -0,0 +1,204 @@
+package packaging
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/rand"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/stretchr/testify/require"
+	"io"
+	"log"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"stellar/setup/deployment/packaging"
+	"stellar/util"
+	"strings"
+	"testing"
+)
+
+func TestManifestCompressedBytes(t *testing.T) {
+	compressedBytes, err := packaging.ManifestCompressedBytes([]byte(`{
+		"schemaVersion": 2,
+		"config": {"size": 1470},
+		"layers": [{"size": 3401613}, {"size": 1024}, {"size": 32}]
+	}`))
+	require.NoError(t, err)
+	require.Equal(t, int64(3402669), compressedBytes)
+
+	_, err = packaging.ManifestCompressedBytes([]byte(`{"manifests": [{"digest": "sha256:abc", "platform": {"os": "linux"}}]}`))
+	require.Error(t, err)
+}
+
+func TestFillerFileSizeForLayer(t *testing.T) {
+	layerSizeBytes := util.MebibyteToBytes(4)
+	fillerFileSizeBytes := packaging.FillerFileSizeForLayer(layerSizeBytes)
+
+	layer := &countingWriter{}
+	compressedLayer, err := gzip.NewWriterLevel(layer, gzip.NoCompression)
+	require.NoError(t, err)
+	layerArchive := tar.NewWriter(compressedLayer)
+	require.NoError(t, layerArchive.WriteHeader(&tar.Header{Name: "filler.file", Mode: 0644, Size: fillerFileSizeBytes}))
+	_, err = io.CopyN(layerArchive, rand.Reader, fillerFileSizeBytes)
+	require.NoError(t, err)
+	require.NoError(t, layerArchive.Close())
+	require.NoError(t, compressedLayer.Close())
+
+	require.InDelta(t, layerSizeBytes, layer.written, 512)
+}
+
+func TestSetupContainerImageDeploymentLocalRegistry(t *testing.T) {
+	registryServer := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	t.Cleanup(registryServer.Close)
+	registryHost := strings.TrimPrefix(registryServer.URL, "http://")
+	packaging.ImageRegistry = registryHost
+	t.Cleanup(func() { packaging.ImageRegistry = "" })
+
+	baseImage, err := random.Image(1024, 1)
+	require.NoError(t, err)
+	baseReference, err := name.ParseReference(registryHost + "/python:3.9")
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(baseReference, baseImage))
+
+	functionDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(functionDir, "app.py"), []byte("print('hello')\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(functionDir, "Dockerfile"), []byte(`FROM `+registryHost+`/python:3.9
+# copy the function code
+WORKDIR /app
+COPY . .
+ENV PORT=8080
+CMD exec python app.py
+`), 0644))
+
+	fake := &util.FakeRunner{}
+	imageReference, imageSize := packaging.SetupContainerImageDeployment(1, "hellopy", "gcr", functionDir, 1, fake)
+
+	require.Empty(t, fake.Commands(), "the image is built natively, without the Docker CLI")
+
+	require.True(t, strings.HasPrefix(imageReference, registryHost+"/hellopy_1_stellar@sha256:"))
+	require.Equal(t, util.MebibyteToBytes(1), imageSize.CompressedBytes)
+
+	pulledReference, err := name.ParseReference(imageReference)
+	require.NoError(t, err)
+	pulledImage, err := remote.Image(pulledReference)
+	require.NoError(t, err)
+	layers, err := pulledImage.Layers()
+	require.NoError(t, err)
+	require.Len(t, layers, 3) // base, function and filler layers
+
+	configFile, err := pulledImage.ConfigFile()
+	require.NoError(t, err)
+	require.Equal(t, "/app", configFile.Config.WorkingDir)
+	require.Equal(t, []string{"/bin/sh", "-c", "exec python app.py"}, configFile.Config.Cmd)
+	require.Contains(t, configFile.Config.Env, "PORT=8080")
+
+	manifest, err := pulledImage.RawManifest()
+	require.NoError(t, err)
+	compressedBytes, err := packaging.ManifestCompressedBytes(manifest)
+	require.NoError(t, err)
+	require.Equal(t, imageSize.CompressedBytes, compressedBytes)
+}
+
+func TestSetupContainerImageDeploymentExactSizes(t *testing.T) {
+	registryServer := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	t.Cleanup(registryServer.Close)
+	registryHost := strings.TrimPrefix(registryServer.URL, "http://")
+	packaging.ImageRegistry = registryHost
+	t.Cleanup(func() { packaging.ImageRegistry = "" })
+
+	baseImage, err := random.Image(1024, 1)
+	require.NoError(t, err)
+	baseReference, err := name.ParseReference(registryHost + "/python:3.9")
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(baseReference, baseImage))
+
+	functionDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(functionDir, "app.py"), []byte("print('hello')\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(functionDir, "Dockerfile"), []byte("FROM "+registryHost+"/python:3.9\nCOPY app.py /app.py\n"), 0644))
+
+	// the base and function layers leave a different remainder to pad for each size
+	for _, compressedImageSizeMebibyte := range []float64{1, 2, 3, 7, 16} {
+		_, imageSize := packaging.SetupContainerImageDeployment(1, "hellopy", "gcr", functionDir, compressedImageSizeMebibyte, &util.FakeRunner{})
+		require.Equal(t, util.MebibyteToBytes(compressedImageSizeMebibyte), imageSize.CompressedBytes, "%v MiB", compressedImageSizeMebibyte)
+	}
+}
+
+// dockerRunner records the commands like util.FakeRunner, and exports a random image for docker save
+type dockerRunner struct {
+	util.FakeRunner
+	exportPath string
+}
+
+func (r *dockerRunner) CombinedOutput(cmd *exec.Cmd) ([]byte, error) {
+	if len(cmd.Args) > 4 && cmd.Args[1] == "save" {
+		r.exportPath = cmd.Args[3]
+		image, err := random.Image(1024, 2)
+		if err != nil {
+			return nil, err
+		}
+		tag, err := name.NewTag(cmd.Args[4])
+		if err != nil {
+			return nil, err
+		}
+		if err := tarball.WriteToFile(r.exportPath, tag, image); err != nil {
+			return nil, err
+		}
+	}
+	return r.FakeRunner.CombinedOutput(cmd)
+}
+
+func TestSetupContainerImageDeploymentBundledDockerfile(t *testing.T) {
+	registryServer := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	t.Cleanup(registryServer.Close)
+	registryHost := strings.TrimPrefix(registryServer.URL, "http://")
+	packaging.ImageRegistry = registryHost
+	t.Cleanup(func() { packaging.ImageRegistry = "" })
+
+	// the bundled Dockerfile installs its dependencies with RUN, hence it is built with the Docker CLI
+	functionDir := "../../raw-code/serverless/gcr/hellopy"
+	runner := &dockerRunner{}
+	imageReference, imageSize := packaging.SetupContainerImageDeployment(1, "hellopy", "gcr", functionDir, 2, runner)
+
+	require.Equal(t, []string{
+		"docker build -t hellopy_stellar:build " + functionDir,
+		"docker save -o " + runner.exportPath + " hellopy_stellar:build",
+	}, commandLines(runner.Commands()))
+	require.NoDirExists(t, filepath.Dir(runner.exportPath), "the image exported by Docker is removed once pushed")
+	require.Equal(t, util.MebibyteToBytes(2), imageSize.CompressedBytes)
+
+	pulledReference, err := name.ParseReference(imageReference)
+	require.NoError(t, err)
+	pulledImage, err := remote.Image(pulledReference)
+	require.NoError(t, err)
+	layers, err := pulledImage.Layers()
+	require.NoError(t, err)
+	require.Len(t, layers, 3) // the two layers built by Docker and the filler layer
+
+	manifest, err := pulledImage.RawManifest()
+	require.NoError(t, err)
+	compressedBytes, err := packaging.ManifestCompressedBytes(manifest)
+	require.NoError(t, err)
+	require.Equal(t, imageSize.CompressedBytes, compressedBytes)
+}
+
+func commandLines(commands []util.RecordedCommand) []string {
+	var lines []string
+	for _, command := range commands {
+		lines = append(lines, command.String())
+	}
+	return lines
+}
+
+type countingWriter struct {
+	written int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	c.written += int64(len(p))
+	return len(p), nil
+}
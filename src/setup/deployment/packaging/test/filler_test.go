@@ -0,0 +1,56 @@
+package packaging
+
+import (
+	"bytes"
+	"compress/gzip"
+	"github.com/stretchr/testify/require"
+	"io"
+	"os"
+	"path/filepath"
+	"stellar/setup/deployment/packaging"
+	"testing"
+)
+
+func readFiller(t *testing.T, seed int64, name string, sizeBytes int64) []byte {
+	filler, err := io.ReadAll(io.LimitReader(packaging.NewFillerReader(seed, name), sizeBytes))
+	require.NoError(t, err)
+	return filler
+}
+
+func TestFillerReaderIsSeeded(t *testing.T) {
+	filler := readFiller(t, 42, "hellopy.zip", 1<<16)
+
+	require.Equal(t, filler, readFiller(t, 42, "hellopy.zip", 1<<16))
+	require.NotEqual(t, filler, readFiller(t, 43, "hellopy.zip", 1<<16))
+	require.NotEqual(t, filler, readFiller(t, 42, "hellogo.zip", 1<<16))
+}
+
+func TestFillerReaderIsIncompressible(t *testing.T) {
+	filler := readFiller(t, 42, "hellopy.zip", 1<<20)
+
+	var compressed bytes.Buffer
+	compressor, err := gzip.NewWriterLevel(&compressed, gzip.BestCompression)
+	require.NoError(t, err)
+	_, err = compressor.Write(filler)
+	require.NoError(t, err)
+	require.NoError(t, compressor.Close())
+
+	require.Greater(t, compressed.Len(), len(filler))
+}
+
+func TestGenerateFillerFileIsReproducible(t *testing.T) {
+	t.Cleanup(func() { packaging.FillerSeed = 0 })
+	fillerFilePath := filepath.Join(t.TempDir(), "filler.file")
+
+	packaging.FillerSeed = 7
+	packaging.GenerateFillerFile(1, fillerFilePath, 100000)
+	firstFiller, err := os.ReadFile(fillerFilePath)
+	require.NoError(t, err)
+	packaging.GenerateFillerFile(1, fillerFilePath, 100000)
+	secondFiller, err := os.ReadFile(fillerFilePath)
+	require.NoError(t, err)
+
+	require.Len(t, firstFiller, 100000)
+	require.Equal(t, firstFiller, secondFiller)
+	require.Equal(t, readFiller(t, 7, fillerFilePath, 100000), firstFiller)
+}
@@ -1,14 +1,17 @@
 package packaging
 
 import (
+	"archive/zip"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 	"os"
+	"path/filepath"
 	"stellar/setup/building"
 	"stellar/setup/deployment/packaging"
 	"stellar/util"
 	"testing"
+	"time"
 )
 
 type ZipTestSuite struct {
@@ -39,11 +42,63 @@ func (s *ZipTestSuite) TestGenerateFillerFile() {
 	}
 }
 
+func (s *ZipTestSuite) TestGenerateSizedZIPExactSize() {
+	binaryPath := filepath.Join(s.T().TempDir(), "bootstrap")
+	assert.NoError(s.T(), os.WriteFile(binaryPath, []byte("#!/bin/sh\necho hello\n"), 0755))
+	zipPath := filepath.Join(s.T().TempDir(), "benchmarking.zip")
+
+	targetSizeBytes := util.MebibyteToBytes(3)
+	assert.Equal(s.T(), targetSizeBytes, packaging.GenerateSizedZIP(1, binaryPath, zipPath, targetSizeBytes))
+
+	fileInfo, err := os.Stat(zipPath)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), targetSizeBytes, fileInfo.Size())
+
+	assert.Equal(s.T(), packaging.GetZippedBinaryFileSize(1, binaryPath), packaging.GenerateSizedZIP(1, binaryPath, zipPath, 0))
+}
+
+func (s *ZipTestSuite) TestGenerateSizedZIPDeterministicEntries() {
+	binaryPath := filepath.Join(s.T().TempDir(), "bootstrap")
+	assert.NoError(s.T(), os.WriteFile(binaryPath, []byte("#!/bin/sh\necho hello\n"), 0755))
+	zipPath := filepath.Join(s.T().TempDir(), "benchmarking.zip")
+	packaging.GenerateSizedZIP(1, binaryPath, zipPath, util.MebibyteToBytes(1))
+
+	archive, err := zip.OpenReader(zipPath)
+	assert.NoError(s.T(), err)
+	defer archive.Close()
+
+	assert.Len(s.T(), archive.File, 2)
+	assert.Equal(s.T(), "bootstrap", archive.File[0].Name)
+	assert.Equal(s.T(), os.FileMode(0755), archive.File[0].Mode().Perm())
+	assert.Equal(s.T(), "filler.file", archive.File[1].Name)
+	for _, entry := range archive.File {
+		assert.True(s.T(), entry.Modified.Equal(time.Date(1980, time.January, 1, 0, 0, 0, 0, time.UTC)))
+	}
+}
+
+func (s *ZipTestSuite) TestGenerateSizedZIPIsReproducible() {
+	binaryPath := filepath.Join(s.T().TempDir(), "bootstrap")
+	assert.NoError(s.T(), os.WriteFile(binaryPath, []byte("#!/bin/sh\necho hello\n"), 0755))
+	firstZipPath := filepath.Join(s.T().TempDir(), "benchmarking.zip")
+	secondZipPath := filepath.Join(s.T().TempDir(), "benchmarking.zip")
+
+	packaging.GenerateSizedZIP(1, binaryPath, firstZipPath, util.MebibyteToBytes(1))
+	packaging.GenerateSizedZIP(1, binaryPath, secondZipPath, util.MebibyteToBytes(1))
+
+	firstZip, err := os.ReadFile(firstZipPath)
+	assert.NoError(s.T(), err)
+	secondZip, err := os.ReadFile(secondZipPath)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), firstZip, secondZip)
+}
+
 func (s *ZipTestSuite) TestGenerateServerlessZipArtifactsPython() {
 	b := &building.Builder{}
 	b.BuildFunction("aws", "hellopy", "python3.9")
-	packaging.GenerateServerlessZIPArtifacts(1, "aws", "python3.9", "hellopy", 50)
-	fileInfo, err := os.Stat("setup/deployment/raw-code/serverless/aws/artifacts/hellopy/hellopy.zip")
+	archiveDirectory := s.T().TempDir()
+	zipPath := packaging.GenerateServerlessZIPArtifacts(1, "aws", "python3.9", "hellopy", 50, archiveDirectory)
+	assert.Equal(s.T(), filepath.Join(archiveDirectory, "hellopy.zip"), zipPath)
+	fileInfo, err := os.Stat(zipPath)
 	if err != nil {
 		assert.Fail(s.T(), "Could not obtain file info of ZIP artifact")
 	}
@@ -53,8 +108,10 @@ func (s *ZipTestSuite) TestGenerateServerlessZipArtifactsPython() {
 func (s *ZipTestSuite) TestGenerateServerlessZipArtifactsGolang() {
 	b := &building.Builder{}
 	b.BuildFunction("aws", "hellogo", "go1.x")
-	packaging.GenerateServerlessZIPArtifacts(2, "aws", "go1.x", "hellogo", 50)
-	fileInfo, err := os.Stat("setup/deployment/raw-code/serverless/aws/artifacts/hellogo/hellogo.zip")
+	archiveDirectory := s.T().TempDir()
+	zipPath := packaging.GenerateServerlessZIPArtifacts(2, "aws", "go1.x", "hellogo", 50, archiveDirectory)
+	assert.Equal(s.T(), filepath.Join(archiveDirectory, "hellogo.zip"), zipPath)
+	fileInfo, err := os.Stat(zipPath)
 	if err != nil {
 		assert.Fail(s.T(), "Could not obtain file info of ZIP artifact")
 	}
@@ -64,8 +121,10 @@ func (s *ZipTestSuite) TestGenerateServerlessZipArtifactsGolang() {
 func (s *ZipTestSuite) TestGenerateServerlessZipArtifactsJava() {
 	b := &building.Builder{}
 	b.BuildFunction("aws", "hellojava", "java11")
-	packaging.GenerateServerlessZIPArtifacts(3, "aws", "java11", "hellojava", 50)
-	fileInfo, err := os.Stat("setup/deployment/raw-code/serverless/aws/artifacts/hellojava/hellojava.zip")
+	archiveDirectory := s.T().TempDir()
+	zipPath := packaging.GenerateServerlessZIPArtifacts(3, "aws", "java11", "hellojava", 50, archiveDirectory)
+	assert.Equal(s.T(), filepath.Join(archiveDirectory, "hellojava.zip"), zipPath)
+	fileInfo, err := os.Stat(zipPath)
 	if err != nil {
 		assert.Fail(s.T(), "Could not obtain file info of ZIP artifact")
 	}
@@ -75,8 +134,10 @@ func (s *ZipTestSuite) TestGenerateServerlessZipArtifactsJava() {
 func (s *ZipTestSuite) TestGenerateServerlessZipArtifactsNode() {
 	b := &building.Builder{}
 	b.BuildFunction("aws", "hellonode", "nodejs18.x")
-	packaging.GenerateServerlessZIPArtifacts(2, "aws", "nodejs18.x", "hellonode", 50)
-	fileInfo, err := os.Stat("setup/deployment/raw-code/serverless/aws/artifacts/hellonode/hellonode.zip")
+	archiveDirectory := s.T().TempDir()
+	zipPath := packaging.GenerateServerlessZIPArtifacts(2, "aws", "nodejs18.x", "hellonode", 50, archiveDirectory)
+	assert.Equal(s.T(), filepath.Join(archiveDirectory, "hellonode.zip"), zipPath)
+	fileInfo, err := os.Stat(zipPath)
 	if err != nil {
 		assert.Fail(s.T(), "Could not obtain file info of ZIP artifact")
 	}
@@ -86,8 +147,10 @@ func (s *ZipTestSuite) TestGenerateServerlessZipArtifactsNode() {
 func (s *ZipTestSuite) TestGenerateServerlessZipArtifactsRuby() {
 	b := &building.Builder{}
 	b.BuildFunction("aws", "helloruby", "ruby3.2")
-	packaging.GenerateServerlessZIPArtifacts(2, "aws", "ruby3.2", "helloruby", 50)
-	fileInfo, err := os.Stat("setup/deployment/raw-code/serverless/aws/artifacts/helloruby/helloruby.zip")
+	archiveDirectory := s.T().TempDir()
+	zipPath := packaging.GenerateServerlessZIPArtifacts(2, "aws", "ruby3.2", "helloruby", 50, archiveDirectory)
+	assert.Equal(s.T(), filepath.Join(archiveDirectory, "helloruby.zip"), zipPath)
+	fileInfo, err := os.Stat(zipPath)
 	if err != nil {
 		assert.Fail(s.T(), "Could not obtain file info of ZIP artifact")
 	}
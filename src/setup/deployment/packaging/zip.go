@@ -25,88 +25,81 @@ package packaging
 import (
 	"fmt"
 	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
 	// "math/rand"
 	// 25.09 change for go linter syntax check errors 
 	// "math/rand"
-	"crypto/rand"
+	"archive/zip"
+	"io"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"stellar/setup/deployment/connection/amazon"
+	"stellar/tracing"
 	"stellar/util"
+	"time"
 )
 
+const fillerEntryName = "filler.file"
+
+// archiveModificationTime is the modification time of all archive entries, so that archives of the same files are
+// identical regardless of when the files were built
+var archiveModificationTime = time.Date(1980, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// NewArchiveDirectory creates the temporary directory the archives of a deployment are written to. The returned
+// function removes it once the archives are uploaded or deployed.
+func NewArchiveDirectory() (string, func()) {
+	archiveDirectory, err := os.MkdirTemp("", "stellar-artifacts-*")
+	if err != nil {
+		log.Fatalf("Could not create temporary directory for archives: %s", err.Error())
+	}
+	return archiveDirectory, func() {
+		if err := os.RemoveAll(archiveDirectory); err != nil {
+			log.Errorf("Could not clean up archives in %q: %s", archiveDirectory, err.Error())
+		}
+	}
+}
+
 // SetupZIPDeployment will package the function using ZIP
 func SetupZIPDeployment(provider string, deploymentSizeBytes int64, zipPath string) {
 	deploymentSizeMB := util.BytesToMebibyte(deploymentSizeBytes)
 	switch provider {
 	case "aws":
 		if deploymentSizeMB > 50. {
-			amazon.UploadZIPToS3(zipPath, deploymentSizeMB)
+			amazon.UploadZIPToS3(tracing.RunContext(), zipPath, deploymentSizeMB)
 		} else {
 			amazon.SetLocalZip(zipPath)
 		}
 	default:
 		log.Warnf("Provider %s does not support ZIP deployment, skipping ZIP generation...", provider)
 	}
-
-	log.Debugf("Cleaning up ZIP %q...", zipPath)
-	util.RunCommandAndLog(exec.Command("rm", "-r", zipPath))
 }
 
-// GetZippedBinaryFileSize zips the binary and returns its size
+// GetZippedBinaryFileSize returns the exact size of the archive holding only the binary, without writing it
 func GetZippedBinaryFileSize(experimentID int, binaryPath string) int64 {
 	log.Infof("[sub-experiment %d] Zipping binary file to find its size...", experimentID)
-	pathToTempArchive := filepath.Join(filepath.Dir(binaryPath), "zipped-binary.zip")
-
-	util.RunCommandAndLog(exec.Command("zip", pathToTempArchive, binaryPath))
-
-	fi, err := os.Stat(pathToTempArchive)
-	if err != nil {
-		log.Fatalf("Could not get size of zipped binary file: %s", err.Error())
-	}
-	zippedBinarySizeBytes := fi.Size()
-
-	log.Debug("Cleaning up zipped binary...")
-	util.RunCommandAndLog(exec.Command("rm", "-r", pathToTempArchive))
-
-	return zippedBinarySizeBytes
-}
-
-func GenerateFillerFile(experimentID int, fillerFilePath string, sizeBytes int64) {
-	log.Infof("[sub-experiment %d] Generating filler file to be included in deployment...", experimentID)
-
-	buffer := make([]byte, sizeBytes)
-	_, err := rand.Read(buffer) // The slice should now contain random bytes instead of only zeroes (prevents efficient archiving).
-	if err != nil {
-		log.Fatalf("[sub-experiment %d] Failed to fill buffer with random bytes: `%s`", experimentID, err.Error())
-	}
-
-	if err := os.WriteFile(fillerFilePath, buffer, 0666); err != nil {
-		log.Fatalf("[sub-experiment %d] Could not generate random file with size %d bytes: %v", experimentID, sizeBytes, err)
-	}
-
-	log.Infof("[sub-experiment %d] Successfully generated the filler file.", experimentID)
+	return archiveSizeBytes(nil, []string{binaryPath}, -1)
 }
 
-// GenerateZIP creates the zip file for deployment
-func GenerateZIP(experimentID int, fillerFilePath string, binaryPath string, zipName string) string {
+// GenerateSizedZIP creates the zip file for deployment with a filler entry sized for the archive to be exactly
+// targetSizeBytes large, or without filler if the target is 0. Returns the size of the archive in bytes.
+func GenerateSizedZIP(experimentID int, binaryPath string, zipPath string, targetSizeBytes int64) int64 {
+	fillerSizeBytes := fillerEntrySizeBytes(experimentID, nil, []string{binaryPath}, targetSizeBytes)
 	log.Infof("[sub-experiment %d] Generating ZIP file to be deployed...", experimentID)
+	writeArchiveFile(zipPath, nil, []string{binaryPath}, fillerSizeBytes)
 
-	util.RunCommandAndLog(exec.Command("zip", "-j", zipName, binaryPath, fillerFilePath))
-
-	util.RunCommandAndLog(exec.Command("rm", "-r", fillerFilePath))
-
-	log.Infof("[sub-experiment %d] Successfully generated ZIP file.", experimentID)
-
-	workingDirectory, err := filepath.Abs(filepath.Dir("."))
+	fileInfo, err := os.Stat(zipPath)
 	if err != nil {
-		log.Fatal(err)
+		log.Fatalf("[sub-experiment %d] Could not get size of ZIP file %s: %s", experimentID, zipPath, err.Error())
 	}
-	return filepath.Join(workingDirectory, zipName)
+	return fileInfo.Size()
 }
 
-func GenerateServerlessZIPArtifacts(experimentID int, provider string, runtime string, functionName string, functionImageSizeMB float64) {
+// GenerateServerlessZIPArtifacts writes the sized archive of the built function to archiveDirectory and returns its path,
+// or an empty path if the runtime is not packaged by STeLLAR.
+func GenerateServerlessZIPArtifacts(experimentID int, provider string, runtime string, functionName string, functionImageSizeMB float64, archiveDirectory string) string {
+	defer tracing.StartStep("package", attribute.Int("stellar.sub_experiment", experimentID), attribute.String("stellar.provider", provider),
+		attribute.String("stellar.function", functionName), attribute.Float64("stellar.image_size_mb", functionImageSizeMB)).End()
+
 	switch runtime {
 	case "python3.9":
 		fallthrough
@@ -115,13 +108,14 @@ func GenerateServerlessZIPArtifacts(experimentID int, provider string, runtime s
 	case "ruby3.2":
 		fallthrough
 	case "go1.x":
-		generateServerlessZIPArtifactsGeneral(experimentID, provider, runtime, functionName, functionImageSizeMB)
+		return generateServerlessZIPArtifactsGeneral(experimentID, provider, runtime, functionName, functionImageSizeMB, archiveDirectory)
 	case "java11":
-		generateServerlessZIPArtifactsJava(experimentID, provider, runtime, functionName, functionImageSizeMB)
+		return generateServerlessZIPArtifactsJava(experimentID, provider, runtime, functionName, functionImageSizeMB, archiveDirectory)
 	}
+	return ""
 }
 
-func generateServerlessZIPArtifactsGeneral(experimentID int, provider string, runtime string, functionName string, functionImageSizeMB float64) {
+func generateServerlessZIPArtifactsGeneral(experimentID int, provider string, runtime string, functionName string, functionImageSizeMB float64, archiveDirectory string) string {
 	defaultBinaryName := map[string]string{
 		"python3.9":  "main.py",
 		"go1.x":      "bootstrap",
@@ -129,33 +123,24 @@ func generateServerlessZIPArtifactsGeneral(experimentID int, provider string, ru
 		"ruby3.2":    "function.rb",
 	}
 	binaryPath := fmt.Sprintf("setup/deployment/raw-code/serverless/%s/artifacts/%s/%s", provider, functionName, defaultBinaryName[runtime])
+	zipPath := filepath.Join(archiveDirectory, fmt.Sprintf("%s.zip", functionName))
 
-	currentSizeInBytes := GetZippedBinaryFileSize(experimentID, binaryPath)
-	targetSizeInBytes := util.MebibyteToBytes(functionImageSizeMB)
-
-	fillerFileSize := CalculateFillerFileSizeInBytes(currentSizeInBytes, targetSizeInBytes)
-	fillerFilePath := fmt.Sprintf("setup/deployment/raw-code/serverless/%s/artifacts/%s/filler.file", provider, functionName)
-	GenerateFillerFile(experimentID, fillerFilePath, fillerFileSize)
-
-	zipPath := fmt.Sprintf("setup/deployment/raw-code/serverless/%s/artifacts/%s/%s.zip", provider, functionName, functionName)
-	GenerateZIP(experimentID, fillerFilePath, binaryPath, zipPath)
+	GenerateSizedZIP(experimentID, binaryPath, zipPath, util.MebibyteToBytes(functionImageSizeMB))
+	return zipPath
 }
 
-func generateServerlessZIPArtifactsJava(experimentID int, provider string, runtime string, functionName string, functionImageSizeMB float64) {
+func generateServerlessZIPArtifactsJava(experimentID int, provider string, runtime string, functionName string, functionImageSizeMB float64, archiveDirectory string) string {
 	gradleArtifactPath := fmt.Sprintf("setup/deployment/raw-code/serverless/%s/artifacts/%s/%s.zip", provider, functionName, functionName)
 	fileInfo, err := os.Stat(gradleArtifactPath)
 	if err != nil {
 		log.Fatalf("Could not file size of Java artifact at %s", gradleArtifactPath)
 	}
 
-	currentSizeInBytes := fileInfo.Size()
-	targetSizeInBytes := util.MebibyteToBytes(functionImageSizeMB)
-
-	fillerFileSize := CalculateFillerFileSizeInBytes(currentSizeInBytes, targetSizeInBytes)
-	fillerFilePath := fmt.Sprintf("setup/deployment/raw-code/serverless/%s/artifacts/%s/filler.file", provider, functionName)
-	GenerateFillerFile(experimentID, fillerFilePath, fillerFileSize)
+	log.Debugf("[sub-experiment %d] Gradle artifact %s is %d bytes", experimentID, gradleArtifactPath, fileInfo.Size())
 
-	addFileToExistingZIPArchive(gradleArtifactPath, fillerFilePath)
+	zipPath := filepath.Join(archiveDirectory, fmt.Sprintf("%s.zip", functionName))
+	addFillerToExistingZIPArchive(experimentID, gradleArtifactPath, zipPath, util.MebibyteToBytes(functionImageSizeMB))
+	return zipPath
 }
 
 func CalculateFillerFileSizeInBytes(currentSizeInBytes int64, targetSizeInBytes int64) int64 {
@@ -174,8 +159,181 @@ func CalculateFillerFileSizeInBytes(currentSizeInBytes int64, targetSizeInBytes
 	return targetSizeInBytes - currentSizeInBytes
 }
 
-func addFileToExistingZIPArchive(archivePath string, filePath string) {
-	log.Infof("Adding %s to an existing archive at %s", filePath, archivePath)
-	util.RunCommandAndLog(exec.Command("zip", "-gj", archivePath, filePath))
-	util.RunCommandAndLog(exec.Command("rm", "-r", filePath))
+// addFillerToExistingZIPArchive writes the entries of the archive with a filler entry to destinationPath, the filler being
+// sized for it to end up exactly at the target size
+func addFillerToExistingZIPArchive(experimentID int, archivePath string, destinationPath string, targetSizeBytes int64) {
+	log.Infof("[sub-experiment %d] Adding a filler to the existing archive at %s", experimentID, archivePath)
+
+	source, err := zip.OpenReader(archivePath)
+	if err != nil {
+		log.Fatalf("[sub-experiment %d] Could not open archive %s: %s", experimentID, archivePath, err.Error())
+	}
+	defer source.Close()
+
+	fillerSizeBytes := fillerEntrySizeBytes(experimentID, &source.Reader, nil, targetSizeBytes)
+	writeArchiveFile(destinationPath, &source.Reader, nil, fillerSizeBytes)
+}
+
+// fillerEntrySizeBytes returns the size of the filler entry which makes the archive of the given entries exactly
+// targetSizeBytes large. A target of 0 means no filler, i.e., -1.
+func fillerEntrySizeBytes(experimentID int, source *zip.Reader, filePaths []string, targetSizeBytes int64) int64 {
+	if targetSizeBytes == 0 {
+		log.Infof("[sub-experiment %d] Desired image size is set to default (0MB), assigning size of zipped binary (%vMB)...",
+			experimentID, util.BytesToMebibyte(archiveSizeBytes(source, filePaths, -1)))
+		return -1
+	}
+
+	// The filler is stored uncompressed, so each of its bytes adds exactly one byte to the archive
+	emptyFillerArchiveSizeBytes := archiveSizeBytes(source, filePaths, 0)
+	if targetSizeBytes < emptyFillerArchiveSizeBytes {
+		log.Fatalf("[sub-experiment %d] Total size (~%vMB) cannot be smaller than zipped binary size (~%vMB).",
+			experimentID,
+			util.BytesToMebibyte(targetSizeBytes),
+			util.BytesToMebibyte(emptyFillerArchiveSizeBytes),
+		)
+	}
+	return targetSizeBytes - emptyFillerArchiveSizeBytes
+}
+
+// archiveSizeBytes returns the exact size of the archive writeArchive would produce, without storing it
+func archiveSizeBytes(source *zip.Reader, filePaths []string, fillerSizeBytes int64) int64 {
+	counter := &countingWriter{}
+	if err := writeArchive(counter, source, filePaths, fillerSizeBytes, zeroReader{}); err != nil {
+		log.Fatalf("Could not compute the size of the archive of %v: %s", filePaths, err.Error())
+	}
+	return counter.written
+}
+
+// writeArchiveFile writes the archive in a temporary directory before moving it to archivePath, which may be the path
+// of the source archive.
+func writeArchiveFile(archivePath string, source *zip.Reader, filePaths []string, fillerSizeBytes int64) {
+	temporaryDirectory, err := os.MkdirTemp("", "stellar-zip-*")
+	if err != nil {
+		log.Fatalf("Could not create temporary directory for archive %s: %s", archivePath, err.Error())
+	}
+	defer os.RemoveAll(temporaryDirectory)
+
+	temporaryPath := filepath.Join(temporaryDirectory, filepath.Base(archivePath))
+	archiveFile, err := os.Create(temporaryPath)
+	if err != nil {
+		log.Fatalf("Could not create archive %s: %s", temporaryPath, err.Error())
+	}
+	fillerContents := NewFillerReader(FillerSeed, filepath.Base(archivePath))
+	if err := writeArchive(archiveFile, source, filePaths, fillerSizeBytes, fillerContents); err != nil {
+		log.Fatalf("Could not write archive %s: %s", archivePath, err.Error())
+	}
+	if err := archiveFile.Close(); err != nil {
+		log.Fatalf("Could not write archive %s: %s", archivePath, err.Error())
+	}
+
+	if err := moveFile(temporaryPath, archivePath); err != nil {
+		log.Fatalf("Could not move archive to %s: %s", archivePath, err.Error())
+	}
+}
+
+// writeArchive writes the entries of the source archive, if any, then the files under their base names and finally a
+// stored filler entry of fillerSizeBytes bytes read from fillerContents, unless fillerSizeBytes is negative.
+func writeArchive(writer io.Writer, source *zip.Reader, filePaths []string, fillerSizeBytes int64, fillerContents io.Reader) error {
+	archive := zip.NewWriter(writer)
+
+	if source != nil {
+		for _, entry := range source.File {
+			if entry.Name == fillerEntryName {
+				continue
+			}
+			if err := archive.Copy(entry); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, filePath := range filePaths {
+		if err := addFileToArchive(archive, filePath); err != nil {
+			return err
+		}
+	}
+
+	if fillerSizeBytes >= 0 {
+		fillerWriter, err := archive.CreateHeader(&zip.FileHeader{
+			Name:     fillerEntryName,
+			Method:   zip.Store, // random bytes do not compress, storing them keeps the archive size predictable
+			Modified: archiveModificationTime,
+		})
+		if err != nil {
+			return err
+		}
+		if _, err := io.CopyN(fillerWriter, fillerContents, fillerSizeBytes); err != nil {
+			return err
+		}
+	}
+
+	return archive.Close()
+}
+
+func addFileToArchive(archive *zip.Writer, filePath string) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	fileInfo, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	header, err := zip.FileInfoHeader(fileInfo) // keeps the permissions, e.g., of executable bootstrap binaries
+	if err != nil {
+		return err
+	}
+	header.Method = zip.Deflate
+	header.Modified = archiveModificationTime
+
+	entryWriter, err := archive.CreateHeader(header)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(entryWriter, file)
+	return err
+}
+
+// moveFile renames the file, copying it instead when the temporary directory is on another device
+func moveFile(sourcePath string, destinationPath string) error {
+	if err := os.Rename(sourcePath, destinationPath); err == nil {
+		return nil
+	}
+
+	source, err := os.Open(sourcePath)
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+
+	destination, err := os.Create(destinationPath)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(destination, source); err != nil {
+		destination.Close()
+		return err
+	}
+	return destination.Close()
+}
+
+type countingWriter struct {
+	written int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	c.written += int64(len(p))
+	return len(p), nil
+}
+
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 0
+	}
+	return len(p), nil
 }
@@ -15,9 +15,9 @@ DESCRIPTOR = _descriptor.FileDescriptor(
     name='chainfunction.proto',
     package='proto_gen',
     syntax='proto3',
-    serialized_options=b'Zegithub.com/vhive-serverless/stellar/src/setup/deployment/raw-code/producer-consumer/go1.x/vhive/proto_gen',
+    serialized_options=b'Zigithub.com/vhive-serverless/stellar/src/setup/deployment/raw-code/producer-consumer/go1.x/vhive/proto_gen',
     create_key=_descriptor._internal_create_key,
-    serialized_pb=b'\n\x13\x63hainfunction.proto\x12\tproto_gen\"\xcd\x01\n\x12InvokeChainRequest\x12\x16\n\x0eincrementLimit\x18\x01 \x01(\t\x12\x1c\n\x14\x64\x61taTransferChainIDs\x18\x02 \x01(\t\x12\x1a\n\x12payloadLengthBytes\x18\x03 \x01(\t\x12\x17\n\x0ftransferPayload\x18\x04 \x01(\t\x12\x16\n\x0etimestampChain\x18\x05 \x01(\t\x12\x0e\n\x06\x62ucket\x18\x06 \x01(\t\x12\x0b\n\x03key\x18\x07 \x01(\t\x12\x17\n\x0fstorageTransfer\x18\x08 \x01(\x08\"*\n\x10InvokeChainReply\x12\x16\n\x0etimestampChain\x18\x01 \x01(\t2^\n\x10ProducerConsumer\x12J\n\nInvokeNext\x12\x1d.proto_gen.InvokeChainRequest\x1a\x1b.proto_gen.InvokeChainReply\"\x00\x42gZegithub.com/vhive-serverless/stellar/src/setup/deployment/raw-code/producer-consumer/go1.x/vhive/proto_genb\x06proto3'
+    serialized_pb=b'\n\x13\x63hainfunction.proto\x12\tproto_gen\"\xcd\x01\n\x12InvokeChainRequest\x12\x16\n\x0eincrementLimit\x18\x01 \x01(\t\x12\x1c\n\x14\x64\x61taTransferChainIDs\x18\x02 \x01(\t\x12\x1a\n\x12payloadLengthBytes\x18\x03 \x01(\t\x12\x17\n\x0ftransferPayload\x18\x04 \x01(\t\x12\x16\n\x0etimestampChain\x18\x05 \x01(\t\x12\x0e\n\x06\x62ucket\x18\x06 \x01(\t\x12\x0b\n\x03key\x18\x07 \x01(\t\x12\x17\n\x0fstorageTransfer\x18\x08 \x01(\x08\"@\n\x10InvokeChainReply\x12\x16\n\x0etimestampChain\x18\x01 \x01(\t\x12\x14\n\x0cserverTiming\x18\x02 \x01(\t2^\n\x10ProducerConsumer\x12J\n\nInvokeNext\x12\x1d.proto_gen.InvokeChainRequest\x1a\x1b.proto_gen.InvokeChainReply\"\x00\x42kZigithub.com/vhive-serverless/stellar/src/setup/deployment/raw-code/producer-consumer/go1.x/vhive/proto_genb\x06proto3'
 )
 
 _INVOKECHAINREQUEST = _descriptor.Descriptor(
@@ -115,6 +115,13 @@ _INVOKECHAINREPLY = _descriptor.Descriptor(
             message_type=None, enum_type=None, containing_type=None,
             is_extension=False, extension_scope=None,
             serialized_options=None, file=DESCRIPTOR, create_key=_descriptor._internal_create_key),
+        _descriptor.FieldDescriptor(
+            name='serverTiming', full_name='proto_gen.InvokeChainReply.serverTiming', index=1,
+            number=2, type=9, cpp_type=9, label=1,
+            has_default_value=False, default_value=b"".decode('utf-8'),
+            message_type=None, enum_type=None, containing_type=None,
+            is_extension=False, extension_scope=None,
+            serialized_options=None, file=DESCRIPTOR, create_key=_descriptor._internal_create_key),
     ],
     extensions=[
     ],
@@ -128,7 +135,7 @@ _INVOKECHAINREPLY = _descriptor.Descriptor(
     oneofs=[
     ],
     serialized_start=242,
-    serialized_end=284,
+    serialized_end=306,
 )
 
 DESCRIPTOR.message_types_by_name['InvokeChainRequest'] = _INVOKECHAINREQUEST
@@ -158,8 +165,8 @@ _PRODUCERCONSUMER = _descriptor.ServiceDescriptor(
     index=0,
     serialized_options=None,
     create_key=_descriptor._internal_create_key,
-    serialized_start=286,
-    serialized_end=380,
+    serialized_start=308,
+    serialized_end=402,
     methods=[
         _descriptor.MethodDescriptor(
             name='InvokeNext',
@@ -1,12 +1,18 @@
 import grpc
+import json
 import logging
+import os
+import platform
 import six
+import time
 from chameleon import PageTemplate
 from concurrent import futures
 
 import chainfunction_pb2
 import chainfunction_pb2_grpc
 
+init_started = time.time()
+
 BIGTABLE_ZPT = """\
 <table xmlns="http://www.w3.org/1999/xhtml"
 xmlns:tal="http://xml.zope.org/namespaces/tal">
@@ -21,10 +27,46 @@ tal:content="python: d" />
 
 responses = ["record_response", "replay_response"]
 
+init_duration_ms = (time.time() - init_started) * 1000
+
+cold_start = True
+
+
+def read_memory_limit_mb():
+    for variable in ('AWS_LAMBDA_FUNCTION_MEMORY_SIZE', 'FUNCTION_MEMORY_MB'):
+        if variable in os.environ:
+            return int(os.environ[variable])
+    try:
+        with open('/sys/fs/cgroup/memory.max') as limit_file:
+            return int(limit_file.read()) // (1024 * 1024)
+    except (OSError, ValueError):
+        return 0
+
+
+MEMORY_LIMIT_MB = read_memory_limit_mb()
+
+
+def server_timing(handler_started, execution_started, spin_duration_ms):
+    # Only the first invocation of an instance is a cold start and reports the init duration
+    global cold_start
+    was_cold, cold_start = cold_start, False
+    return {
+        "HandlerStartMs": handler_started * 1000,
+        "HandlerEndMs": time.time() * 1000,
+        "ExecutionDurationMs": (time.perf_counter() - execution_started) * 1000,
+        "SpinDurationMs": spin_duration_ms,
+        "InitDurationMs": init_duration_ms if was_cold else 0,
+        "ColdStart": was_cold,
+        "Runtime": "python" + platform.python_version(),
+        "MemoryLimitMB": MEMORY_LIMIT_MB,
+    }
+
 
 class Greeter(chainfunction_pb2_grpc.ProducerConsumerServicer):
 
     def InvokeNext(self, _, context):
+        handler_started = time.time()
+        execution_started = time.perf_counter()
         tmpl = PageTemplate(BIGTABLE_ZPT)
 
         data = {}
@@ -39,7 +81,9 @@ class Greeter(chainfunction_pb2_grpc.ProducerConsumerServicer):
 
         data = tmpl.render(options=options)
 
-        return chainfunction_pb2.InvokeChainReply(timestampChain='[0]')
+        return chainfunction_pb2.InvokeChainReply(
+            timestampChain='[0]',
+            serverTiming=json.dumps(server_timing(handler_started, execution_started, 0)))
 
 
 def serve():
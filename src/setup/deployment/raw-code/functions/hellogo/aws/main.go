@@ -29,24 +29,55 @@ import (
 	"github.com/aws/aws-lambda-go/lambda"
 	"github.com/aws/aws-lambda-go/lambdacontext"
 	log "github.com/sirupsen/logrus"
+	"io/ioutil"
 	"net/http"
+	"os"
+	"runtime"
 	"strconv"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"time"
+	"unsafe"
 )
 
+//clockThreadCPUTimeID is CLOCK_THREAD_CPUTIME_ID, which the syscall package does not define
+const clockThreadCPUTimeID = 3
+
 //ProducerConsumerResponse is the structure that we expect a consumer-producer function response to follow
 type ProducerConsumerResponse struct {
-	RequestID      string   `json:"RequestID"`
-	TimestampChain []string `json:"TimestampChain"`
+	RequestID      string       `json:"RequestID"`
+	TimestampChain []string     `json:"TimestampChain"`
+	ServerTiming   ServerTiming `json:"ServerTiming"`
+}
+
+//ServerTiming is the standard server-side timing envelope of the benchmark functions
+type ServerTiming struct {
+	HandlerStartMs      float64 `json:"HandlerStartMs"`
+	HandlerEndMs        float64 `json:"HandlerEndMs"`
+	ExecutionDurationMs float64 `json:"ExecutionDurationMs"`
+	SpinDurationMs      float64 `json:"SpinDurationMs"`
+	InitDurationMs      float64 `json:"InitDurationMs"`
+	ColdStart           bool    `json:"ColdStart"`
+	Runtime             string  `json:"Runtime"`
+	MemoryLimitMB       int     `json:"MemoryLimitMB"`
 }
 
+var (
+	initStarted    = time.Now()
+	initDurationMs float64
+	initReported   int32
+	memoryLimitMB  = readMemoryLimitMB()
+)
+
 func main() {
+	initDurationMs = float64(time.Since(initStarted).Microseconds()) / 1000
 	lambda.Start(producerConsumer)
 }
 
 func producerConsumer(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	incrementLimit := extractIncrementLimit(&request)
-
-	simulateWork(incrementLimit)
+	handlerStarted := time.Now()
+	spinDuration := simulateWork(request.QueryStringParameters)
 
 	reqId := "no-context"
 
@@ -60,6 +91,7 @@ func producerConsumer(ctx context.Context, request events.APIGatewayProxyRequest
 	httpOutput, err := json.Marshal(ProducerConsumerResponse{
 		RequestID:      reqId,
 		TimestampChain: []string{},
+		ServerTiming:   newServerTiming(handlerStarted, spinDuration),
 	})
 	if err != nil {
 		log.Fatalf("Could not marshal function output: %s", err)
@@ -72,20 +104,85 @@ func producerConsumer(ctx context.Context, request events.APIGatewayProxyRequest
 	}, nil
 }
 
-func extractIncrementLimit(requestHTTP *events.APIGatewayProxyRequest) string {
-	var incrementLimit string
-	incrementLimit = requestHTTP.QueryStringParameters["IncrementLimit"]
-	return incrementLimit
+//simulateWork will keep the CPU busy-spinning until the desired service time has elapsed on the requested clock,
+//or up to the legacy increment limit, and returns the achieved duration
+func simulateWork(parameters map[string]string) time.Duration {
+	clock := wallClock
+	if parameters["SpinClock"] == "cpu" {
+		//The thread CPU time is only meaningful if the goroutine keeps spinning on the same thread
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+		clock = threadCPUClock
+	}
+	spinStarted := clock()
+
+	serviceTimeMs, ok := parameters["ServiceTimeMs"]
+	if !ok {
+		incrementLimit, err := strconv.Atoi(parameters["IncrementLimit"])
+		if err != nil {
+			log.Fatalf("Could not parse IncrementLimit parameter: %s", err.Error())
+		}
+		log.Infof("Running function up to increment limit (%d)...", incrementLimit)
+		for i := 0; i < incrementLimit; i++ {
+		}
+		return clock() - spinStarted
+	}
+
+	serviceTime, err := strconv.ParseFloat(serviceTimeMs, 64)
+	if err != nil {
+		log.Fatalf("Could not parse ServiceTimeMs parameter: %s", err.Error())
+	}
+	deadline := spinStarted + time.Duration(serviceTime*float64(time.Millisecond))
+	log.Infof("Running function for %v of %s time...", deadline-spinStarted, parameters["SpinClock"])
+	for clock() < deadline {
+	}
+	return clock() - spinStarted
+}
+
+func wallClock() time.Duration {
+	return time.Duration(time.Now().UnixNano())
+}
+
+//threadCPUClock returns the CPU time consumed by the calling thread
+func threadCPUClock() time.Duration {
+	var cpuTime syscall.Timespec
+	_, _, errno := syscall.RawSyscall(syscall.SYS_CLOCK_GETTIME, clockThreadCPUTimeID, uintptr(unsafe.Pointer(&cpuTime)), 0)
+	if errno != 0 {
+		log.Warnf("Could not read the thread CPU time: %s", errno.Error())
+		return wallClock()
+	}
+	return time.Duration(cpuTime.Nano())
 }
 
-//simulateWork will keep the CPU busy-spinning
-func simulateWork(incrementLimitString string) {
-	incrementLimit, err := strconv.Atoi(incrementLimitString)
+func readMemoryLimitMB() int {
+	if memorySize, err := strconv.Atoi(os.Getenv("AWS_LAMBDA_FUNCTION_MEMORY_SIZE")); err == nil {
+		return memorySize
+	}
+	limit, err := ioutil.ReadFile("/sys/fs/cgroup/memory.max")
+	if err != nil {
+		return 0
+	}
+	limitBytes, err := strconv.Atoi(strings.TrimSpace(string(limit)))
 	if err != nil {
-		log.Fatalf("Could not parse IncrementLimit parameter: %s", err.Error())
+		return 0
 	}
+	return limitBytes / (1024 * 1024)
+}
 
-	log.Infof("Running function up to increment limit (%d)...", incrementLimit)
-	for i := 0; i < incrementLimit; i++ {
+//newServerTiming reports the init duration to the first (cold) invocation of the instance only
+func newServerTiming(handlerStarted time.Time, spinDuration time.Duration) ServerTiming {
+	coldStart := atomic.CompareAndSwapInt32(&initReported, 0, 1)
+	timing := ServerTiming{
+		HandlerStartMs:      float64(handlerStarted.UnixNano()) / 1e6,
+		HandlerEndMs:        float64(time.Now().UnixNano()) / 1e6,
+		ExecutionDurationMs: float64(time.Since(handlerStarted).Microseconds()) / 1000,
+		SpinDurationMs:      float64(spinDuration.Microseconds()) / 1000,
+		ColdStart:           coldStart,
+		Runtime:             runtime.Version(),
+		MemoryLimitMB:       memoryLimitMB,
+	}
+	if coldStart {
+		timing.InitDurationMs = initDurationMs
 	}
+	return timing
 }
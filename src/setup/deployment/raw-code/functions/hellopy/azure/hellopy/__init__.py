@@ -1,10 +1,50 @@
 import logging
 
 import json
+import os
+import platform
+import time
 import azure.functions as func
 
+init_started = time.time()
+init_duration_ms = (time.time() - init_started) * 1000
+
+cold_start = True
+
+
+def read_memory_limit_mb():
+    for variable in ('AWS_LAMBDA_FUNCTION_MEMORY_SIZE', 'FUNCTION_MEMORY_MB'):
+        if variable in os.environ:
+            return int(os.environ[variable])
+    try:
+        with open('/sys/fs/cgroup/memory.max') as limit_file:
+            return int(limit_file.read()) // (1024 * 1024)
+    except (OSError, ValueError):
+        return 0
+
+
+MEMORY_LIMIT_MB = read_memory_limit_mb()
+
+
+def server_timing(handler_started, execution_started, spin_duration_ms):
+    # Only the first invocation of an instance is a cold start and reports the init duration
+    global cold_start
+    was_cold, cold_start = cold_start, False
+    return {
+        "HandlerStartMs": handler_started * 1000,
+        "HandlerEndMs": time.time() * 1000,
+        "ExecutionDurationMs": (time.perf_counter() - execution_started) * 1000,
+        "SpinDurationMs": spin_duration_ms,
+        "InitDurationMs": init_duration_ms if was_cold else 0,
+        "ColdStart": was_cold,
+        "Runtime": "python" + platform.python_version(),
+        "MemoryLimitMB": MEMORY_LIMIT_MB,
+    }
+
 
 def main(req: func.HttpRequest, context: func.Context) -> func.HttpResponse:
+    handler_started = time.time()
+    execution_started = time.perf_counter()
     return func.HttpResponse(
         status_code=200,
         headers={
@@ -13,5 +53,6 @@ def main(req: func.HttpRequest, context: func.Context) -> func.HttpResponse:
         body=json.dumps({
             "RequestID": context.invocation_id,
             "TimestampChain": ['0'],
+            "ServerTiming": server_timing(handler_started, execution_started, 0),
         })
     )
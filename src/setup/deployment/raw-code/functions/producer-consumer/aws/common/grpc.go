@@ -28,10 +28,11 @@ import (
 	protogen2 "github.com/vhive-serverless/stellar/src/setup/deployment/raw-code/functions/producer-consumer/proto_gen"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 	"time"
 )
 
-func invokeNextFunctionGRPC(request *protogen2.InvokeChainRequest, updatedTimestampChain []string, dataTransferChainIDs []string) []string {
+func invokeNextFunctionGRPC(request *protogen2.InvokeChainRequest, updatedTimestampChain []string, dataTransferChainIDs []string, traceParent string) []string {
 	log.Printf("Invoking next function: %s", dataTransferChainIDs[0])
 	conn, err := grpc.Dial(dataTransferChainIDs[0], grpc.WithInsecure(), grpc.WithBlock())
 	if err != nil {
@@ -41,6 +42,9 @@ func invokeNextFunctionGRPC(request *protogen2.InvokeChainRequest, updatedTimest
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
 	defer cancel()
+	if traceParent != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, traceParentHeader, traceParent)
+	}
 
 	client, err := protogen2.NewProducerConsumerClient(conn).InvokeNext(ctx, &protogen2.InvokeChainRequest{
 		IncrementLimit:       request.IncrementLimit,
@@ -30,21 +30,30 @@ import (
 	"github.com/aws/aws-lambda-go/lambdacontext"
 	protogen2 "github.com/vhive-serverless/stellar/src/setup/deployment/raw-code/functions/producer-consumer/proto_gen"
 	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/metadata"
 	"strconv"
+	"strings"
+	"time"
 )
 
+//traceParentHeader carries the W3C trace context of the client, forwarded along the chain
+const traceParentHeader = "traceparent"
+
 //GlobalRandomPayload is a 1MB string used for quick random payload generation
 var GlobalRandomPayload string
 
 //ProducerConsumerResponse is the structure that we expect a consumer-producer function response to follow
 type ProducerConsumerResponse struct {
-	RequestID      string   `json:"RequestID"`
-	TimestampChain []string `json:"TimestampChain"`
+	RequestID      string       `json:"RequestID"`
+	TimestampChain []string     `json:"TimestampChain"`
+	ServerTiming   ServerTiming `json:"ServerTiming"`
 }
 
-//GenerateResponse creates the HTTP or gRPC producer-consumer response payload
-func GenerateResponse(ctx context.Context, requestHTTP *events.APIGatewayProxyRequest, requestGRPC *protogen2.InvokeChainRequest) ([]byte, []string) {
-	dataTransferChainIDs, incrementLimit := extractChainIDsAndIncrementLimit(requestHTTP, requestGRPC)
+//GenerateResponse creates the HTTP or gRPC producer-consumer response payload, the timing envelope being part of the
+//HTTP payload and returned separately for gRPC
+func GenerateResponse(ctx context.Context, requestHTTP *events.APIGatewayProxyRequest, requestGRPC *protogen2.InvokeChainRequest) ([]byte, []string, ServerTiming) {
+	handlerStarted := time.Now()
+	dataTransferChainIDs, busySpin := extractChainIDsAndBusySpin(requestHTTP, requestGRPC)
 
 	var updatedTimestampChain []string
 	if isFirstFunctionInChain(requestGRPC, requestHTTP) {
@@ -99,12 +108,13 @@ func GenerateResponse(ctx context.Context, requestHTTP *events.APIGatewayProxyRe
 		}
 	}
 
-	simulateWork(incrementLimit)
+	spinDuration := simulateWork(busySpin)
 
 	if functionsLeftInChain(dataTransferChainIDs) {
 		log.Infof("There are %d functions left in the chain, invoking next one...", len(dataTransferChainIDs))
 
-		updatedTimestampChain = invokeNextFunction(requestHTTP, updatedTimestampChain, dataTransferChainIDs, requestGRPC)
+		traceParent := incomingTraceParent(ctx, requestHTTP)
+		updatedTimestampChain = invokeNextFunction(requestHTTP, updatedTimestampChain, dataTransferChainIDs, busySpin, requestGRPC, traceParent)
 	}
 
 	if requestHTTP != nil {
@@ -114,27 +124,34 @@ func GenerateResponse(ctx context.Context, requestHTTP *events.APIGatewayProxyRe
 		httpOutput, err := json.Marshal(ProducerConsumerResponse{
 			RequestID:      lc.AwsRequestID,
 			TimestampChain: updatedTimestampChain,
+			ServerTiming:   newServerTiming(handlerStarted, spinDuration),
 		})
 		if err != nil {
 			log.Fatalf("Could not marshal function output: %s", err)
 		}
-		return httpOutput, nil
+		return httpOutput, nil, ServerTiming{}
 	}
 
 	// gRPC
-	return nil, updatedTimestampChain
+	return nil, updatedTimestampChain, newServerTiming(handlerStarted, spinDuration)
 }
 
-func extractChainIDsAndIncrementLimit(requestHTTP *events.APIGatewayProxyRequest, requestGRPC *protogen2.InvokeChainRequest) ([]string, string) {
-	var dataTransferChainIDsString, incrementLimit string
+//extractChainIDsAndBusySpin returns the remaining chain and the busy-spin parameters present in the request
+func extractChainIDsAndBusySpin(requestHTTP *events.APIGatewayProxyRequest, requestGRPC *protogen2.InvokeChainRequest) ([]string, map[string]string) {
+	var dataTransferChainIDsString string
+	busySpin := make(map[string]string)
 	if requestHTTP != nil {
-		incrementLimit = requestHTTP.QueryStringParameters["IncrementLimit"]
+		for _, name := range busySpinParameters {
+			if value, ok := requestHTTP.QueryStringParameters[name]; ok {
+				busySpin[name] = value
+			}
+		}
 		dataTransferChainIDsString = requestHTTP.QueryStringParameters["DataTransferChainIDs"]
 	} else {
-		incrementLimit = requestGRPC.IncrementLimit
+		busySpin["IncrementLimit"] = requestGRPC.IncrementLimit
 		dataTransferChainIDsString = fmt.Sprintf("%v", requestGRPC.DataTransferChainIDs)
 	}
-	return StringArrayToArrayOfString(dataTransferChainIDsString), incrementLimit
+	return StringArrayToArrayOfString(dataTransferChainIDsString), busySpin
 }
 
 func isFirstFunctionInChain(requestGRPC *protogen2.InvokeChainRequest, requestHTTP *events.APIGatewayProxyRequest) bool {
@@ -152,15 +169,38 @@ func functionsLeftInChain(dataTransferChainIDs []string) bool {
 	return len(dataTransferChainIDs) > 0 && dataTransferChainIDs[0] != ""
 }
 
-func invokeNextFunction(requestHTTP *events.APIGatewayProxyRequest, updatedTimestampChain []string, dataTransferChainIDs []string, requestGRPC *protogen2.InvokeChainRequest) []string {
+//incomingTraceParent returns the trace context the function was invoked with, empty if none
+func incomingTraceParent(ctx context.Context, requestHTTP *events.APIGatewayProxyRequest) string {
+	if requestHTTP != nil {
+		for name, value := range requestHTTP.Headers {
+			if strings.EqualFold(name, traceParentHeader) {
+				return value
+			}
+		}
+		return ""
+	}
+
+	// gRPC
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(traceParentHeader); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func invokeNextFunction(requestHTTP *events.APIGatewayProxyRequest, updatedTimestampChain []string, dataTransferChainIDs []string, busySpin map[string]string, requestGRPC *protogen2.InvokeChainRequest, traceParent string) []string {
 	if requestHTTP != nil {
-		result := invokeNextFunctionAWS(map[string]string{
-			"IncrementLimit":       requestHTTP.QueryStringParameters["IncrementLimit"],
+		parameters := map[string]string{
 			"TimestampChain":       fmt.Sprintf("%v", updatedTimestampChain),
 			"TransferPayload":      requestHTTP.QueryStringParameters["TransferPayload"],
 			"DataTransferChainIDs": fmt.Sprintf("%v", dataTransferChainIDs[1:]),
-		},
+		}
+		for name, value := range busySpin {
+			parameters[name] = value
+		}
+		result := invokeNextFunctionAWS(parameters,
 			dataTransferChainIDs[0],
+			traceParent,
 		)
 
 		updatedTimestampChain = extractJSONTimestampChain(result)
@@ -169,19 +209,9 @@ func invokeNextFunction(requestHTTP *events.APIGatewayProxyRequest, updatedTimes
 			requestGRPC,
 			updatedTimestampChain,
 			dataTransferChainIDs,
+			traceParent,
 		)
 	}
 	return updatedTimestampChain
 }
 
-//simulateWork will keep the CPU busy-spinning
-func simulateWork(incrementLimitString string) {
-	incrementLimit, err := strconv.Atoi(incrementLimitString)
-	if err != nil {
-		log.Fatalf("Could not parse IncrementLimit parameter: %s", err.Error())
-	}
-
-	log.Infof("Running function up to increment limit (%d)...", incrementLimit)
-	for i := 0; i < incrementLimit; i++ {
-	}
-}
@@ -96,3 +96,11 @@ func TestStringArrayToArrayOfString(t *testing.T) {
 	require.Equal(t, StringArrayToArrayOfString("[35]"), []string{"35"})
 	require.Equal(t, StringArrayToArrayOfString("[14 35 8]"), []string{"14", "35", "8"})
 }
+
+func TestSimulateWork(t *testing.T) {
+	require.GreaterOrEqual(t, int64(simulateWork(map[string]string{"IncrementLimit": "0", "ServiceTimeMs": "20", "SpinClock": "wall"})),
+		int64(20*time.Millisecond))
+	require.GreaterOrEqual(t, int64(simulateWork(map[string]string{"IncrementLimit": "0", "ServiceTimeMs": "20", "SpinClock": "cpu"})),
+		int64(20*time.Millisecond))
+	require.Less(t, int64(simulateWork(map[string]string{"IncrementLimit": "1000"})), int64(time.Second))
+}
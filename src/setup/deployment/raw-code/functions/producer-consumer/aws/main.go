@@ -37,7 +37,7 @@ func main() {
 }
 
 func producerConsumer(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	httpOutput, _ := common2.GenerateResponse(ctx, &request, nil)
+	httpOutput, _, _ := common2.GenerateResponse(ctx, &request, nil)
 
 	return events.APIGatewayProxyResponse{
 		IsBase64Encoded: false,
@@ -36,7 +36,7 @@ func init() {
 // ProducerConsumer prints the JSON encoded "message" field in the body
 // of the request or "Hello, World!" if there isn't one.
 func ProducerConsumer(w http.ResponseWriter, r *http.Request) {
-	httpOutput, _ := GenerateResponse(nil, r, nil)
+	httpOutput, _, _ := GenerateResponse(nil, r, nil)
 
 	w.WriteHeader(http.StatusOK)
 	_, err := fmt.Fprint(w, html.EscapeString(string(httpOutput)))
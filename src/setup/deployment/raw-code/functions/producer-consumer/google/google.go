@@ -31,19 +31,32 @@ import (
 	"net/http"
 )
 
-func invokeNextFunctionGoogle(parameters map[string]string, functionID string) []byte {
+func invokeNextFunctionGoogle(parameters map[string]string, functionID string, traceParent string) []byte {
 	rawQuery := fmt.Sprintf("IncrementLimit=%s&TimestampChain=%v&TransferPayload=%v&DataTransferChainIDs=%v",
 		parameters["IncrementLimit"],
 		parameters["TimestampChain"],
 		parameters["TransferPayload"],
 		parameters["DataTransferChainIDs"],
 	)
+	for _, name := range []string{"ServiceTimeMs", "SpinClock"} {
+		if value, ok := parameters[name]; ok {
+			rawQuery += fmt.Sprintf("&%s=%s", name, value)
+		}
+	}
 
 	finalURL := fmt.Sprintf("%s?%s", functionID, rawQuery)
 
 	log.Printf("Invoking next function: %s", finalURL)
 
-	resp, err := http.Get(finalURL)
+	request, err := http.NewRequest(http.MethodGet, finalURL, nil)
+	if err != nil {
+		log.Fatalf("Error while creating http request: %v", err)
+	}
+	if traceParent != "" {
+		request.Header.Set(traceParentHeader, traceParent)
+	}
+
+	resp, err := http.DefaultClient.Do(request)
 	if err != nil {
 		log.Fatalf("Error while issuing http Post request: %v", err)
 	}
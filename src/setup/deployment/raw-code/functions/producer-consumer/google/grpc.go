@@ -29,10 +29,11 @@ import (
 	"github.com/minio/minio-go/v7/pkg/credentials"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 	"time"
 )
 
-func invokeNextFunctionGRPC(request *InvokeChainRequest, updatedTimestampChain []string, dataTransferChainIDs []string) []string {
+func invokeNextFunctionGRPC(request *InvokeChainRequest, updatedTimestampChain []string, dataTransferChainIDs []string, traceParent string) []string {
 	log.Printf("Invoking next function: %s", dataTransferChainIDs[0])
 	conn, err := grpc.Dial(dataTransferChainIDs[0], grpc.WithInsecure(), grpc.WithBlock())
 	if err != nil {
@@ -42,6 +43,9 @@ func invokeNextFunctionGRPC(request *InvokeChainRequest, updatedTimestampChain [
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
 	defer cancel()
+	if traceParent != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, traceParentHeader, traceParent)
+	}
 
 	client, err := NewProducerConsumerClient(conn).InvokeNext(ctx, &InvokeChainRequest{
 		IncrementLimit:       request.IncrementLimit,
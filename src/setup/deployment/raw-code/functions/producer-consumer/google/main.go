@@ -28,22 +28,30 @@ import (
 	"fmt"
 	"github.com/aws/aws-lambda-go/lambdacontext"
 	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/metadata"
 	"net/http"
 	"strconv"
+	"time"
 )
 
+//traceParentHeader carries the W3C trace context of the client, forwarded along the chain
+const traceParentHeader = "traceparent"
+
 //GlobalRandomPayload is a 1MB string used for quick random payload generation
 var GlobalRandomPayload string
 
 //ProducerConsumerResponse is the structure that we expect a consumer-producer function response to follow
 type ProducerConsumerResponse struct {
-	RequestID      string   `json:"RequestID"`
-	TimestampChain []string `json:"TimestampChain"`
+	RequestID      string       `json:"RequestID"`
+	TimestampChain []string     `json:"TimestampChain"`
+	ServerTiming   ServerTiming `json:"ServerTiming"`
 }
 
-//GenerateResponse creates the HTTP or gRPC producer-consumer response payload
-func GenerateResponse(ctx context.Context, requestHTTP *http.Request, requestGRPC *InvokeChainRequest) ([]byte, []string) {
-	dataTransferChainIDs, incrementLimit := extractChainIDsAndIncrementLimit(requestHTTP, requestGRPC)
+//GenerateResponse creates the HTTP or gRPC producer-consumer response payload, the timing envelope being part of the
+//HTTP payload and returned separately for gRPC
+func GenerateResponse(ctx context.Context, requestHTTP *http.Request, requestGRPC *InvokeChainRequest) ([]byte, []string, ServerTiming) {
+	handlerStarted := time.Now()
+	dataTransferChainIDs, busySpin := extractChainIDsAndBusySpin(requestHTTP, requestGRPC)
 
 	var updatedTimestampChain []string
 	if isFirstFunctionInChain(requestGRPC, requestHTTP) {
@@ -99,12 +107,13 @@ func GenerateResponse(ctx context.Context, requestHTTP *http.Request, requestGRP
 		}
 	}
 
-	simulateWork(incrementLimit)
+	spinDuration := simulateWork(busySpin)
 
 	if functionsLeftInChain(dataTransferChainIDs) {
 		log.Infof("There are %d functions left in the chain, invoking next one...", len(dataTransferChainIDs))
 
-		updatedTimestampChain = invokeNextFunction(requestHTTP, updatedTimestampChain, dataTransferChainIDs, requestGRPC)
+		traceParent := incomingTraceParent(ctx, requestHTTP)
+		updatedTimestampChain = invokeNextFunction(requestHTTP, updatedTimestampChain, dataTransferChainIDs, busySpin, requestGRPC, traceParent)
 	}
 
 	if requestHTTP != nil {
@@ -120,27 +129,34 @@ func GenerateResponse(ctx context.Context, requestHTTP *http.Request, requestGRP
 		httpOutput, err := json.Marshal(ProducerConsumerResponse{
 			RequestID:      reqId,
 			TimestampChain: updatedTimestampChain,
+			ServerTiming:   newServerTiming(handlerStarted, spinDuration),
 		})
 		if err != nil {
 			log.Fatalf("Could not marshal function output: %s", err)
 		}
-		return httpOutput, nil
+		return httpOutput, nil, ServerTiming{}
 	}
 
 	// gRPC
-	return nil, updatedTimestampChain
+	return nil, updatedTimestampChain, newServerTiming(handlerStarted, spinDuration)
 }
 
-func extractChainIDsAndIncrementLimit(requestHTTP *http.Request, requestGRPC *InvokeChainRequest) ([]string, string) {
-	var dataTransferChainIDsString, incrementLimit string
+//extractChainIDsAndBusySpin returns the remaining chain and the busy-spin parameters present in the request
+func extractChainIDsAndBusySpin(requestHTTP *http.Request, requestGRPC *InvokeChainRequest) ([]string, map[string]string) {
+	var dataTransferChainIDsString string
+	busySpin := make(map[string]string)
 	if requestHTTP != nil {
-		incrementLimit = requestHTTP.URL.Query().Get("IncrementLimit")
+		for _, name := range busySpinParameters {
+			if values, ok := requestHTTP.URL.Query()[name]; ok {
+				busySpin[name] = values[0]
+			}
+		}
 		dataTransferChainIDsString = requestHTTP.URL.Query().Get("DataTransferChainIDs")
 	} else {
-		incrementLimit = requestGRPC.IncrementLimit
+		busySpin["IncrementLimit"] = requestGRPC.IncrementLimit
 		dataTransferChainIDsString = fmt.Sprintf("%v", requestGRPC.DataTransferChainIDs)
 	}
-	return StringArrayToArrayOfString(dataTransferChainIDsString), incrementLimit
+	return StringArrayToArrayOfString(dataTransferChainIDsString), busySpin
 }
 
 func isFirstFunctionInChain(requestGRPC *InvokeChainRequest, requestHTTP *http.Request) bool {
@@ -158,15 +174,33 @@ func functionsLeftInChain(dataTransferChainIDs []string) bool {
 	return len(dataTransferChainIDs) > 0 && dataTransferChainIDs[0] != ""
 }
 
-func invokeNextFunction(requestHTTP *http.Request, updatedTimestampChain []string, dataTransferChainIDs []string, requestGRPC *InvokeChainRequest) []string {
+//incomingTraceParent returns the trace context the function was invoked with, empty if none
+func incomingTraceParent(ctx context.Context, requestHTTP *http.Request) string {
+	if requestHTTP != nil {
+		return requestHTTP.Header.Get(traceParentHeader)
+	}
+
+	// gRPC
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(traceParentHeader); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func invokeNextFunction(requestHTTP *http.Request, updatedTimestampChain []string, dataTransferChainIDs []string, busySpin map[string]string, requestGRPC *InvokeChainRequest, traceParent string) []string {
 	if requestHTTP != nil {
-		result := invokeNextFunctionGoogle(map[string]string{
-			"IncrementLimit":       requestHTTP.URL.Query().Get("IncrementLimit"),
+		parameters := map[string]string{
 			"TimestampChain":       fmt.Sprintf("%v", updatedTimestampChain),
 			"TransferPayload":      requestHTTP.URL.Query().Get("TransferPayload"),
 			"DataTransferChainIDs": fmt.Sprintf("%v", dataTransferChainIDs[1:]),
-		},
+		}
+		for name, value := range busySpin {
+			parameters[name] = value
+		}
+		result := invokeNextFunctionGoogle(parameters,
 			dataTransferChainIDs[0],
+			traceParent,
 		)
 
 		updatedTimestampChain = extractJSONTimestampChain(result)
@@ -175,19 +209,9 @@ func invokeNextFunction(requestHTTP *http.Request, updatedTimestampChain []strin
 			requestGRPC,
 			updatedTimestampChain,
 			dataTransferChainIDs,
+			traceParent,
 		)
 	}
 	return updatedTimestampChain
 }
 
-//simulateWork will keep the CPU busy-spinning
-func simulateWork(incrementLimitString string) {
-	incrementLimit, err := strconv.Atoi(incrementLimitString)
-	if err != nil {
-		log.Fatalf("Could not parse IncrementLimit parameter: %s", err.Error())
-	}
-
-	log.Infof("Running function up to increment limit (%d)...", incrementLimit)
-	for i := 0; i < incrementLimit; i++ {
-	}
-}
@@ -53,6 +53,7 @@ func InitializeGlobalRandomPayload() {
 		length *= 2
 		GlobalRandomPayload = GeneratePayloadFromGlobalRandom(length)
 	}
+	markInitialized()
 }
 
 //GeneratePayloadFromGlobalRandom creates a transfer payload for the producer-consumer chain
@@ -36,13 +36,18 @@ import (
 
 var sessionInstance *session.Session
 
-func invokeNextFunctionAWS(parameters map[string]string, functionID string) []byte {
+func invokeNextFunctionAWS(parameters map[string]string, functionID string, traceParent string) []byte {
 	const namingPrefix = "vHive-bench_"
 
 	type Payload struct {
 		QueryStringParameters map[string]string `json:"queryStringParameters"`
+		Headers               map[string]string `json:"headers,omitempty"`
 	}
-	nextFunctionPayload, err := json.Marshal(Payload{QueryStringParameters: parameters})
+	payload := Payload{QueryStringParameters: parameters}
+	if traceParent != "" {
+		payload.Headers = map[string]string{traceParentHeader: traceParent}
+	}
+	nextFunctionPayload, err := json.Marshal(payload)
 	if err != nil {
 		log.Fatalf("Could not marshal nextFunctionPayload: %s", err)
 	}
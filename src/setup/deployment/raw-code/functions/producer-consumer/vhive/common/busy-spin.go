@@ -0,0 +1,88 @@
+// MIT License
+//
+// Copyright (c) 2021 Theodor Amariucai and EASE Lab
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package common
+
+import (
+	log "github.com/sirupsen/logrus"
+	"runtime"
+	"strconv"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+//clockThreadCPUTimeID is CLOCK_THREAD_CPUTIME_ID, which the syscall package does not define
+const clockThreadCPUTimeID = 3
+
+//busySpinParameters are the request parameters telling the function how long to busy-spin for
+var busySpinParameters = []string{"IncrementLimit", "ServiceTimeMs", "SpinClock"}
+
+//simulateWork will keep the CPU busy-spinning until the desired service time has elapsed on the requested clock,
+//or up to the legacy increment limit, and returns the achieved duration
+func simulateWork(parameters map[string]string) time.Duration {
+	clock := wallClock
+	if parameters["SpinClock"] == "cpu" {
+		//The thread CPU time is only meaningful if the goroutine keeps spinning on the same thread
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+		clock = threadCPUClock
+	}
+	spinStarted := clock()
+
+	serviceTimeMs, ok := parameters["ServiceTimeMs"]
+	if !ok {
+		incrementLimit, err := strconv.Atoi(parameters["IncrementLimit"])
+		if err != nil {
+			log.Fatalf("Could not parse IncrementLimit parameter: %s", err.Error())
+		}
+		log.Infof("Running function up to increment limit (%d)...", incrementLimit)
+		for i := 0; i < incrementLimit; i++ {
+		}
+		return clock() - spinStarted
+	}
+
+	serviceTime, err := strconv.ParseFloat(serviceTimeMs, 64)
+	if err != nil {
+		log.Fatalf("Could not parse ServiceTimeMs parameter: %s", err.Error())
+	}
+	deadline := spinStarted + time.Duration(serviceTime*float64(time.Millisecond))
+	log.Infof("Running function for %v of %s time...", deadline-spinStarted, parameters["SpinClock"])
+	for clock() < deadline {
+	}
+	return clock() - spinStarted
+}
+
+func wallClock() time.Duration {
+	return time.Duration(time.Now().UnixNano())
+}
+
+//threadCPUClock returns the CPU time consumed by the calling thread
+func threadCPUClock() time.Duration {
+	var cpuTime syscall.Timespec
+	_, _, errno := syscall.RawSyscall(syscall.SYS_CLOCK_GETTIME, clockThreadCPUTimeID, uintptr(unsafe.Pointer(&cpuTime)), 0)
+	if errno != 0 {
+		log.Warnf("Could not read the thread CPU time: %s", errno.Error())
+		return wallClock()
+	}
+	return time.Duration(cpuTime.Nano())
+}
@@ -0,0 +1,94 @@
+// MIT License
+//
+// Copyright (c) 2021 Theodor Amariucai and EASE Lab
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package common
+
+import (
+	"io/ioutil"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+//ServerTiming is the standard server-side timing envelope of the benchmark functions
+type ServerTiming struct {
+	HandlerStartMs      float64 `json:"HandlerStartMs"`
+	HandlerEndMs        float64 `json:"HandlerEndMs"`
+	ExecutionDurationMs float64 `json:"ExecutionDurationMs"`
+	SpinDurationMs      float64 `json:"SpinDurationMs"`
+	InitDurationMs      float64 `json:"InitDurationMs"`
+	ColdStart           bool    `json:"ColdStart"`
+	Runtime             string  `json:"Runtime"`
+	MemoryLimitMB       int     `json:"MemoryLimitMB"`
+}
+
+var (
+	initStarted = time.Now()
+	//initDurationMs is the time spent in global initialization, i.e., until the random payload is ready
+	initDurationMs float64
+	initReported   int32
+	memoryLimitMB  = readMemoryLimitMB()
+)
+
+//markInitialized records the end of the global initialization of the instance
+func markInitialized() {
+	initDurationMs = float64(time.Since(initStarted).Microseconds()) / 1000
+}
+
+func readMemoryLimitMB() int {
+	for _, variable := range []string{"AWS_LAMBDA_FUNCTION_MEMORY_SIZE", "FUNCTION_MEMORY_MB"} {
+		if memorySize, err := strconv.Atoi(os.Getenv(variable)); err == nil {
+			return memorySize
+		}
+	}
+	limit, err := ioutil.ReadFile("/sys/fs/cgroup/memory.max")
+	if err != nil {
+		return 0
+	}
+	limitBytes, err := strconv.Atoi(strings.TrimSpace(string(limit)))
+	if err != nil {
+		return 0
+	}
+	return limitBytes / (1024 * 1024)
+}
+
+//newServerTiming reports the init duration to the first (cold) invocation of the instance only. The execution of
+//the first function of a chain includes the invocations of the next ones.
+func newServerTiming(handlerStarted time.Time, spinDuration time.Duration) ServerTiming {
+	coldStart := atomic.CompareAndSwapInt32(&initReported, 0, 1)
+	timing := ServerTiming{
+		HandlerStartMs:      float64(handlerStarted.UnixNano()) / 1e6,
+		HandlerEndMs:        float64(time.Now().UnixNano()) / 1e6,
+		ExecutionDurationMs: float64(time.Since(handlerStarted).Microseconds()) / 1000,
+		SpinDurationMs:      float64(spinDuration.Microseconds()) / 1000,
+		ColdStart:           coldStart,
+		Runtime:             runtime.Version(),
+		MemoryLimitMB:       memoryLimitMB,
+	}
+	if coldStart {
+		timing.InitDurationMs = initDurationMs
+	}
+	return timing
+}
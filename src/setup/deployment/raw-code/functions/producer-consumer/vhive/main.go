@@ -24,6 +24,7 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	common2 "github.com/vhive-serverless/stellar/src/setup/deployment/raw-code/functions/producer-consumer/common"
 	protogen2 "github.com/vhive-serverless/stellar/src/setup/deployment/raw-code/functions/producer-consumer/proto_gen"
@@ -61,9 +62,15 @@ func main() {
 }
 
 func (s *server) InvokeNext(ctx context.Context, request *protogen2.InvokeChainRequest) (*protogen2.InvokeChainReply, error) {
-	_, grpcOutput := common2.GenerateResponse(ctx, nil, request)
+	_, grpcOutput, serverTiming := common2.GenerateResponse(ctx, nil, request)
+
+	encodedServerTiming, err := json.Marshal(serverTiming)
+	if err != nil {
+		log.Fatalf("Could not marshal server timing: %s", err)
+	}
 
 	return &protogen2.InvokeChainReply{
 		TimestampChain: fmt.Sprintf("%v", grpcOutput),
+		ServerTiming:   string(encodedServerTiming),
 	}, nil
 }
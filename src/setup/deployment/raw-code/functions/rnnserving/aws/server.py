@@ -3,10 +3,14 @@ from __future__ import print_function
 import json
 import os
 import pickle
+import platform
 import rnn
 import string
+import time
 import torch
 
+init_started = time.time()
+
 os.environ["CUDA_VISIBLE_DEVICES"] = ""
 device = torch.device("cpu")
 torch.set_num_threads(1)
@@ -31,8 +35,44 @@ rnn_model = rnn.RNN(n_letters, 128, n_letters, all_categories, n_categories, all
 rnn_model.load_state_dict(torch.load('/rnn_model.pth'))
 rnn_model.eval()
 
+init_duration_ms = (time.time() - init_started) * 1000
+
+cold_start = True
+
+
+def read_memory_limit_mb():
+    for variable in ('AWS_LAMBDA_FUNCTION_MEMORY_SIZE', 'FUNCTION_MEMORY_MB'):
+        if variable in os.environ:
+            return int(os.environ[variable])
+    try:
+        with open('/sys/fs/cgroup/memory.max') as limit_file:
+            return int(limit_file.read()) // (1024 * 1024)
+    except (OSError, ValueError):
+        return 0
+
+
+MEMORY_LIMIT_MB = read_memory_limit_mb()
+
+
+def server_timing(handler_started, execution_started, spin_duration_ms):
+    # Only the first invocation of an instance is a cold start and reports the init duration
+    global cold_start
+    was_cold, cold_start = cold_start, False
+    return {
+        "HandlerStartMs": handler_started * 1000,
+        "HandlerEndMs": time.time() * 1000,
+        "ExecutionDurationMs": (time.perf_counter() - execution_started) * 1000,
+        "SpinDurationMs": spin_duration_ms,
+        "InitDurationMs": init_duration_ms if was_cold else 0,
+        "ColdStart": was_cold,
+        "Runtime": "python" + platform.python_version(),
+        "MemoryLimitMB": MEMORY_LIMIT_MB,
+    }
+
 
 def handler(_, context):
+    handler_started = time.time()
+    execution_started = time.perf_counter()
     output_names = list(rnn_model.samples(language, start_letters))
 
     json_region = os.environ['AWS_REGION']
@@ -45,5 +85,6 @@ def handler(_, context):
             "Region ": json_region,
             "RequestID": context.aws_request_id,
             "TimestampChain": '[0]',
+            "ServerTiming": server_timing(handler_started, execution_started, 0),
         })
     }
@@ -1,32 +1,100 @@
 import json
+import os
+import platform
 import time
 
+MEBIBYTE = 1024 * 1024
+PAGE_SIZE = 4096
+
+
+def simulate_init(init_started, init_time_ms, init_memory_mb):
+    # The memory stays referenced for the lifetime of the instance, like imported libraries or loaded models
+    memory = bytearray(init_memory_mb * MEBIBYTE)
+    for offset in range(0, len(memory), PAGE_SIZE):
+        memory[offset] = 1
+    while (time.time() - init_started) * 1000 < init_time_ms:
+        pass
+    return memory
+
+
+init_started = time.time()
+init_memory = simulate_init(init_started, int(os.environ.get('INIT_TIME_MS', 0)), int(os.environ.get('INIT_MEMORY_MB', 0)))
+init_duration_ms = (time.time() - init_started) * 1000
+
+
+cold_start = True
+
+
+def read_memory_limit_mb():
+    if 'AWS_LAMBDA_FUNCTION_MEMORY_SIZE' in os.environ:
+        return int(os.environ['AWS_LAMBDA_FUNCTION_MEMORY_SIZE'])
+    try:
+        with open('/sys/fs/cgroup/memory.max') as limit_file:
+            return int(limit_file.read()) // MEBIBYTE
+    except (OSError, ValueError):
+        return 0
+
+
+MEMORY_LIMIT_MB = read_memory_limit_mb()
+
+
+def server_timing(handler_started, execution_started, spin_duration_ms):
+    # Only the first invocation of an instance is a cold start and reports the init duration
+    global cold_start
+    was_cold, cold_start = cold_start, False
+    return {
+        "HandlerStartMs": handler_started * 1000,
+        "HandlerEndMs": time.time() * 1000,
+        "ExecutionDurationMs": (time.perf_counter() - execution_started) * 1000,
+        "SpinDurationMs": spin_duration_ms,
+        "InitDurationMs": init_duration_ms if was_cold else 0,
+        "ColdStart": was_cold,
+        "Runtime": "python" + platform.python_version(),
+        "MemoryLimitMB": MEMORY_LIMIT_MB,
+    }
+
+
+def simulate_work(parameters):
+    # Busy-spin until the desired service time has elapsed on the requested clock, or up to the legacy increment limit
+    if 'ServiceTimeMs' in parameters:
+        clock = time.thread_time if parameters.get('SpinClock') == 'cpu' else time.perf_counter
+        spin_started = clock()
+        deadline = spin_started + float(parameters['ServiceTimeMs']) / 1000
+        while clock() < deadline:
+            pass
+    else:
+        clock = time.perf_counter
+        spin_started = clock()
+        increment_limit = int(parameters.get('IncrementLimit') or 0)
+        num = 0
+        while num < increment_limit:
+            num += 1
+    return (clock() - spin_started) * 1000
+
+
+def generate_body(request_id, parameters):
+    handler_started = time.time()
+    execution_started = time.perf_counter()
+    payload_length_bytes = int(parameters.get('PayloadLengthBytes') or 0)
+
+    spin_duration_ms = simulate_work(parameters)
+
+    return {
+        "RequestID": request_id,
+        "TimestampChain": [str(time.time_ns() // 1_000_000)],
+        "TransferPayload": parameters.get('TransferPayload') or 'A' * payload_length_bytes,
+        "ServerTiming": server_timing(handler_started, execution_started, spin_duration_ms),
+    }
+
 
 def main(event, context):
     event = json.loads(event)
+    parameters = event.get('queryParameters') or {}
 
-    increment_limit = 0
-    if "IncrementLimit" in event["queryParameters"]:
-        increment_limit = int(event["queryParameters"]["IncrementLimit"])
-    if "IncrementLimit" in event["body"]:
-        increment_limit = int(event["body"]["IncrementLimit"])
-    simulate_work(increment_limit)
-
-    response_body = {
-        "Region": context.region,
-        "RequestID": context.request_id,
-        "TimestampChain": [str(time.time_ns())],
-    }
     response = {
         "isBase64Encoded": "false",
         "statusCode": "200",
-        "headers": {"x-custom-header": "no", "Content-Type": "application/json"},
-        "body": response_body,
+        "headers": {"Content-Type": "application/json"},
+        "body": generate_body(context.request_id, parameters),
     }
     return json.dumps(response)
-
-
-def simulate_work(increment_limit: int) -> None:
-    num = 0
-    while num < increment_limit:
-        num += 1
@@ -1,60 +1,138 @@
 package org.hellojava;
 
-import java.util.Map;
+import java.lang.management.ManagementFactory;
+import java.lang.management.ThreadMXBean;
 import java.util.HashMap;
-import java.time.Instant;
+import java.util.LinkedHashMap;
+import java.nio.file.Files;
+import java.nio.file.Paths;
+import java.util.Map;
+import java.util.concurrent.atomic.AtomicBoolean;
 import com.amazonaws.services.lambda.runtime.Context;
 import com.amazonaws.services.lambda.runtime.RequestHandler;
 import com.amazonaws.services.lambda.runtime.events.APIGatewayProxyRequestEvent;
 import com.amazonaws.services.lambda.runtime.events.APIGatewayProxyResponseEvent;
 import com.google.gson.Gson;
 
-class ResponseEventBody {
-    String region;
-    String requestId;
-    String[] timestampChain;
-
-    public ResponseEventBody(String region, String requestId, String[] timestampChain) {
-        this.region = region;
-        this.requestId = requestId;
-        this.timestampChain = timestampChain;
-    }
-}
+public class Handler implements RequestHandler<APIGatewayProxyRequestEvent, APIGatewayProxyResponseEvent> {
 
-public class Handler implements RequestHandler<APIGatewayProxyRequestEvent, APIGatewayProxyResponseEvent>{
+    private static final long INIT_STARTED = System.nanoTime();
+    // The memory stays referenced for the lifetime of the instance, like imported libraries or loaded models
+    private static final byte[] INIT_MEMORY = simulateInit(
+            parseIntParameter(System.getenv(), "INIT_TIME_MS"), parseIntParameter(System.getenv(), "INIT_MEMORY_MB"));
+    private static final double INIT_DURATION_MS = (System.nanoTime() - INIT_STARTED) / 1e6;
+    private static final AtomicBoolean INIT_REPORTED = new AtomicBoolean(false);
+    private static final int MEMORY_LIMIT_MB = readMemoryLimitMB();
 
     @Override
-    public APIGatewayProxyResponseEvent handleRequest(APIGatewayProxyRequestEvent event, Context context)
-    {
-        Gson gson = new Gson();
-	int incrementLimit = 0;
-	if (event.getQueryStringParameters() != null) {
-        	incrementLimit = Integer.parseInt(event.getQueryStringParameters().getOrDefault("incrementLimit", "0"));
-	}
-        this.simulateWork(incrementLimit);
+    public APIGatewayProxyResponseEvent handleRequest(APIGatewayProxyRequestEvent event, Context context) {
+        Map<String, String> parameters = new HashMap<>();
+        if (event.getQueryStringParameters() != null) {
+            parameters.putAll(event.getQueryStringParameters());
+        }
+
         String requestId = "no-context";
         if (context != null) {
             requestId = context.getAwsRequestId();
         }
 
-        Instant now = Instant.now();
-        String[] timestampChain = new String[]{""+now.getEpochSecond()+now.getNano()};
-        ResponseEventBody resBody = new ResponseEventBody(System.getenv("AWS_REGION"), requestId, timestampChain);
-
-	Map<String, String> responseHeaders = new HashMap<>();
-	responseHeaders.put("Content-Type", "application/json");
+        Map<String, String> responseHeaders = new HashMap<>();
+        responseHeaders.put("Content-Type", "application/json");
         APIGatewayProxyResponseEvent response = new APIGatewayProxyResponseEvent().withHeaders(responseHeaders);
         response.setIsBase64Encoded(false);
         response.setStatusCode(200);
-        response.setBody(gson.toJson(resBody));
+        response.setBody(new Gson().toJson(generateBody(requestId, parameters)));
 
         return response;
     }
 
-    public void simulateWork(int incrementLimit) {
-        int i = 0;
-        while (i < incrementLimit) {
-            i++;
+    public static Map<String, Object> generateBody(String requestId, Map<String, String> parameters) {
+        long handlerStartMs = System.currentTimeMillis();
+        long executionStarted = System.nanoTime();
+        int payloadLengthBytes = parseIntParameter(parameters, "PayloadLengthBytes");
+
+        double spinDurationMs = simulateWork(parameters);
+
+        String payload = parameters.getOrDefault("TransferPayload", "");
+        if (payload.isEmpty()) {
+            payload = "A".repeat(payloadLengthBytes);
+        }
+
+        Map<String, Object> body = new LinkedHashMap<>();
+        body.put("RequestID", requestId);
+        body.put("TimestampChain", new String[]{Long.toString(System.currentTimeMillis())});
+        body.put("TransferPayload", payload);
+        body.put("ServerTiming", serverTiming(handlerStartMs, executionStarted, spinDurationMs));
+        return body;
+    }
+
+    // Only the first invocation of an instance is a cold start and reports the init duration
+    public static Map<String, Object> serverTiming(long handlerStartMs, long executionStarted, double spinDurationMs) {
+        boolean coldStart = !INIT_REPORTED.getAndSet(true);
+
+        Map<String, Object> timing = new LinkedHashMap<>();
+        timing.put("HandlerStartMs", handlerStartMs);
+        timing.put("HandlerEndMs", System.currentTimeMillis());
+        timing.put("ExecutionDurationMs", (System.nanoTime() - executionStarted) / 1e6);
+        timing.put("SpinDurationMs", spinDurationMs);
+        timing.put("InitDurationMs", coldStart ? INIT_DURATION_MS : 0);
+        timing.put("ColdStart", coldStart);
+        timing.put("Runtime", "java" + System.getProperty("java.version"));
+        timing.put("MemoryLimitMB", MEMORY_LIMIT_MB);
+        return timing;
+    }
+
+    public static int readMemoryLimitMB() {
+        String memorySize = System.getenv("AWS_LAMBDA_FUNCTION_MEMORY_SIZE");
+        if (memorySize != null) {
+            return Integer.parseInt(memorySize);
+        }
+        try {
+            return (int) (Long.parseLong(Files.readString(Paths.get("/sys/fs/cgroup/memory.max")).trim()) / (1024 * 1024));
+        } catch (Exception e) {
+            return 0;
+        }
+    }
+
+    public static int parseIntParameter(Map<String, String> parameters, String name) {
+        try {
+            return Integer.parseInt(parameters.getOrDefault(name, "0"));
+        } catch (NumberFormatException e) {
+            return 0;
+        }
+    }
+
+    public static byte[] simulateInit(int initTimeMs, int initMemoryMB) {
+        byte[] memory = new byte[initMemoryMB * 1024 * 1024];
+        for (int offset = 0; offset < memory.length; offset += 4096) {
+            memory[offset] = 1;
+        }
+        while ((System.nanoTime() - INIT_STARTED) / 1_000_000 < initTimeMs) {
+            Thread.onSpinWait();
+        }
+        return memory;
+    }
+
+    // Busy-spins until the desired service time has elapsed on the requested clock, or up to the legacy increment limit
+    public static double simulateWork(Map<String, String> parameters) {
+        ThreadMXBean threads = ManagementFactory.getThreadMXBean();
+        boolean cpuClock = "cpu".equals(parameters.get("SpinClock")) && threads.isCurrentThreadCpuTimeSupported();
+        long spinStarted = cpuClock ? threads.getCurrentThreadCpuTime() : System.nanoTime();
+        long spinEnded;
+
+        if (parameters.containsKey("ServiceTimeMs")) {
+            long deadline = spinStarted + (long) (Double.parseDouble(parameters.get("ServiceTimeMs")) * 1e6);
+            do {
+                Thread.onSpinWait();
+                spinEnded = cpuClock ? threads.getCurrentThreadCpuTime() : System.nanoTime();
+            } while (spinEnded < deadline);
+        } else {
+            int incrementLimit = parseIntParameter(parameters, "IncrementLimit");
+            for (int i = 0; i < incrementLimit; i++) {
+                Thread.onSpinWait(); // Prevent JVM/JIT optimizations from skipping the loop
+            }
+            spinEnded = System.nanoTime();
         }
+        return (spinEnded - spinStarted) / 1e6;
     }
 }
@@ -1,18 +1,53 @@
 import json
 import os
+import platform
 import time
 import random
 
+init_started = time.time()
+init_duration_ms = (time.time() - init_started) * 1000
 
-def lambda_handler(request, context):
-    incr_limit = 0
+cold_start = True
+
+
+def read_memory_limit_mb():
+    for variable in ('AWS_LAMBDA_FUNCTION_MEMORY_SIZE', 'FUNCTION_MEMORY_MB'):
+        if variable in os.environ:
+            return int(os.environ[variable])
+    try:
+        with open('/sys/fs/cgroup/memory.max') as limit_file:
+            return int(limit_file.read()) // (1024 * 1024)
+    except (OSError, ValueError):
+        return 0
+
+
+MEMORY_LIMIT_MB = read_memory_limit_mb()
 
-    if 'queryStringParameters' in request and 'IncrementLimit' in request['queryStringParameters']:
-        incr_limit = int(request['queryStringParameters'].get('IncrementLimit', 0))
-    elif 'body' in request and json.loads(request['body'])['IncrementLimit']:
-        incr_limit = int(json.loads(request['body'])['IncrementLimit'])
 
-    simulate_work(incr_limit)
+def server_timing(handler_started, execution_started, spin_duration_ms):
+    # Only the first invocation of an instance is a cold start and reports the init duration
+    global cold_start
+    was_cold, cold_start = cold_start, False
+    return {
+        "HandlerStartMs": handler_started * 1000,
+        "HandlerEndMs": time.time() * 1000,
+        "ExecutionDurationMs": (time.perf_counter() - execution_started) * 1000,
+        "SpinDurationMs": spin_duration_ms,
+        "InitDurationMs": init_duration_ms if was_cold else 0,
+        "ColdStart": was_cold,
+        "Runtime": "python" + platform.python_version(),
+        "MemoryLimitMB": MEMORY_LIMIT_MB,
+    }
+
+
+def lambda_handler(request, context):
+    handler_started = time.time()
+    execution_started = time.perf_counter()
+    parameters = request.get('queryStringParameters') or {}
+    if 'IncrementLimit' not in parameters and request.get('body'):
+        parameters = json.loads(request['body'])
+
+    spin_duration_ms = simulate_work(parameters)
     read_filler_file('./filler.file')
 
     json_region = os.environ.get('AWS_REGION', 'Unknown')
@@ -25,18 +60,30 @@ def lambda_handler(request, context):
         "body": json.dumps({
             "Region ": json_region,
             "RequestID": context.aws_request_id,
-            "TimestampChain": [str(time.time_ns())]
+            "TimestampChain": [str(time.time_ns())],
+            "ServerTiming": server_timing(handler_started, execution_started, spin_duration_ms),
         }, indent=4)
     }
 
     return response
 
 
-def simulate_work(increment):
-    # MAXNUM = 6103705
-    num = 0
-    while num < increment:
-        num += 1
+def simulate_work(parameters):
+    # Busy-spin until the desired service time has elapsed on the requested clock, or up to the legacy increment limit
+    if 'ServiceTimeMs' in parameters:
+        clock = time.thread_time if parameters.get('SpinClock') == 'cpu' else time.perf_counter
+        spin_started = clock()
+        deadline = spin_started + float(parameters['ServiceTimeMs']) / 1000
+        while clock() < deadline:
+            pass
+    else:
+        clock = time.perf_counter
+        spin_started = clock()
+        increment_limit = int(parameters.get('IncrementLimit') or 0)
+        num = 0
+        while num < increment_limit:
+            num += 1
+    return (clock() - spin_started) * 1000
 
 
 def read_filler_file(path: str) -> None:
@@ -1,21 +1,85 @@
 require 'json'
 
-def handler(event:, context:)
-  incrementLimit = 0
-  if event['queryStringParameters'] != nil
-    if event['queryStringParameters']['IncrementLimit'] != nil
-      incrementLimit = event['queryStringParameters']['IncrementLimit'].to_i
-    end
+INIT_STARTED = Process.clock_gettime(Process::CLOCK_MONOTONIC)
+
+# The memory stays referenced for the lifetime of the instance, like imported libraries or loaded models
+def simulate_init(init_time_ms, init_memory_mb)
+  memory = "\0" * (init_memory_mb * 1024 * 1024)
+  while (Process.clock_gettime(Process::CLOCK_MONOTONIC) - INIT_STARTED) * 1000 < init_time_ms
   end
+  memory
+end
+
+INIT_MEMORY = simulate_init(ENV.fetch('INIT_TIME_MS', '0').to_i, ENV.fetch('INIT_MEMORY_MB', '0').to_i)
+$init_duration_ms = (Process.clock_gettime(Process::CLOCK_MONOTONIC) - INIT_STARTED) * 1000
 
-  simulateWork(incrementLimit)
+$cold_start = true
 
-  { RequestID: context.aws_request_id, TimestampChain: [ DateTime.now.strftime('%Q') ] }
+def read_memory_limit_mb
+  return ENV['AWS_LAMBDA_FUNCTION_MEMORY_SIZE'].to_i if ENV.key?('AWS_LAMBDA_FUNCTION_MEMORY_SIZE')
+
+  Integer(File.read('/sys/fs/cgroup/memory.max').strip) / (1024 * 1024)
+rescue StandardError
+  0
 end
 
-def simulateWork(incrementLimit)
-  i = 0
-  while i < incrementLimit
-    i += 1
+MEMORY_LIMIT_MB = read_memory_limit_mb
+
+# Only the first invocation of an instance is a cold start and reports the init duration
+def server_timing(handler_start_ms, execution_started, spin_duration_ms)
+  was_cold = $cold_start
+  $cold_start = false
+  {
+    HandlerStartMs: handler_start_ms,
+    HandlerEndMs: Time.now.to_f * 1000,
+    ExecutionDurationMs: (Process.clock_gettime(Process::CLOCK_MONOTONIC) - execution_started) * 1000,
+    SpinDurationMs: spin_duration_ms,
+    InitDurationMs: was_cold ? $init_duration_ms : 0,
+    ColdStart: was_cold,
+    Runtime: "ruby#{RUBY_VERSION}",
+    MemoryLimitMB: MEMORY_LIMIT_MB
+  }
+end
+
+# Busy-spins until the desired service time has elapsed on the requested clock, or up to the legacy increment limit
+def simulate_work(parameters)
+  clock = parameters['SpinClock'] == 'cpu' ? Process::CLOCK_THREAD_CPUTIME_ID : Process::CLOCK_MONOTONIC
+  spin_started = Process.clock_gettime(clock)
+  if parameters.key?('ServiceTimeMs')
+    deadline = spin_started + parameters['ServiceTimeMs'].to_f / 1000
+    while Process.clock_gettime(clock) < deadline
+    end
+  else
+    increment_limit = parameters.fetch('IncrementLimit', 0).to_i
+    i = 0
+    while i < increment_limit
+      i += 1
+    end
   end
+  (Process.clock_gettime(clock) - spin_started) * 1000
+end
+
+def generate_body(request_id, parameters)
+  handler_start_ms = Time.now.to_f * 1000
+  execution_started = Process.clock_gettime(Process::CLOCK_MONOTONIC)
+  payload_length_bytes = parameters.fetch('PayloadLengthBytes', 0).to_i
+
+  spin_duration_ms = simulate_work(parameters)
+
+  {
+    RequestID: request_id,
+    TimestampChain: [(Time.now.to_r * 1000).to_i.to_s],
+    TransferPayload: parameters['TransferPayload'] || 'A' * payload_length_bytes,
+    ServerTiming: server_timing(handler_start_ms, execution_started, spin_duration_ms)
+  }
+end
+
+def handler(event:, context:)
+  parameters = event['queryStringParameters'] || {}
+
+  {
+    statusCode: 200,
+    headers: { 'Content-Type' => 'application/json' },
+    body: JSON.generate(generate_body(context.aws_request_id, parameters))
+  }
 end
@@ -1,23 +1,93 @@
-async function handler(context, request) {
-  let q = request.query;
-  let incrementLimit = 0;
-  if (q.incrementLimit) {
-    incrementLimit = parseInt(q.incrementLimit);
+const fs = require('fs');
+
+const initStarted = Date.now();
+
+// The memory stays referenced for the lifetime of the instance, like imported libraries or loaded models
+const simulateInit = (initTimeMs, initMemoryMB) => {
+  const memory = Buffer.alloc(initMemoryMB * 1024 * 1024);
+  while (Date.now() - initStarted < initTimeMs) { }
+  return memory;
+};
+
+const initMemory = simulateInit(parseInt(process.env.INIT_TIME_MS || "0"), parseInt(process.env.INIT_MEMORY_MB || "0"));
+const initDurationMs = Date.now() - initStarted;
+
+let coldStart = true;
+
+const readMemoryLimitMB = () => {
+  if (process.env.AWS_LAMBDA_FUNCTION_MEMORY_SIZE) {
+    return parseInt(process.env.AWS_LAMBDA_FUNCTION_MEMORY_SIZE);
   }
+  try {
+    return Math.floor(parseInt(fs.readFileSync('/sys/fs/cgroup/memory.max', 'utf8')) / (1024 * 1024)) || 0;
+  } catch (e) {
+    return 0;
+  }
+};
 
-  simulateWork(incrementLimit);
+const memoryLimitMB = readMemoryLimitMB();
 
-  context.res = {
-    status: 200,
-    body: {
-      RequestID: context.invocationId,
-      TimestampChain: [Date.now().toString()],
-    }
+// Only the first invocation of an instance is a cold start and reports the init duration
+const serverTiming = (handlerStartMs, executionStarted, spinDurationMs) => {
+  const wasCold = coldStart;
+  coldStart = false;
+  return {
+    HandlerStartMs: handlerStartMs,
+    HandlerEndMs: Date.now(),
+    ExecutionDurationMs: Number(process.hrtime.bigint() - executionStarted) / 1e6,
+    SpinDurationMs: spinDurationMs,
+    InitDurationMs: wasCold ? initDurationMs : 0,
+    ColdStart: wasCold,
+    Runtime: "node" + process.version,
+    MemoryLimitMB: memoryLimitMB,
   };
 };
 
-const simulateWork = (incrementLimit) => {
-  for (let i = 0; i < incrementLimit; i++) { }
+const wallClockMs = () => Number(process.hrtime.bigint()) / 1e6;
+
+// process.cpuUsage() covers the whole process, which only runs the handler's single JavaScript thread
+const cpuClockMs = () => {
+  const usage = process.cpuUsage();
+  return (usage.user + usage.system) / 1e3;
+};
+
+// Busy-spins until the desired service time has elapsed on the requested clock, or up to the legacy increment limit
+const simulateWork = (parameters) => {
+  const clock = parameters.SpinClock === "cpu" ? cpuClockMs : wallClockMs;
+  const spinStarted = clock();
+  if (parameters.ServiceTimeMs !== undefined) {
+    const deadline = spinStarted + parseFloat(parameters.ServiceTimeMs);
+    while (clock() < deadline) { }
+  } else {
+    const incrementLimit = parseInt(parameters.IncrementLimit || "0");
+    for (let i = 0; i < incrementLimit; i++) { }
+  }
+  return clock() - spinStarted;
+};
+
+const generateBody = (requestId, parameters) => {
+  const handlerStartMs = Date.now();
+  const executionStarted = process.hrtime.bigint();
+  const payloadLengthBytes = parseInt(parameters.PayloadLengthBytes || "0");
+
+  const spinDurationMs = simulateWork(parameters);
+
+  return {
+    RequestID: requestId,
+    TimestampChain: [Date.now().toString()],
+    TransferPayload: parameters.TransferPayload || "A".repeat(payloadLengthBytes),
+    ServerTiming: serverTiming(handlerStartMs, executionStarted, spinDurationMs),
+  };
+};
+
+async function handler(context, request) {
+  const parameters = request.query || {};
+
+  context.res = {
+    status: 200,
+    headers: { "Content-Type": "application/json" },
+    body: generateBody(context.invocationId, parameters),
+  };
 };
 
-module.exports = handler
+module.exports = handler;
@@ -1,16 +1,37 @@
+// Workers cap every isolate at 128MB and do not report how long the isolate took to start
+const memoryLimitMB = 128;
+
+let coldStart = true;
+
+// Only the first invocation of an isolate is a cold start
+function serverTiming(handlerStartMs, spinDurationMs) {
+	const wasCold = coldStart;
+	coldStart = false;
+	const handlerEndMs = Date.now();
+	return {
+		"HandlerStartMs": handlerStartMs,
+		"HandlerEndMs": handlerEndMs,
+		"ExecutionDurationMs": handlerEndMs - handlerStartMs,
+		"SpinDurationMs": spinDurationMs,
+		"InitDurationMs": 0,
+		"ColdStart": wasCold,
+		"Runtime": "workerd",
+		"MemoryLimitMB": memoryLimitMB,
+	};
+}
+
 export default {
 	async fetch(request) {
-		var incrLimit = 0
-
-		if (request.url.includes("incrementLimit")) {
-			incrLimit = parseInt(request.url.split("=")[1])
-		}
+		const handlerStartMs = Date.now();
+		// Workers freeze their clocks while a request executes, so the functions can only spin up to an increment limit
+		const incrLimit = parseInt(new URL(request.url).searchParams.get("IncrementLimit") || "0")
 
-		simulateWork(incrLimit)
+		const spinDurationMs = simulateWork(incrLimit)
 
 		const resData = {
 			"RequestID": "cloudflare-does-not-specify",
 			"TimestampChain": [Date.now().toString()],
+			"ServerTiming": serverTiming(handlerStartMs, spinDurationMs),
 		};
 
 		const body = JSON.stringify(resData, null, 2);
@@ -24,9 +45,11 @@ export default {
 };
 
 function simulateWork(incr) {
+	const spinStarted = Date.now();
 	let i = 0;
 	while (i < incr) {
 		i++;
 	}
+	return Date.now() - spinStarted;
 }
 
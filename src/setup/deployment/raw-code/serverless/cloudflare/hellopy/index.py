@@ -1,17 +1,40 @@
 from datetime import datetime
-def handleRequest(request):
-    incr_limit = 0
 
-    if 'queryStringParameters' in request and 'IncrementLimit' in request['queryStringParameters']:
-        incr_limit = int(request['queryStringParameters'].get('IncrementLimit', 0))
-    elif 'body' in request and JSON.parse(request['body'])['IncrementLimit']:
-        incr_limit = int(JSON.parse(request['body'])['IncrementLimit'])
+# Workers cap every isolate at 128MB and do not report how long the isolate took to start
+MEMORY_LIMIT_MB = 128
+
+cold_start = True
+
+
+def server_timing(handler_start_ms, spin_duration_ms):
+    # Only the first invocation of an isolate is a cold start
+    global cold_start
+    was_cold = cold_start
+    cold_start = False
+    handler_end_ms = Date.now()
+    return {
+        "HandlerStartMs": handler_start_ms,
+        "HandlerEndMs": handler_end_ms,
+        "ExecutionDurationMs": handler_end_ms - handler_start_ms,
+        "SpinDurationMs": spin_duration_ms,
+        "InitDurationMs": 0,
+        "ColdStart": was_cold,
+        "Runtime": "workerd-transcrypt",
+        "MemoryLimitMB": MEMORY_LIMIT_MB,
+    }
+
+
+def handleRequest(request):
+    handler_start_ms = Date.now()
+    # Workers freeze their clocks while a request executes, so the functions can only spin up to an increment limit
+    incr_limit = int(__new__(URL(request.url)).searchParams.js_get('IncrementLimit') or 0)
 
-    simulate_work(incr_limit)
+    spin_duration_ms = simulate_work(incr_limit)
 
     response = JSON.stringify({
         "RequestID": "cloudflare-does-not-specify",
-        "TimestampChain": [str(datetime.now())]
+        "TimestampChain": [str(datetime.now())],
+        "ServerTiming": server_timing(handler_start_ms, spin_duration_ms)
     })
 
 
@@ -21,8 +44,10 @@ def handleRequest(request):
 
 def simulate_work(increment):
     # MAXNUM = 6103705
+    spin_started = Date.now()
     num = 0
     while num < increment:
         num += 1
+    return Date.now() - spin_started
 
 addEventListener('fetch', (lambda event: event.respondWith(handleRequest(event.request))))
@@ -6,54 +6,186 @@ import (
 	log "github.com/sirupsen/logrus"
 	"net/http"
 	"os"
+	"runtime"
 	"strconv"
+	"strings"
+	"sync/atomic"
+	"syscall"
 	"time"
+	"unsafe"
 )
 
-type HelloGoResponse struct {
-	RequestID      string   `json:"RequestID"`
-	TimestampChain []string `json:"TimestampChain"`
+// clockThreadCPUTimeID is CLOCK_THREAD_CPUTIME_ID, which the syscall package does not define
+const clockThreadCPUTimeID = 3
+
+type Response struct {
+	RequestID       string       `json:"RequestID"`
+	TimestampChain  []string     `json:"TimestampChain"`
+	TransferPayload string       `json:"TransferPayload"`
+	ServerTiming    ServerTiming `json:"ServerTiming"`
 }
 
-func handler(w http.ResponseWriter, r *http.Request) {
-	incrementLimit, err := extractIncrementLimit(r)
+// ServerTiming is the standard server-side timing envelope of the benchmark functions
+type ServerTiming struct {
+	HandlerStartMs      float64 `json:"HandlerStartMs"`
+	HandlerEndMs        float64 `json:"HandlerEndMs"`
+	ExecutionDurationMs float64 `json:"ExecutionDurationMs"`
+	SpinDurationMs      float64 `json:"SpinDurationMs"`
+	InitDurationMs      float64 `json:"InitDurationMs"`
+	ColdStart           bool    `json:"ColdStart"`
+	Runtime             string  `json:"Runtime"`
+	MemoryLimitMB       int     `json:"MemoryLimitMB"`
+}
+
+var (
+	initStarted = time.Now()
+	// initMemory stays referenced for the lifetime of the instance, like imported libraries or loaded models
+	initMemory     []byte
+	initDurationMs float64
+	initReported   int32
+	memoryLimitMB  = readMemoryLimitMB()
+)
+
+func init() {
+	initTimeMs, _ := strconv.Atoi(os.Getenv("INIT_TIME_MS"))
+	initMemoryMB, _ := strconv.Atoi(os.Getenv("INIT_MEMORY_MB"))
+	initMemory = simulateInit(initTimeMs, initMemoryMB)
+	initDurationMs = float64(time.Since(initStarted).Microseconds()) / 1000
+}
+
+func simulateInit(initTimeMs int, initMemoryMB int) []byte {
+	memory := make([]byte, initMemoryMB*1024*1024)
+	for offset := 0; offset < len(memory); offset += os.Getpagesize() {
+		memory[offset] = 1
+	}
+	for time.Since(initStarted) < time.Duration(initTimeMs)*time.Millisecond {
+	}
+	return memory
+}
+
+func readMemoryLimitMB() int {
+	if memorySize, err := strconv.Atoi(os.Getenv("AWS_LAMBDA_FUNCTION_MEMORY_SIZE")); err == nil {
+		return memorySize
+	}
+	limit, err := os.ReadFile("/sys/fs/cgroup/memory.max")
+	if err != nil {
+		return 0
+	}
+	limitBytes, err := strconv.Atoi(strings.TrimSpace(string(limit)))
 	if err != nil {
-		log.Errorf("Error extracting IncrementLimit: %s", err)
-		http.Error(w, "Error extracting IncrementLimit", http.StatusBadRequest)
-		return
+		return 0
+	}
+	return limitBytes / (1024 * 1024)
+}
+
+// newServerTiming reports the init duration to the first (cold) invocation of the instance only
+func newServerTiming(handlerStarted time.Time, spinDuration time.Duration) ServerTiming {
+	coldStart := atomic.CompareAndSwapInt32(&initReported, 0, 1)
+	timing := ServerTiming{
+		HandlerStartMs:      float64(handlerStarted.UnixNano()) / 1e6,
+		HandlerEndMs:        float64(time.Now().UnixNano()) / 1e6,
+		ExecutionDurationMs: float64(time.Since(handlerStarted).Microseconds()) / 1000,
+		SpinDurationMs:      float64(spinDuration.Microseconds()) / 1000,
+		ColdStart:           coldStart,
+		Runtime:             runtime.Version(),
+		MemoryLimitMB:       memoryLimitMB,
+	}
+	if coldStart {
+		timing.InitDurationMs = initDurationMs
+	}
+	return timing
+}
+
+// generateResponse busy-spins for the requested service time and echoes back the requested payload
+func generateResponse(requestID string, parameters map[string]string) Response {
+	handlerStarted := time.Now()
+	payloadLengthBytes := parseIntParameter(parameters, "PayloadLengthBytes")
+
+	spinDuration := simulateWork(parameters)
+
+	payload := parameters["TransferPayload"]
+	if payload == "" {
+		payload = strings.Repeat("A", payloadLengthBytes)
+	}
+
+	return Response{
+		RequestID:       requestID,
+		TimestampChain:  []string{strconv.FormatInt(time.Now().UnixNano()/int64(time.Millisecond), 10)},
+		TransferPayload: payload,
+		ServerTiming:    newServerTiming(handlerStarted, spinDuration),
 	}
-	simulateWork(incrementLimit)
+}
 
-	res := HelloGoResponse{
-		RequestID: "google-does-not-specify",
-		TimestampChain: []string{
-			strconv.Itoa(int(time.Now().Nanosecond())),
-		},
+func parseIntParameter(parameters map[string]string, name string) int {
+	value, ok := parameters[name]
+	if !ok || value == "" {
+		return 0
 	}
-	err = json.NewEncoder(w).Encode(res)
+	parsed, err := strconv.Atoi(value)
 	if err != nil {
-		log.Errorf("Error encoding response body: %s", err)
-		http.Error(w, "Error encoding response body", http.StatusInternalServerError)
-		return
+		log.Warnf("Could not parse %s parameter: %s", name, err.Error())
+		return 0
 	}
+	return parsed
 }
 
-func extractIncrementLimit(r *http.Request) (int, error) {
-	incrementLimit := 0
-	reqIncrementLimit := r.URL.Query().Get("IncrementLimit")
-	if reqIncrementLimit != "" {
-		var err error
-		incrementLimit, err = strconv.Atoi(r.URL.Query().Get("IncrementLimit"))
-		if err != nil {
-			return 0, fmt.Errorf("Error parsing IncrementLimit: %s", err)
+// simulateWork will keep the CPU busy-spinning until the desired service time has elapsed on the requested clock,
+// or up to the legacy increment limit, and returns the achieved duration
+func simulateWork(parameters map[string]string) time.Duration {
+	clock := wallClock
+	if parameters["SpinClock"] == "cpu" {
+		// The thread CPU time is only meaningful if the goroutine keeps spinning on the same thread
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+		clock = threadCPUClock
+	}
+	spinStarted := clock()
+
+	serviceTimeMs, ok := parameters["ServiceTimeMs"]
+	if !ok {
+		incrementLimit := parseIntParameter(parameters, "IncrementLimit")
+		log.Infof("Running function up to increment limit (%d)...", incrementLimit)
+		for i := 0; i < incrementLimit; i++ {
 		}
+		return clock() - spinStarted
+	}
+
+	serviceTime, err := strconv.ParseFloat(serviceTimeMs, 64)
+	if err != nil {
+		log.Warnf("Could not parse ServiceTimeMs parameter: %s", err.Error())
+	}
+	deadline := spinStarted + time.Duration(serviceTime*float64(time.Millisecond))
+	log.Infof("Running function for %v of %s time...", deadline-spinStarted, parameters["SpinClock"])
+	for clock() < deadline {
 	}
-	return incrementLimit, nil
+	return clock() - spinStarted
 }
 
-func simulateWork(incrementLimit int) {
-	log.Infof("Running function up to increment limit (%d)...", incrementLimit)
-	for i := 0; i < incrementLimit; i++ {
+func wallClock() time.Duration {
+	return time.Duration(time.Now().UnixNano())
+}
+
+// threadCPUClock returns the CPU time consumed by the calling thread
+func threadCPUClock() time.Duration {
+	var cpuTime syscall.Timespec
+	_, _, errno := syscall.RawSyscall(syscall.SYS_CLOCK_GETTIME, clockThreadCPUTimeID, uintptr(unsafe.Pointer(&cpuTime)), 0)
+	if errno != 0 {
+		log.Warnf("Could not read the thread CPU time: %s", errno.Error())
+		return wallClock()
+	}
+	return time.Duration(cpuTime.Nano())
+}
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	parameters := make(map[string]string)
+	for name := range r.URL.Query() {
+		parameters[name] = r.URL.Query().Get(name)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(generateResponse("google-does-not-specify", parameters)); err != nil {
+		log.Errorf("Error encoding response body: %s", err)
+		http.Error(w, "Error encoding response body", http.StatusInternalServerError)
 	}
 }
 
@@ -67,4 +199,3 @@ func main() {
 
 	log.Fatal(http.ListenAndServe(fmt.Sprintf(":%s", port), nil))
 }
-
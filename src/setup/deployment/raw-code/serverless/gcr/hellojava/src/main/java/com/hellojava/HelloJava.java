@@ -1,42 +1,129 @@
 package com.hellojava;
 
+import java.lang.management.ManagementFactory;
+import java.lang.management.ThreadMXBean;
+import java.util.HashMap;
+import java.util.LinkedHashMap;
+import java.nio.file.Files;
+import java.nio.file.Paths;
+import java.util.Map;
+import java.util.concurrent.atomic.AtomicBoolean;
 import com.google.gson.Gson;
 
-import java.time.Instant;
-
 import static spark.Spark.*;
 
 public class HelloJava {
 
-    static String  message;
-
-    public static void main(String args[]) {
+    private static final long INIT_STARTED = System.nanoTime();
+    // The memory stays referenced for the lifetime of the instance, like imported libraries or loaded models
+    private static final byte[] INIT_MEMORY = simulateInit(
+            parseIntParameter(System.getenv(), "INIT_TIME_MS"), parseIntParameter(System.getenv(), "INIT_MEMORY_MB"));
+    private static final double INIT_DURATION_MS = (System.nanoTime() - INIT_STARTED) / 1e6;
+    private static final AtomicBoolean INIT_REPORTED = new AtomicBoolean(false);
+    private static final int MEMORY_LIMIT_MB = readMemoryLimitMB();
 
-        /*
-        * Note:
-        * Default port in SparkJava is 4567
-        * We are using the environment variable PORT or default to 8080
-        */
+    public static void main(String[] args) {
+        // SparkJava listens on port 4567 by default, we use the PORT environment variable or 8080 instead
+        port(Integer.parseInt(System.getenv().getOrDefault("PORT", "8080")));
 
-        port(Integer.valueOf(System.getenv().getOrDefault("PORT", "8080")));
-
-        get("/", (req,res) -> {
-            int incrementLimit = 0;
-            String reqIncrementLimit = req.queryParamOrDefault("IncrementLimit", "");
-            if (!reqIncrementLimit.equals("")) {
-                incrementLimit = Integer.parseInt(reqIncrementLimit);
+        get("/", (req, res) -> {
+            Map<String, String> parameters = new HashMap<>();
+            for (String name : req.queryParams()) {
+                parameters.put(name, req.queryParams(name));
             }
-            simulateWork(incrementLimit);
 
-            return new Gson().toJson(new HelloJavaResponse("google-does-not-specify", new String[]{Long.toString(Instant.now().toEpochMilli())}));
+            res.type("application/json");
+            return new Gson().toJson(generateBody("google-does-not-specify", parameters));
         });
     }
-    public static void simulateWork(int incrementLimit) {
-         for (int i = 0; i < incrementLimit; i++) {
-            Thread.onSpinWait(); // Prevent JVM/JIT optimizations from skipping the loop
+
+    public static Map<String, Object> generateBody(String requestId, Map<String, String> parameters) {
+        long handlerStartMs = System.currentTimeMillis();
+        long executionStarted = System.nanoTime();
+        int payloadLengthBytes = parseIntParameter(parameters, "PayloadLengthBytes");
+
+        double spinDurationMs = simulateWork(parameters);
+
+        String payload = parameters.getOrDefault("TransferPayload", "");
+        if (payload.isEmpty()) {
+            payload = "A".repeat(payloadLengthBytes);
         }
+
+        Map<String, Object> body = new LinkedHashMap<>();
+        body.put("RequestID", requestId);
+        body.put("TimestampChain", new String[]{Long.toString(System.currentTimeMillis())});
+        body.put("TransferPayload", payload);
+        body.put("ServerTiming", serverTiming(handlerStartMs, executionStarted, spinDurationMs));
+        return body;
     }
 
+    // Only the first invocation of an instance is a cold start and reports the init duration
+    public static Map<String, Object> serverTiming(long handlerStartMs, long executionStarted, double spinDurationMs) {
+        boolean coldStart = !INIT_REPORTED.getAndSet(true);
 
-}
+        Map<String, Object> timing = new LinkedHashMap<>();
+        timing.put("HandlerStartMs", handlerStartMs);
+        timing.put("HandlerEndMs", System.currentTimeMillis());
+        timing.put("ExecutionDurationMs", (System.nanoTime() - executionStarted) / 1e6);
+        timing.put("SpinDurationMs", spinDurationMs);
+        timing.put("InitDurationMs", coldStart ? INIT_DURATION_MS : 0);
+        timing.put("ColdStart", coldStart);
+        timing.put("Runtime", "java" + System.getProperty("java.version"));
+        timing.put("MemoryLimitMB", MEMORY_LIMIT_MB);
+        return timing;
+    }
+
+    public static int readMemoryLimitMB() {
+        String memorySize = System.getenv("AWS_LAMBDA_FUNCTION_MEMORY_SIZE");
+        if (memorySize != null) {
+            return Integer.parseInt(memorySize);
+        }
+        try {
+            return (int) (Long.parseLong(Files.readString(Paths.get("/sys/fs/cgroup/memory.max")).trim()) / (1024 * 1024));
+        } catch (Exception e) {
+            return 0;
+        }
+    }
 
+    public static int parseIntParameter(Map<String, String> parameters, String name) {
+        try {
+            return Integer.parseInt(parameters.getOrDefault(name, "0"));
+        } catch (NumberFormatException e) {
+            return 0;
+        }
+    }
+
+    public static byte[] simulateInit(int initTimeMs, int initMemoryMB) {
+        byte[] memory = new byte[initMemoryMB * 1024 * 1024];
+        for (int offset = 0; offset < memory.length; offset += 4096) {
+            memory[offset] = 1;
+        }
+        while ((System.nanoTime() - INIT_STARTED) / 1_000_000 < initTimeMs) {
+            Thread.onSpinWait();
+        }
+        return memory;
+    }
+
+    // Busy-spins until the desired service time has elapsed on the requested clock, or up to the legacy increment limit
+    public static double simulateWork(Map<String, String> parameters) {
+        ThreadMXBean threads = ManagementFactory.getThreadMXBean();
+        boolean cpuClock = "cpu".equals(parameters.get("SpinClock")) && threads.isCurrentThreadCpuTimeSupported();
+        long spinStarted = cpuClock ? threads.getCurrentThreadCpuTime() : System.nanoTime();
+        long spinEnded;
+
+        if (parameters.containsKey("ServiceTimeMs")) {
+            long deadline = spinStarted + (long) (Double.parseDouble(parameters.get("ServiceTimeMs")) * 1e6);
+            do {
+                Thread.onSpinWait();
+                spinEnded = cpuClock ? threads.getCurrentThreadCpuTime() : System.nanoTime();
+            } while (spinEnded < deadline);
+        } else {
+            int incrementLimit = parseIntParameter(parameters, "IncrementLimit");
+            for (int i = 0; i < incrementLimit; i++) {
+                Thread.onSpinWait(); // Prevent JVM/JIT optimizations from skipping the loop
+            }
+            spinEnded = System.nanoTime();
+        }
+        return (spinEnded - spinStarted) / 1e6;
+    }
+}
@@ -1,20 +1,53 @@
 import json
 import os
+import platform
 import time
 import random
 from flask import Flask, request
 
+init_started = time.time()
 app = Flask(__name__)
+init_duration_ms = (time.time() - init_started) * 1000
+
+cold_start = True
+
+
+def read_memory_limit_mb():
+    for variable in ('AWS_LAMBDA_FUNCTION_MEMORY_SIZE', 'FUNCTION_MEMORY_MB'):
+        if variable in os.environ:
+            return int(os.environ[variable])
+    try:
+        with open('/sys/fs/cgroup/memory.max') as limit_file:
+            return int(limit_file.read()) // (1024 * 1024)
+    except (OSError, ValueError):
+        return 0
+
+
+MEMORY_LIMIT_MB = read_memory_limit_mb()
+
+
+def server_timing(handler_started, execution_started, spin_duration_ms):
+    # Only the first invocation of an instance is a cold start and reports the init duration
+    global cold_start
+    was_cold, cold_start = cold_start, False
+    return {
+        "HandlerStartMs": handler_started * 1000,
+        "HandlerEndMs": time.time() * 1000,
+        "ExecutionDurationMs": (time.perf_counter() - execution_started) * 1000,
+        "SpinDurationMs": spin_duration_ms,
+        "InitDurationMs": init_duration_ms if was_cold else 0,
+        "ColdStart": was_cold,
+        "Runtime": "python" + platform.python_version(),
+        "MemoryLimitMB": MEMORY_LIMIT_MB,
+    }
 
 
 @app.route('/')
 def hello_world():
-    incr_limit = 0
-    if request.args and 'incrementLimit' in request.args:
-        incr_limit = request.args.get('incrementLimit')
-
+    handler_started = time.time()
+    execution_started = time.perf_counter()
     read_filler_file("./filler.file")
-    simulate_work(incr_limit)
+    spin_duration_ms = simulate_work(request.args)
 
     response = {
         "statusCode": 200,
@@ -24,16 +57,29 @@ def hello_world():
         "body": {
             "RequestID": "gcr-does-not-specify",
             "TimestampChain": [str(time.time_ns())],
+            "ServerTiming": server_timing(handler_started, execution_started, spin_duration_ms),
         }
     }
 
     return json.dumps(response, indent=4)
 
 
-def simulate_work(incr):
-    num = 0
-    while num < incr:
-        num += 1
+def simulate_work(parameters):
+    # Busy-spin until the desired service time has elapsed on the requested clock, or up to the legacy increment limit
+    if 'ServiceTimeMs' in parameters:
+        clock = time.thread_time if parameters.get('SpinClock') == 'cpu' else time.perf_counter
+        spin_started = clock()
+        deadline = spin_started + float(parameters['ServiceTimeMs']) / 1000
+        while clock() < deadline:
+            pass
+    else:
+        clock = time.perf_counter
+        spin_started = clock()
+        increment_limit = int(parameters.get('IncrementLimit') or 0)
+        num = 0
+        while num < increment_limit:
+            num += 1
+    return (clock() - spin_started) * 1000
 
 
 def read_filler_file(path: str) -> None:
@@ -1,13 +1,11 @@
 use actix_web::{get, web, App, HttpRequest, HttpServer, Responder, Result};
-use serde::{Deserialize, Serialize};
-use std::time::SystemTime;
+use serde::Serialize;
+use std::collections::HashMap;
+use std::sync::atomic::{AtomicBool, AtomicU64, Ordering};
+use std::time::{Duration, Instant, SystemTime};
 
-#[derive(Debug, Deserialize)]
-pub struct Params {
-    #[serde(rename = "IncrementLimit")]
-    #[serde(default)]
-    increment_limit: u64,
-}
+static INIT_DURATION_US: AtomicU64 = AtomicU64::new(0);
+static INIT_REPORTED: AtomicBool = AtomicBool::new(false);
 
 #[derive(Serialize)]
 struct Response {
@@ -15,41 +13,173 @@ struct Response {
     request_id: String,
     #[serde(rename = "TimestampChain")]
     timestamp_chain: Vec<String>,
+    #[serde(rename = "TransferPayload")]
+    transfer_payload: String,
+    #[serde(rename = "ServerTiming")]
+    server_timing: ServerTiming,
+}
+
+// ServerTiming is the standard server-side timing envelope of the benchmark functions
+#[derive(Serialize)]
+#[serde(rename_all = "PascalCase")]
+struct ServerTiming {
+    handler_start_ms: f64,
+    handler_end_ms: f64,
+    execution_duration_ms: f64,
+    spin_duration_ms: f64,
+    init_duration_ms: f64,
+    cold_start: bool,
+    runtime: String,
+    #[serde(rename = "MemoryLimitMB")]
+    memory_limit_mb: u64,
+}
+
+// The returned memory is kept for the lifetime of the instance, like imported libraries or loaded models
+fn simulate_init(init_started: Instant, init_time_ms: u64, init_memory_mb: u64) -> Vec<u8> {
+    let mut memory = vec![0u8; (init_memory_mb * 1024 * 1024) as usize];
+    for offset in (0..memory.len()).step_by(4096) {
+        memory[offset] = 1;
+    }
+    while init_started.elapsed() < Duration::from_millis(init_time_ms) {}
+    memory
+}
+
+fn unix_time_ms() -> f64 {
+    match SystemTime::now().duration_since(SystemTime::UNIX_EPOCH) {
+        Ok(n) => n.as_micros() as f64 / 1000.0,
+        Err(_) => panic!("SystemTime before UNIX EPOCH!"),
+    }
+}
+
+fn read_memory_limit_mb() -> u64 {
+    if let Some(memory_size) = std::env::var("AWS_LAMBDA_FUNCTION_MEMORY_SIZE")
+        .ok()
+        .and_then(|value| value.parse().ok())
+    {
+        return memory_size;
+    }
+    std::fs::read_to_string("/sys/fs/cgroup/memory.max")
+        .ok()
+        .and_then(|limit| limit.trim().parse::<u64>().ok())
+        .map(|limit| limit / (1024 * 1024))
+        .unwrap_or(0)
 }
 
-fn simulate_work(increment_limit: u64) {
-    let mut i = 0;
-    while i < increment_limit {
-        i += 1;
+// Only the first invocation of an instance is a cold start and reports the init duration
+fn server_timing(handler_start_ms: f64, execution_started: Instant, spin_duration_ms: f64) -> ServerTiming {
+    let cold_start = !INIT_REPORTED.swap(true, Ordering::SeqCst);
+    ServerTiming {
+        handler_start_ms,
+        handler_end_ms: unix_time_ms(),
+        execution_duration_ms: execution_started.elapsed().as_micros() as f64 / 1000.0,
+        spin_duration_ms,
+        init_duration_ms: if cold_start {
+            INIT_DURATION_US.load(Ordering::SeqCst) as f64 / 1000.0
+        } else {
+            0.0
+        },
+        cold_start,
+        runtime: "rust".to_owned(),
+        memory_limit_mb: read_memory_limit_mb(),
     }
 }
 
+fn env_parameter(name: &str) -> u64 {
+    std::env::var(name)
+        .ok()
+        .and_then(|value| value.parse().ok())
+        .unwrap_or(0)
+}
+
+// The standard library has no CPU clock, so the thread CPU time (ns) is read from the scheduler statistics
+fn thread_cpu_time_ns() -> Option<u64> {
+    std::fs::read_to_string("/proc/thread-self/schedstat")
+        .ok()
+        .and_then(|stats| stats.split_whitespace().next()?.parse().ok())
+}
+
+// Busy-spins until the desired service time has elapsed on the requested clock, or up to the legacy increment
+// limit, and returns the achieved duration (ms). The CPU clock falls back to the wall clock where unavailable.
+fn simulate_work(parameters: &HashMap<String, String>) -> f64 {
+    let wall_started = Instant::now();
+    let cpu_started = match parameters.get("SpinClock").map(String::as_str) {
+        Some("cpu") => thread_cpu_time_ns(),
+        _ => None,
+    };
+    let elapsed_ns = || match cpu_started {
+        Some(started) => thread_cpu_time_ns().unwrap_or(started) - started,
+        None => wall_started.elapsed().as_nanos() as u64,
+    };
+
+    match parameters.get("ServiceTimeMs").and_then(|value| value.parse::<f64>().ok()) {
+        Some(service_time_ms) => {
+            let service_time_ns = (service_time_ms * 1e6) as u64;
+            while elapsed_ns() < service_time_ns {}
+        }
+        None => {
+            let increment_limit = parse_parameter(parameters, "IncrementLimit");
+            let mut i = 0;
+            while i < increment_limit {
+                i += 1;
+            }
+        }
+    }
+    elapsed_ns() as f64 / 1e6
+}
+
 fn get_system_time() -> String {
     let mut buffer = itoa::Buffer::new();
     match SystemTime::now().duration_since(SystemTime::UNIX_EPOCH) {
-        Ok(n) => buffer.format(n.as_secs()).to_owned(),
+        Ok(n) => buffer.format(n.as_millis()).to_owned(),
         Err(_) => panic!("SystemTime before UNIX EPOCH!"),
     }
 }
 
+fn parse_parameter(parameters: &HashMap<String, String>, name: &str) -> u64 {
+    parameters
+        .get(name)
+        .and_then(|value| value.parse().ok())
+        .unwrap_or(0)
+}
+
+fn generate_response(request_id: &str, parameters: &HashMap<String, String>) -> Response {
+    let handler_start_ms = unix_time_ms();
+    let execution_started = Instant::now();
+    let spin_duration_ms = simulate_work(parameters);
+
+    let transfer_payload = match parameters.get("TransferPayload") {
+        Some(payload) if !payload.is_empty() => payload.to_owned(),
+        _ => "A".repeat(parse_parameter(parameters, "PayloadLengthBytes") as usize),
+    };
+
+    Response {
+        request_id: request_id.to_owned(),
+        timestamp_chain: vec![get_system_time()],
+        transfer_payload,
+        server_timing: server_timing(handler_start_ms, execution_started, spin_duration_ms),
+    }
+}
+
 #[get("/")]
 async fn hellorust(req: HttpRequest) -> Result<impl Responder> {
-    let params = web::Query::<Params>::from_query(req.query_string()).unwrap();
-    if params.increment_limit > 0 {
-        simulate_work(params.increment_limit)
-    }
+    let parameters = web::Query::<HashMap<String, String>>::from_query(req.query_string())
+        .map(|query| query.into_inner())
+        .unwrap_or_default();
 
-    let res_obj = Response {
-        request_id: "google-does-not-specify".to_owned(),
-        timestamp_chain: vec![get_system_time()],
-    };
-    Ok(web::Json(res_obj))
+    Ok(web::Json(generate_response("google-does-not-specify", &parameters)))
 }
 
 #[actix_web::main]
 async fn main() -> std::io::Result<()> {
+    let init_started = Instant::now();
+    let init_memory = simulate_init(init_started, env_parameter("INIT_TIME_MS"), env_parameter("INIT_MEMORY_MB"));
+    INIT_DURATION_US.store(init_started.elapsed().as_micros() as u64, Ordering::SeqCst);
+
     HttpServer::new(|| App::new().service(hellorust))
         .bind(("0.0.0.0", 8080))?
         .run()
-        .await
+        .await?;
+
+    drop(init_memory);
+    Ok(())
 }
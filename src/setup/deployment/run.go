@@ -25,56 +25,37 @@ package deployment
 import (
 	"fmt"
 	log "github.com/sirupsen/logrus"
-	"math"
 	"os"
+	"path/filepath"
 	"stellar/setup/deployment/packaging"
 	"stellar/util"
 )
 
 // SetupDeployment will create the serverless function zip deployment for the given provider,
-// in the given language and of the given size in bytes. Returns size of deployment in MB and the handler path for AWS automation.
-func SetupDeployment(rawCodePath string, provider string, deploymentSizeBytes int64, packageType string, experimentID int, function string) (float64, string) {
-	fillerFilePath := rawCodePath + "/filler.file"
-
+// in the given language and of the given size in bytes. Returns size of deployment in MB, the handler path for AWS
+// automation and, for container images, the achieved image size. The commands building the images, if any, are run
+// through the runner.
+func SetupDeployment(rawCodePath string, provider string, deploymentSizeBytes int64, packageType string, experimentID int, function string, runner util.CommandRunner) (float64, string, packaging.ImageSize) {
 	switch packageType {
 	case "Zip":
 		_, binaryPath, handlerPath := getExecutableInfo(rawCodePath, experimentID, function)
 
-		zippedBinaryFileSizeBytes := packaging.GetZippedBinaryFileSize(experimentID, binaryPath)
-
-		if deploymentSizeBytes == 0 {
-			log.Infof("[sub-experiment %d] Desired image size is set to default (0MB), assigning size of zipped binary (%vMB)...",
-				experimentID,
-				util.BytesToMebibyte(zippedBinaryFileSizeBytes),
-			)
-			deploymentSizeBytes = zippedBinaryFileSizeBytes
-		}
-
-		if deploymentSizeBytes < zippedBinaryFileSizeBytes {
-			log.Fatalf("[sub-experiment %d] Total size (~%vMB) cannot be smaller than zipped binary size (~%vMB).",
-				experimentID,
-				util.BytesToMebibyte(deploymentSizeBytes),
-				util.BytesToMebibyte(zippedBinaryFileSizeBytes),
-			)
-		}
+		archiveDirectory, removeArchiveDirectory := packaging.NewArchiveDirectory()
+		defer removeArchiveDirectory()
 
-		packaging.GenerateFillerFile(experimentID, fillerFilePath, deploymentSizeBytes-zippedBinaryFileSizeBytes)
-		zipPath := packaging.GenerateZIP(experimentID, fillerFilePath, binaryPath, "benchmarking.zip")
+		zipPath := filepath.Join(archiveDirectory, "benchmarking.zip")
+		deploymentSizeBytes = packaging.GenerateSizedZIP(experimentID, binaryPath, zipPath, deploymentSizeBytes)
 		packaging.SetupZIPDeployment(provider, deploymentSizeBytes, zipPath)
 
-		return util.BytesToMebibyte(deploymentSizeBytes), handlerPath
+		return util.BytesToMebibyte(deploymentSizeBytes), handlerPath, packaging.ImageSize{}
 	case "Image":
-		log.Warn("Container image deployment does not support code size verification on AWS, making the image size benchmarks unreliable.")
-
-		// TODO: Size of containerized binary should be subtracted, seems to be 134MB in Amazon ECR...
-		packaging.GenerateFillerFile(experimentID, fillerFilePath, int64(math.Max(float64(deploymentSizeBytes)-134, 0)))
-		//packaging.SetupContainerImageDeployment(function, provider, rawCodePath)
-
+		_, imageSize := packaging.SetupContainerImageDeployment(experimentID, function, provider, rawCodePath, util.BytesToMebibyte(deploymentSizeBytes), runner)
+		return util.BytesToMebibyte(deploymentSizeBytes), "", imageSize
 	default:
 		log.Fatalf("[sub-experiment %d] Unrecognized package type: %s", experimentID, packageType)
 	}
 
-	return util.BytesToMebibyte(deploymentSizeBytes), ""
+	return util.BytesToMebibyte(deploymentSizeBytes), "", packaging.ImageSize{}
 }
 
 func getExecutableInfo(rawCodePath string, experimentID int, function string) (int64, string, string) {
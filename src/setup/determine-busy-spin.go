@@ -23,9 +23,13 @@
 package setup
 
 import (
+	"fmt"
 	log "github.com/sirupsen/logrus"
 	"math"
 	"math/big"
+	"net/url"
+	"stellar/util"
+	"strconv"
 	"time"
 )
 
@@ -37,14 +41,132 @@ const (
 	retryLimit        = 10
 )
 
-// FindBusySpinIncrements transforms given service times (e.g., 10s) into busy-spin increments (e.g., 10,000,000)
-func FindBusySpinIncrements(config *Configuration) {
+const (
+	// SpinModeWallClock makes the functions busy-spin until the desired service time has elapsed on the wall clock
+	SpinModeWallClock = "wall"
+	// SpinModeCPUTime makes the functions busy-spin until they have consumed the desired service time of CPU time
+	SpinModeCPUTime = "cpu"
+	// SpinModeIncrement is the legacy mode, where the functions busy-spin up to an increment limit calibrated on the client host
+	SpinModeIncrement = "increment"
+)
+
+// supportedSpinModes are the ways functions can be told to simulate their service time
+var supportedSpinModes = map[string]bool{SpinModeWallClock: true, SpinModeCPUTime: true, SpinModeIncrement: true}
+
+// incrementOnlyProviders cannot run time-based spin modes: vHive functions are invoked over gRPC, whose requests only
+// carry increment limits, and Cloudflare Workers freeze their clocks while a request executes.
+var incrementOnlyProviders = map[string]bool{"vhive": true, "cloudflare": true}
+
+// defaultSpinMode returns the spin mode used when a sub-experiment does not set one
+func defaultSpinMode(provider string) string {
+	if incrementOnlyProviders[provider] {
+		return SpinModeIncrement
+	}
+	return SpinModeWallClock
+}
+
+// checkSpinMode refuses time-based spin modes on providers whose functions would otherwise busy-spin for 0ms
+func checkSpinMode(provider string, spinMode string) error {
+	if !supportedSpinModes[spinMode] {
+		return fmt.Errorf("unsupported spin mode `%s`", spinMode)
+	}
+	if spinMode != SpinModeIncrement && incrementOnlyProviders[provider] {
+		return fmt.Errorf("spin mode `%s` is not supported on %s, only `%s` is", spinMode, provider, SpinModeIncrement)
+	}
+	return nil
+}
+
+// BusySpin describes the work a function should simulate for a single request
+type BusySpin struct {
+	Mode           string
+	Duration       time.Duration
+	IncrementLimit int64
+}
+
+// QueryParameters returns the query parameters telling the function how long to busy-spin for
+func (b BusySpin) QueryParameters() url.Values {
+	parameters := url.Values{}
+	parameters.Set("IncrementLimit", strconv.FormatInt(b.IncrementLimit, 10))
+	if b.Mode != SpinModeIncrement {
+		parameters.Set("ServiceTimeMs", strconv.FormatFloat(float64(b.Duration.Microseconds())/1000, 'f', -1, 64))
+		parameters.Set("SpinClock", b.Mode)
+	}
+	return parameters
+}
+
+func (b BusySpin) String() string {
+	if b.Mode == SpinModeIncrement {
+		return fmt.Sprintf("increment limit %d", b.IncrementLimit)
+	}
+	return fmt.Sprintf("%s time %v", b.Mode, b.Duration)
+}
+
+// BusySpinAt returns the busy-spin of the requests sent after the given number of inter-arrival time refreshes,
+// repeating the last desired service time once all of them have been used.
+func (s *SubExperiment) BusySpinAt(index int) BusySpin {
+	if s.SpinMode == SpinModeIncrement {
+		return BusySpin{Mode: s.SpinMode, IncrementLimit: s.BusySpinIncrements[util.IntegerMin(index, len(s.BusySpinIncrements)-1)]}
+	}
+	return BusySpin{Mode: s.SpinMode, Duration: s.BusySpinDurations[util.IntegerMin(index, len(s.BusySpinDurations)-1)]}
+}
+
+// FindBusySpinIncrements transforms given service times (e.g., 10s) into the busy-spin work of each sub-experiment.
+// Time-based spin modes use the durations as-is, while the legacy increment mode uses the busy-spin increments
+// (e.g., 10,000,000) calibrated on the target platform if the cache holds them, and calibrates them on the client
+// host otherwise. The cache may be nil.
+func FindBusySpinIncrements(config *Configuration, cache *BusySpinCache) {
 	cachedServiceTimeIncrement = make(map[string]int64)
 	cachedServiceTimeIncrement["0ms"] = 0
 
-	standardDurationMs := timeSession(standardIncrement).Milliseconds()
+	var standardDurationMs int64
 	for subExperimentIndex := range config.SubExperiments {
-		findBusySpinIncrement(&config.SubExperiments[subExperimentIndex], standardDurationMs)
+		subExperiment := &config.SubExperiments[subExperimentIndex]
+		if subExperiment.SpinMode != SpinModeIncrement {
+			parseBusySpinDurations(subExperiment)
+			continue
+		}
+
+		if increments, ok := calibratedIncrements(subExperiment, config.Provider, cache); ok {
+			log.Infof("Using busy-spin increments %v calibrated on %s for sub-experiment %q.",
+				increments, config.Provider, subExperiment.Title)
+			subExperiment.BusySpinIncrements = increments
+			continue
+		}
+		log.Warnf("Busy-spin increments of sub-experiment %q were not calibrated on %s, calibrating them on the client host instead (see the `calibrate` command).",
+			subExperiment.Title, config.Provider)
+
+		if standardDurationMs == 0 {
+			standardDurationMs = timeSession(standardIncrement).Milliseconds()
+		}
+		findBusySpinIncrement(subExperiment, standardDurationMs)
+	}
+}
+
+// calibratedIncrements returns the increments of all desired service times of the sub-experiment if the cache holds them
+func calibratedIncrements(subExperiment *SubExperiment, provider string, cache *BusySpinCache) ([]int64, bool) {
+	if cache == nil {
+		return nil, false
+	}
+
+	var increments []int64
+	for _, serviceTime := range subExperiment.DesiredServiceTimes {
+		calibration, ok := cache.Lookup(provider, subExperiment.Runtime, subExperiment.FunctionMemoryMB, serviceTime)
+		if !ok {
+			return nil, false
+		}
+		increments = append(increments, calibration.IncrementLimit)
+	}
+	return increments, len(increments) > 0
+}
+
+func parseBusySpinDurations(subExperiment *SubExperiment) {
+	subExperiment.BusySpinDurations = nil
+	for _, serviceTime := range subExperiment.DesiredServiceTimes {
+		parsedDesiredDuration, err := time.ParseDuration(serviceTime)
+		if err != nil || parsedDesiredDuration < 0 {
+			log.Fatalf("Could not parse desired function run duration %s from configuration file.", serviceTime)
+		}
+		subExperiment.BusySpinDurations = append(subExperiment.BusySpinDurations, parsedDesiredDuration)
 	}
 }
 
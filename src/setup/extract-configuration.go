@@ -24,9 +24,13 @@ package setup
 
 import (
 	"encoding/json"
+	"fmt"
 	log "github.com/sirupsen/logrus"
 	"io"
+	"stellar/setup/deployment/packaging"
 	"stellar/util"
+	"strings"
+	"time"
 )
 
 // Configuration is the schema for all experiment configurations.
@@ -35,6 +39,39 @@ type Configuration struct {
 	Provider       string          `json:"Provider"`
 	Runtime        string          `json:"Runtime"`
 	SubExperiments []SubExperiment `json:"SubExperiments"`
+	Comparisons    []Comparison    `json:"Comparisons"`
+	MaxBudgetUSD   float64         `json:"MaxBudgetUSD"`
+	Pricing        *Pricing        `json:"Pricing"`
+	Safety         Safety          `json:"Safety"`
+	// Seed of the inter-arrival times, service names and filler contents, picked from the current time if 0
+	Seed int64 `json:"Seed"`
+	// Seed of the filler contents, set by ResolveSeed
+	FillerSeed int64 `json:"-"`
+}
+
+// Comparison describes a plot rendered across several sub-experiments once all of them have finished.
+type Comparison struct {
+	Title          string    `json:"Title"`
+	SubExperiments []string  `json:"SubExperiments"`
+	PlotType       string    `json:"PlotType"`
+	Parameter      string    `json:"Parameter"`
+	Percentiles    []float64 `json:"Percentiles"`
+}
+
+// PlotOptions customizes the output format and styling of the visualizations generated for a sub-experiment.
+type PlotOptions struct {
+	Format               string    `json:"Format"`
+	WidthInches          float64   `json:"WidthInches"`
+	HeightInches         float64   `json:"HeightInches"`
+	Font                 string    `json:"Font"`
+	FontSizePoints       float64   `json:"FontSizePoints"`
+	LogX                 bool      `json:"LogX"`
+	LogY                 bool      `json:"LogY"`
+	XMin                 *float64  `json:"XMin"`
+	XMax                 *float64  `json:"XMax"`
+	YMin                 *float64  `json:"YMin"`
+	YMax                 *float64  `json:"YMax"`
+	AnnotatedPercentiles []float64 `json:"AnnotatedPercentiles"`
 }
 
 // EndpointInfo contains an ID identifying the function together with the IDs of other functions further in the data transfer chain
@@ -46,30 +83,37 @@ type EndpointInfo struct {
 // SubExperiment contains all the information needed for a sub-experiment to run.
 type SubExperiment struct {
 	ID                      int
-	Title                   string   `json:"Title"`
-	Bursts                  int      `json:"Bursts"`
-	BurstSizes              []int    `json:"BurstSizes"`
-	PayloadLengthBytes      int      `json:"PayloadLengthBytes"`
-	IATSeconds              float64  `json:"IATSeconds"`
-	DesiredServiceTimes     []string `json:"DesiredServiceTimes"`
-	IATType                 string   `json:"IATType"`
-	PackageType             string   `json:"PackageType"`
-	Parallelism             int      `json:"Parallelism"`
-	Visualization           string   `json:"Visualization"`
-	Function                string   `json:"Function"`
-	FunctionMemoryMB        int64    `json:"FunctionMemoryMB"`
-	FunctionImageSizeMB     float64  `json:"FunctionImageSizeMB"`
-	DataTransferChainLength int      `json:"DataTransferChainLength"`
-	StorageTransfer         bool     `json:"StorageTransfer"`
-	Handler                 string   `json:"Handler"`
-	Runtime                 string   `json:"Runtime"`
-	SnapStartEnabled        bool     `json:"SnapStartEnabled"`
-	CPUBoostEnabled         bool     `json:"CPUBoostEnabled"`
-	PackagePattern          string   `json:"PackagePattern"`
+	Title                   string          `json:"Title"`
+	Bursts                  int             `json:"Bursts"`
+	BurstSizes              []int           `json:"BurstSizes"`
+	PayloadLengthBytes      int             `json:"PayloadLengthBytes"`
+	IATSeconds              float64         `json:"IATSeconds"`
+	DesiredServiceTimes     []string        `json:"DesiredServiceTimes"`
+	SpinMode                string          `json:"SpinMode"`
+	DesiredInitTime         string          `json:"DesiredInitTime"`
+	InitMemoryMB            int             `json:"InitMemoryMB"`
+	IATType                 string          `json:"IATType"`
+	PackageType             string          `json:"PackageType"`
+	Parallelism             int             `json:"Parallelism"`
+	Visualization           string          `json:"Visualization"`
+	Function                string          `json:"Function"`
+	FunctionMemoryMB        int64           `json:"FunctionMemoryMB"`
+	FunctionImageSizeMB     float64         `json:"FunctionImageSizeMB"`
+	DataTransferChainLength int             `json:"DataTransferChainLength"`
+	StorageTransfer         bool            `json:"StorageTransfer"`
+	Handler                 string          `json:"Handler"`
+	Runtime                 string          `json:"Runtime"`
+	SnapStartEnabled        bool            `json:"SnapStartEnabled"`
+	CPUBoostEnabled         bool            `json:"CPUBoostEnabled"`
+	PackagePattern          string          `json:"PackagePattern"`
+	PlotOptions             PlotOptions     `json:"PlotOptions"`
+	Workload                WorkloadProfile `json:"Workload"`
 	// All of the below are computed after reading the configuration
 	BusySpinIncrements []int64 `json:"BusySpinIncrements"`
+	BusySpinDurations  []time.Duration
 	Endpoints          []EndpointInfo
 	Routes             []string
+	ImageSize          packaging.ImageSize // achieved size of the container image, if any
 }
 
 const (
@@ -84,8 +128,18 @@ const (
 	defaultParallelism             = 1
 	defaultDataTransferChainLength = 1
 	defaultFunctionMemoryMB        = 128
+	defaultPlotFormat              = "png"
+	defaultComparisonPlotType      = "cdf"
+	defaultComparisonParameter     = "FunctionMemoryMB"
 )
 
+var defaultComparisonPercentiles = []float64{50, 99}
+
+// supportedPlotFormats are the file formats the plotting library can render to
+var supportedPlotFormats = map[string]bool{
+	"png": true, "jpg": true, "jpeg": true, "tif": true, "tiff": true, "svg": true, "pdf": true, "eps": true,
+}
+
 // ExtractConfiguration will read and parse the JSON configuration file, assign any default values and return the config object
 func ExtractConfiguration(configFilePath string) Configuration {
 	configFile := util.ReadFile(configFilePath)
@@ -102,8 +156,13 @@ func ExtractConfiguration(configFilePath string) Configuration {
 	if parsedConfig.Runtime == "" {
 		parsedConfig.Runtime = defaultRuntime
 	}
+	if parsedConfig.MaxBudgetUSD < 0 {
+		log.Fatalf("MaxBudgetUSD cannot be negative.")
+	}
+	applySafetyDefaults(&parsedConfig.Safety)
 
 	for index := range parsedConfig.SubExperiments {
+		parsedConfig.SubExperiments[index].ID = index
 		if parsedConfig.SubExperiments[index].Function == "" {
 			parsedConfig.SubExperiments[index].Function = defaultFunction
 		}
@@ -134,6 +193,42 @@ func ExtractConfiguration(configFilePath string) Configuration {
 		if parsedConfig.SubExperiments[index].Parallelism == 0 {
 			parsedConfig.SubExperiments[index].Parallelism = defaultParallelism
 		}
+		if parsedConfig.SubExperiments[index].PlotOptions.Format == "" {
+			parsedConfig.SubExperiments[index].PlotOptions.Format = defaultPlotFormat
+		}
+		parsedConfig.SubExperiments[index].PlotOptions.Format = strings.ToLower(parsedConfig.SubExperiments[index].PlotOptions.Format)
+		if !supportedPlotFormats[parsedConfig.SubExperiments[index].PlotOptions.Format] {
+			log.Fatalf("Sub-experiment %q uses unsupported plot format `%s`.",
+				parsedConfig.SubExperiments[index].Title, parsedConfig.SubExperiments[index].PlotOptions.Format)
+		}
+		if parsedConfig.SubExperiments[index].SpinMode == "" {
+			parsedConfig.SubExperiments[index].SpinMode = defaultSpinMode(parsedConfig.Provider)
+		}
+		if err := checkSpinMode(parsedConfig.Provider, parsedConfig.SubExperiments[index].SpinMode); err != nil {
+			log.Fatalf("Sub-experiment %q uses an invalid spin mode: %s.", parsedConfig.SubExperiments[index].Title, err.Error())
+		}
+		checkWorkload(&parsedConfig.SubExperiments[index])
+		if _, err := parsedConfig.SubExperiments[index].InitTime(); err != nil {
+			log.Fatalf("Sub-experiment %q has an invalid desired init time: %s", parsedConfig.SubExperiments[index].Title, err.Error())
+		}
+		if parsedConfig.SubExperiments[index].InitMemoryMB < 0 {
+			log.Fatalf("Sub-experiment %q has a negative init memory.", parsedConfig.SubExperiments[index].Title)
+		}
+	}
+
+	for index := range parsedConfig.Comparisons {
+		if parsedConfig.Comparisons[index].Title == "" {
+			parsedConfig.Comparisons[index].Title = fmt.Sprintf("comparison%d", index)
+		}
+		if parsedConfig.Comparisons[index].PlotType == "" {
+			parsedConfig.Comparisons[index].PlotType = defaultComparisonPlotType
+		}
+		if parsedConfig.Comparisons[index].Parameter == "" {
+			parsedConfig.Comparisons[index].Parameter = defaultComparisonParameter
+		}
+		if len(parsedConfig.Comparisons[index].Percentiles) == 0 {
+			parsedConfig.Comparisons[index].Percentiles = defaultComparisonPercentiles
+		}
 	}
 
 	log.Debugf("Extracted %d sub-experiments from given configuration file.", len(parsedConfig.SubExperiments))
@@ -14,10 +14,10 @@ func TestDeployAndRemoveServiceAWS(t *testing.T) {
 	// The two unit tests were merged together in order to make sure we are not left with a number of deployed test function on the cloud which are never used in.
 	assert := require.New(t)
 
-	util.RunCommandAndLog(exec.Command("cp", "aws-integration-test-serverless.yml", "../deployment/raw-code/serverless/aws/serverless.yml"))
+	util.RunCommandAndLog(exec.Command("cp", "aws-integration-test-serverless.yml", "../deployment/raw-code/serverless/aws/serverless.yml"), util.ExecRunner{})
 
-	msgDeploy := setup.DeployService("../deployment/raw-code/serverless/aws/")
-	msgRemove := setup.RemoveServerlessService("../deployment/raw-code/serverless/aws/")
+	msgDeploy := setup.DeployService("../deployment/raw-code/serverless/aws/", util.ExecRunner{})
+	msgRemove := setup.RemoveServerlessService("../deployment/raw-code/serverless/aws/", util.ExecRunner{})
 
 	assert.True(strings.Contains(msgDeploy, "Service deployed"))
 	assert.True(strings.Contains(msgRemove, "successfully removed"))
@@ -41,7 +41,7 @@ func TestDeployAndRemoveServiceGCR(t *testing.T) {
 	}
 
 	s.DeployGCRContainerService(subex, 0, "abc12", "docker.io/kkmin/hellopy", "../deployment/raw-code/serverless/gcr/hellopy/", "us-west1")
-	deleteMsg := setup.RemoveGCRSingleService("abc12-hellopytest-0-0")
+	deleteMsg := setup.RemoveGCRSingleService("abc12-hellopytest-0-0", util.ExecRunner{})
 	assert.True(strings.Contains(deleteMsg, "Deleted service [abc12-hellopytest-0-0]"))
 }
 
@@ -64,17 +64,17 @@ func TestDeployAndRemoveServiceGCRCPUBoost(t *testing.T) {
 	}
 
 	s.DeployGCRContainerService(subex, 0, "def12", "docker.io/kkmin/hellopy", "../deployment/raw-code/serverless/gcr/hellopy/", "us-west1")
-	deleteMsg := setup.RemoveGCRSingleService("def12-cpuboosttest-0-0")
+	deleteMsg := setup.RemoveGCRSingleService("def12-cpuboosttest-0-0", util.ExecRunner{})
 	assert.True(strings.Contains(deleteMsg, "Deleted service [def12-cpuboosttest-0-0]"))
 }
 
 func TestDeployAndRemoveServiceAzure(t *testing.T) {
 	assert := require.New(t)
 
-	util.RunCommandAndLog(exec.Command("cp", "azure-integration-test-serverless.yml", "../deployment/raw-code/serverless/azure/hellopy/serverless.yml"))
+	util.RunCommandAndLog(exec.Command("cp", "azure-integration-test-serverless.yml", "../deployment/raw-code/serverless/azure/hellopy/serverless.yml"), util.ExecRunner{})
 
-	msgDeploy := setup.DeployService("../deployment/raw-code/serverless/azure/hellopy/")
-	msgRemove := setup.RemoveServerlessServiceForcefully("../deployment/raw-code/serverless/azure/hellopy/")
+	msgDeploy := setup.DeployService("../deployment/raw-code/serverless/azure/hellopy/", util.ExecRunner{})
+	msgRemove := setup.RemoveServerlessServiceForcefully("../deployment/raw-code/serverless/azure/hellopy/", util.ExecRunner{})
 
 	assert.True(strings.Contains(msgDeploy, "Deployed serverless functions"))
 	assert.True(strings.Contains(msgRemove, "successfully removed"))
@@ -90,8 +90,8 @@ func TestDeployAndRemoveServiceCloudflare(t *testing.T) {
 		Parallelism: 1,
 	}
 
-	setup.DeployCloudflareWorkers(subex, 0, "abc12", "../deployment/raw-code/serverless/cloudflare")
-	msgRemove := setup.RemoveCloudflareSingleWorker("abc12-cloudflaretest-0-0")
+	setup.DeployCloudflareWorkers(subex, 0, "abc12", "../deployment/raw-code/serverless/cloudflare", util.ExecRunner{})
+	msgRemove := setup.RemoveCloudflareSingleWorker("abc12-cloudflaretest-0-0", util.ExecRunner{})
 
 	assert.True(strings.Contains(msgRemove, "Successfully deleted"))
 }
@@ -100,10 +100,10 @@ func TestDeployAndRemoveServiceCloudflare(t *testing.T) {
 func TestDeployAndRemoveServiceAlibaba(t *testing.T) {
 	assert := require.New(t)
 
-	util.RunCommandAndLog(exec.Command("cp", "aliyun-integration-test-serverless.yml", "../deployment/raw-code/serverless/aliyun/hellopy/serverless.yml"))
+	util.RunCommandAndLog(exec.Command("cp", "aliyun-integration-test-serverless.yml", "../deployment/raw-code/serverless/aliyun/hellopy/serverless.yml"), util.ExecRunner{})
 
-	msgDeploy := setup.DeployService("../deployment/raw-code/serverless/aliyun/hellopy/")
-	msgRemove := setup.RemoveServerlessService("../deployment/raw-code/serverless/aliyun/hellopy/")
+	msgDeploy := setup.DeployService("../deployment/raw-code/serverless/aliyun/hellopy/", util.ExecRunner{})
+	msgRemove := setup.RemoveServerlessService("../deployment/raw-code/serverless/aliyun/hellopy/", util.ExecRunner{})
 
 	assert.True(strings.Contains(msgDeploy, "Deployed API"))
 	assert.True(strings.Contains(msgRemove, "Removed service"))
@@ -0,0 +1,145 @@
+// MIT License
+//
+// Copyright (c) 2021 Theodor Amariucai and EASE Lab
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package setup
+
+import (
+	"encoding/json"
+	log "github.com/sirupsen/logrus"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"time"
+)
+
+// RunManifest records what is needed to reproduce a run: the seed of its random choices, the client build and host,
+// and the configuration after defaults were applied, including the endpoints assigned to each sub-experiment.
+type RunManifest struct {
+	Seed          int64
+	FillerSeed    int64
+	StartTime     time.Time
+	Arguments     []string
+	GitCommit     string
+	GoVersion     string
+	ClientHost    ClientHost
+	Configuration Configuration
+}
+
+// ClientHost describes the host the client ran on
+type ClientHost struct {
+	Hostname     string
+	OS           string
+	Architecture string
+	CPUs         int
+}
+
+// DefaultFillerSeed seeds the filler contents of runs without a seed. Unlike the seed picked from the current time, it
+// does not change across runs, so that the artifacts of a function, which are stored under their digest, are
+// uploaded once and reused by later runs rather than piling up in the bucket and the registry.
+const DefaultFillerSeed int64 = 1
+
+// ResolveSeed sets the seed of the run, preferring the given seed, e.g., from the command line, over that of the
+// configuration, and picking one from the current time if neither is set. The filler contents follow the given seed,
+// or DefaultFillerSeed if the seed is picked from the current time.
+func ResolveSeed(config *Configuration, seed int64) {
+	if seed != 0 {
+		config.Seed = seed
+	}
+	config.FillerSeed = config.Seed
+	if config.Seed == 0 {
+		config.Seed = time.Now().UnixNano()
+		config.FillerSeed = DefaultFillerSeed
+	}
+}
+
+// NewRunManifest describes the run of the given configuration, started at startTime, on the client host
+func NewRunManifest(config Configuration, startTime time.Time) RunManifest {
+	hostname, err := os.Hostname()
+	if err != nil {
+		log.Warnf("Could not get the hostname of the client: %s", err.Error())
+	}
+
+	return RunManifest{
+		Seed:       config.Seed,
+		FillerSeed: config.FillerSeed,
+		StartTime:  startTime.UTC(),
+		Arguments:  os.Args[1:],
+		GitCommit:  gitCommit(),
+		GoVersion:  runtime.Version(),
+		ClientHost: ClientHost{
+			Hostname:     hostname,
+			OS:           runtime.GOOS,
+			Architecture: runtime.GOARCH,
+			CPUs:         runtime.NumCPU(),
+		},
+		Configuration: config,
+	}
+}
+
+// Write saves the manifest as manifest.json in the output directory of the run
+func (m RunManifest) Write(outputDirectoryPath string) {
+	manifestPath := filepath.Join(outputDirectoryPath, "manifest.json")
+	log.Infof("Writing run manifest to `%s`", manifestPath)
+
+	manifest, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		log.Fatalf("Could not encode the run manifest: %s", err.Error())
+	}
+	if err := os.WriteFile(manifestPath, manifest, 0644); err != nil {
+		log.Fatalf("Could not write the run manifest: %s", err.Error())
+	}
+}
+
+// gitCommit returns the commit the client was built from, marked as dirty if it had uncommitted changes. Binaries
+// built without version control information fall back to the commit checked out in the working directory.
+func gitCommit() string {
+	if buildInfo, ok := debug.ReadBuildInfo(); ok {
+		var revision, modified string
+		for _, setting := range buildInfo.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				revision = setting.Value
+			case "vcs.modified":
+				modified = setting.Value
+			}
+		}
+		if revision != "" {
+			if modified == "true" {
+				return revision + "-dirty"
+			}
+			return revision
+		}
+	}
+
+	revision, err := exec.Command("git", "rev-parse", "HEAD").Output()
+	if err != nil {
+		log.Warnf("Could not get the git commit of the client: %s", err.Error())
+		return ""
+	}
+	if status, err := exec.Command("git", "status", "--porcelain").Output(); err == nil && len(status) > 0 {
+		return strings.TrimSpace(string(revision)) + "-dirty"
+	}
+	return strings.TrimSpace(string(revision))
+}
@@ -26,6 +26,7 @@ package setup
 
 import (
 	"fmt"
+	"go.opentelemetry.io/otel/attribute"
 	"math"
 	"os"
 	"os/exec"
@@ -35,6 +36,7 @@ import (
 	"stellar/setup/deployment/connection"
 	"stellar/setup/deployment/connection/amazon"
 	"stellar/setup/deployment/packaging"
+	"stellar/tracing"
 	"stellar/util"
 	"sync"
 	"time"
@@ -43,36 +45,13 @@ import (
 )
 
 // ProvisionFunctions will deploy, reconfigure, etc. functions to get ready for the sub-experiments.
-func ProvisionFunctions(config Configuration) {
-	const (
-		nicContentionWarnThreshold = 800 // Experimentally found
-		storageSpaceWarnThreshold  = 500 // 500 * ~18KiB = 10MB just for 1 sub-experiment
-	)
+func ProvisionFunctions(config Configuration, runner util.CommandRunner) {
+	checkInitSimulation(&config, false)
 
+	discoverySpan := tracing.StartStep("discover endpoints", attribute.String("stellar.provider", config.Provider))
 	availableEndpoints := connection.Singleton.ListAPIs()
 
-	for index, subExperiment := range config.SubExperiments {
-		config.SubExperiments[index].ID = index
-
-		for _, burstSize := range subExperiment.BurstSizes {
-			if burstSize > nicContentionWarnThreshold {
-				log.Warnf("Experiment %d has a burst of size %d, NIC (Network Interface Controller) contention may occur.",
-					index, burstSize)
-				if !promptForBool("Do you wish to continue?") {
-					os.Exit(0)
-				}
-			}
-		}
-
-		if subExperiment.Bursts >= storageSpaceWarnThreshold &&
-			(subExperiment.Visualization == "all" || subExperiment.Visualization == "histogram") {
-			log.Warnf("SubExperiment %d is generating histograms for each burst, this will create a large number (%d) of new files (>10MB).",
-				index, subExperiment.Bursts)
-			if !promptForBool("Do you wish to continue?") {
-				os.Exit(0)
-			}
-		}
-
+	for index := range config.SubExperiments {
 		if availableEndpoints == nil { // hostname must be the endpoint itself (external URL)
 			config.SubExperiments[index].Endpoints = []EndpointInfo{{ID: config.Provider}}
 			continue
@@ -82,8 +61,10 @@ func ProvisionFunctions(config Configuration) {
 			availableEndpoints,
 			&config.SubExperiments[index],
 			config.Provider,
+			runner,
 		)
 	}
+	discoverySpan.End()
 
 	if amazon.AWSSingletonInstance != nil && amazon.AWSSingletonInstance.ImageURI != "" {
 		log.Info("A deployment was made using container images, waiting 10 seconds for changes to take effect with the provider...")
@@ -91,81 +72,102 @@ func ProvisionFunctions(config Configuration) {
 	}
 }
 
-// ProvisionFunctionsServerless will deploy, reconfigure, etc. functions to get ready for the sub-experiments.
-func ProvisionFunctionsServerless(config *Configuration, serverlessDirPath string) {
+// ProvisionFunctionsServerless will deploy, reconfigure, etc. functions to get ready for the sub-experiments. The
+// commands building and deploying the functions are run through the runner.
+func ProvisionFunctionsServerless(config *Configuration, serverlessDirPath string, runner util.CommandRunner) {
+	checkInitSimulation(config, true)
+
 	switch config.Provider {
 	case "aws":
-		ProvisionFunctionsServerlessAWS(config, serverlessDirPath)
+		if DeployAWSWithSDK {
+			ProvisionFunctionsAWSSDK(config, serverlessDirPath, runner)
+			return
+		}
+		ProvisionFunctionsServerlessAWS(config, serverlessDirPath, runner)
 	case "azure":
-		ProvisionFunctionsServerlessAzure(config, serverlessDirPath)
+		ProvisionFunctionsServerlessAzure(config, serverlessDirPath, runner)
 	case "gcr":
-		ProvisionFunctionsGCR(config, serverlessDirPath)
+		ProvisionFunctionsGCR(config, serverlessDirPath, runner)
 	case "cloudflare":
-		ProvisionFunctionsCloudflare(config, serverlessDirPath)
+		ProvisionFunctionsCloudflare(config, serverlessDirPath, runner)
 	case "aliyun":
-		ProvisionFunctionsServerlessAlibaba(config, serverlessDirPath)
+		ProvisionFunctionsServerlessAlibaba(config, serverlessDirPath, runner)
 	default:
 		log.Fatalf("Provider %s not supported for deployment", config.Provider)
 	}
 }
 
+func checkInitSimulation(config *Configuration, serverless bool) {
+	for index := range config.SubExperiments {
+		if err := config.SubExperiments[index].CheckInitSimulation(config.Provider, serverless); err != nil {
+			log.Fatalf("Cannot honour the init settings: %s.", err.Error())
+		}
+	}
+}
+
 // ProvisionFunctionsServerlessAWS will deploy, reconfigure, etc. functions to get ready for the sub-experiments.
-func ProvisionFunctionsServerlessAWS(config *Configuration, serverlessDirPath string) {
-	slsConfig := &Serverless{}
-	builder := &building.Builder{}
+func ProvisionFunctionsServerlessAWS(config *Configuration, serverlessDirPath string, runner util.CommandRunner) {
+	slsConfig := &Serverless{Runner: runner}
+	builder := &building.Builder{Runner: runner}
 
 	randomTag := util.GenerateRandLowercaseLetters(5)
 	slsConfig.CreateHeaderConfig(config, fmt.Sprintf("STeLLAR-%s", randomTag))
 	slsConfig.packageIndividually()
 
+	// the archives are only needed until they are deployed
+	archiveDirectory, removeArchiveDirectory := packaging.NewArchiveDirectory()
+	defer removeArchiveDirectory()
+
 	for index, subExperiment := range config.SubExperiments {
-		//TODO: generate the code
 		code_generation.GenerateCode(subExperiment.Function, config.Provider)
 
 		// TODO: build the functions (Java and Golang)
-		artifactPathRelativeToServerlessConfigFile := builder.BuildFunction(config.Provider, subExperiment.Function, subExperiment.Runtime)
-		slsConfig.AddFunctionConfigAWS(&config.SubExperiments[index], index, randomTag, artifactPathRelativeToServerlessConfigFile)
+		builder.BuildFunction(config.Provider, subExperiment.Function, subExperiment.Runtime)
 
 		// generate filler files and zip used as Serverless artifacts
-		packaging.GenerateServerlessZIPArtifacts(subExperiment.ID, config.Provider, subExperiment.Runtime, subExperiment.Function, subExperiment.FunctionImageSizeMB)
+		artifactPath := packaging.GenerateServerlessZIPArtifacts(subExperiment.ID, config.Provider, subExperiment.Runtime, subExperiment.Function,
+			subExperiment.FunctionImageSizeMB, archiveDirectory)
+		slsConfig.AddFunctionConfigAWS(&config.SubExperiments[index], index, randomTag, artifactPath)
 	}
 
 	slsConfig.CreateServerlessConfigFile(fmt.Sprintf("%sserverless.yml", serverlessDirPath))
 
 	log.Infof("Starting functions deployment. Deploying %d functions to %s.", len(slsConfig.Functions), config.Provider)
-	slsDeployMessage := DeployService(serverlessDirPath)
+	slsDeployMessage := DeployService(serverlessDirPath, runner)
 	log.Info(slsDeployMessage)
 
 	// TODO: assign endpoints to subexperiments
 	// Get the endpoints by scraping the serverless deploy message.
 
+	discoverySpan := tracing.StartStep("discover endpoints", attribute.String("stellar.provider", config.Provider))
 	endpointID := GetAWSEndpointID(slsDeployMessage)
 
 	// Assign Endpoint ID to each deployed function
 	for i := range config.SubExperiments {
 		config.SubExperiments[i].AssignEndpointIDs(endpointID)
 	}
+	discoverySpan.End()
 
 }
 
-func ProvisionFunctionsServerlessAzure(config *Configuration, serverlessDirPath string) {
+func ProvisionFunctionsServerlessAzure(config *Configuration, serverlessDirPath string, runner util.CommandRunner) {
 	randomExperimentTag := util.GenerateRandLowercaseLetters(5)
 
 	for subExperimentIndex, subExperiment := range config.SubExperiments {
 		code_generation.GenerateCode(subExperiment.Function, config.Provider)
 
-		builder := &building.Builder{}
+		builder := &building.Builder{Runner: runner}
 		builder.BuildFunction(config.Provider, subExperiment.Function, subExperiment.Runtime)
 
 		if config.SubExperiments[subExperimentIndex].Endpoints == nil {
 			config.SubExperiments[subExperimentIndex].Endpoints = []EndpointInfo{}
 		}
 
-		deploySubExperimentParallelismInBatches(config, serverlessDirPath, randomExperimentTag, subExperimentIndex, 1)
+		deploySubExperimentParallelismInBatches(config, serverlessDirPath, randomExperimentTag, subExperimentIndex, 1, runner)
 	}
 }
 
-func deploySubExperimentParallelismInBatches(config *Configuration, serverlessDirPath string, randomExperimentTag string, subExperimentIndex int, functionsPerBatch int) {
+func deploySubExperimentParallelismInBatches(config *Configuration, serverlessDirPath string, randomExperimentTag string, subExperimentIndex int, functionsPerBatch int, runner util.CommandRunner) {
 	subExperiment := config.SubExperiments[subExperimentIndex]
 
 	numberOfBatches := int(math.Ceil(float64(subExperiment.Parallelism) / float64(functionsPerBatch)))
@@ -188,7 +190,7 @@ func deploySubExperimentParallelismInBatches(config *Configuration, serverlessDi
 				if err := os.MkdirAll(deploymentDir, os.ModePerm); err != nil {
 					log.Fatalf("Error creating pre-deployment directory for function %s: %s", subExperiment.Function, err.Error())
 				}
-				util.RunCommandAndLog(exec.Command("cp", artifactsPath, deploymentDir))
+				util.RunCommandAndLog(exec.Command("cp", artifactsPath, deploymentDir), runner)
 
 				deploymentCodePath := filepath.Join(deploymentDir, subExperiment.PackagePattern)
 				currentSizeInBytes := packaging.GetZippedBinaryFileSize(subExperiment.ID, deploymentCodePath)
@@ -198,7 +200,7 @@ func deploySubExperimentParallelismInBatches(config *Configuration, serverlessDi
 				fillerFilePath := filepath.Join(deploymentDir, "filler.file")
 				packaging.GenerateFillerFile(subExperiment.ID, fillerFilePath, fillerFileSize)
 
-				slsConfig := &Serverless{}
+				slsConfig := &Serverless{Runner: runner}
 				slsConfig.CreateHeaderConfig(config, fmt.Sprintf("%s-subex%d-para%d", randomExperimentTag, subExperimentIndex, parallelism))
 				slsConfig.addPlugin("serverless-azure-functions")
 				name := createName(&subExperiment, subExperimentIndex, parallelism)
@@ -206,7 +208,7 @@ func deploySubExperimentParallelismInBatches(config *Configuration, serverlessDi
 				slsConfig.CreateServerlessConfigFile(filepath.Join(deploymentDir, "serverless.yml"))
 
 				log.Infof("Starting functions deployment. Deploying %d functions to %s.", len(slsConfig.Functions), config.Provider)
-				slsDeployMessage := DeployService(deploymentDir)
+				slsDeployMessage := DeployService(deploymentDir, runner)
 
 				endpointID := GetAzureEndpointID(slsDeployMessage)
 				mu.Lock()
@@ -224,21 +226,18 @@ func deploySubExperimentParallelismInBatches(config *Configuration, serverlessDi
 	}
 }
 
-func ProvisionFunctionsGCR(config *Configuration, serverlessDirPath string) {
-	slsConfig := &Serverless{}
+func ProvisionFunctionsGCR(config *Configuration, serverlessDirPath string, runner util.CommandRunner) {
+	slsConfig := &Serverless{Runner: runner}
 	slsConfig.CreateHeaderConfig(config, "STeLLAR-GCR")
 
 	for index, subExperiment := range config.SubExperiments {
+		code_generation.GenerateCode(subExperiment.Function, config.Provider)
+
 		switch subExperiment.PackageType {
 		case "Container":
-			// size of compressed images of GCR functions on Docker Hub are experimentally found to be approximately 21.84 MiB
-			currentSizeInBytes := util.MebibyteToBytes(21.84)
-			targetSizeInBytes := util.MebibyteToBytes(subExperiment.FunctionImageSizeMB)
-			fillerFileSize := packaging.CalculateFillerFileSizeInBytes(currentSizeInBytes, targetSizeInBytes)
-			fillerFilePath := filepath.Join(serverlessDirPath, subExperiment.Function, "filler.file")
-			packaging.GenerateFillerFile(subExperiment.ID, fillerFilePath, fillerFileSize)
-
-			imageLink := packaging.SetupContainerImageDeployment(subExperiment.Function, config.Provider, subExperiment.FunctionImageSizeMB)
+			imageLink, imageSize := packaging.SetupContainerImageDeployment(subExperiment.ID, subExperiment.Function, config.Provider,
+				filepath.Join(serverlessDirPath, subExperiment.Function), subExperiment.FunctionImageSizeMB, runner)
+			config.SubExperiments[index].ImageSize = imageSize
 			randomTag := util.GenerateRandLowercaseLetters(5)
 			slsConfig.DeployGCRContainerService(&config.SubExperiments[index], index, randomTag, imageLink, serverlessDirPath, slsConfig.Provider.Region)
 		default:
@@ -247,18 +246,18 @@ func ProvisionFunctionsGCR(config *Configuration, serverlessDirPath string) {
 	}
 }
 
-func ProvisionFunctionsCloudflare(config *Configuration, serverlessDirPath string) {
+func ProvisionFunctionsCloudflare(config *Configuration, serverlessDirPath string, runner util.CommandRunner) {
 	for index := range config.SubExperiments {
 		randomTag := util.GenerateRandLowercaseLetters(5)
-		DeployCloudflareWorkers(&config.SubExperiments[index], index, randomTag, serverlessDirPath)
+		DeployCloudflareWorkers(&config.SubExperiments[index], index, randomTag, serverlessDirPath, runner)
 	}
 }
 
-func ProvisionFunctionsServerlessAlibaba(config *Configuration, serverlessDirPath string) {
+func ProvisionFunctionsServerlessAlibaba(config *Configuration, serverlessDirPath string, runner util.CommandRunner) {
 	for index, subExperiment := range config.SubExperiments {
 		code_generation.GenerateCode(subExperiment.Function, config.Provider)
 
-		builder := &building.Builder{}
+		builder := &building.Builder{Runner: runner}
 		builder.BuildFunction(config.Provider, subExperiment.Function, subExperiment.Runtime)
 
 		preDeploymentDir := fmt.Sprintf("setup/deployment/raw-code/serverless/%s/sub-experiment-%d", config.Provider, index)
@@ -266,16 +265,16 @@ func ProvisionFunctionsServerlessAlibaba(config *Configuration, serverlessDirPat
 			log.Fatalf("Error creating pre-deployment directory for function %s: %s", subExperiment.Function, err.Error())
 		}
 		artifactsPath := fmt.Sprintf("setup/deployment/raw-code/serverless/%s/artifacts/%s/main.py", config.Provider, subExperiment.Function)
-		util.RunCommandAndLog(exec.Command("cp", artifactsPath, preDeploymentDir))
+		util.RunCommandAndLog(exec.Command("cp", artifactsPath, preDeploymentDir), runner)
 
-		slsConfig := &Serverless{}
+		slsConfig := &Serverless{Runner: runner}
 		slsConfig.CreateHeaderConfig(config, fmt.Sprintf("stellar-aliyun-subex%d", index))
 		slsConfig.addPlugin("serverless-aliyun-function-compute")
 		slsConfig.AddFunctionConfigAlibaba(&config.SubExperiments[index], index, "")
 		slsConfig.CreateServerlessConfigFile(fmt.Sprintf("%s/sub-experiment-%d/serverless.yml", serverlessDirPath, index))
 
 		log.Infof("Starting functions deployment. Deploying %d functions to %s.", len(slsConfig.Functions), config.Provider)
-		slsDeployMessage := DeployService(fmt.Sprintf("%ssub-experiment-%d", serverlessDirPath, index))
+		slsDeployMessage := DeployService(fmt.Sprintf("%ssub-experiment-%d", serverlessDirPath, index), runner)
 
 		endpointID := GetAlibabaEndpointID(slsDeployMessage)
 		config.SubExperiments[index].AssignEndpointIDs(endpointID)
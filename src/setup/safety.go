@@ -0,0 +1,174 @@
+// MIT License
+//
+// Copyright (c) 2021 Theodor Amariucai and EASE Lab
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package setup
+
+import (
+	log "github.com/sirupsen/logrus"
+	"math"
+	"os"
+)
+
+const (
+	// SafetyActionPrompt asks whether to continue, unless the prompt mode answers on the user's behalf
+	SafetyActionPrompt = "prompt"
+	// SafetyActionFatal aborts the run
+	SafetyActionFatal = "fatal"
+	// SafetyActionIgnore skips the check altogether
+	SafetyActionIgnore = "ignore"
+	// SafetyActionAccept logs the warning and continues
+	SafetyActionAccept = "accept"
+)
+
+const (
+	defaultNICContentionBurstSize = 800 // Experimentally found
+	defaultHistogramFilesBursts   = 500 // 500 * ~18KiB = 10MB just for 1 sub-experiment
+)
+
+// supportedSafetyActions are the ways a safety warning can be resolved
+var supportedSafetyActions = map[string]bool{
+	SafetyActionPrompt: true, SafetyActionFatal: true, SafetyActionIgnore: true, SafetyActionAccept: true,
+}
+
+// PromptMode controls how safety warnings configured to prompt are answered
+type PromptMode int
+
+const (
+	// PromptInteractive asks on the standard input
+	PromptInteractive PromptMode = iota
+	// PromptAssumeYes continues without asking
+	PromptAssumeYes
+	// PromptDisabled aborts without asking
+	PromptDisabled
+)
+
+// Safety configures the thresholds of the checks made before deployment and the action taken when they are exceeded.
+type Safety struct {
+	NICContentionBurstSize int    `json:"NICContentionBurstSize"`
+	NICContention          string `json:"NICContention"`
+	HistogramFilesBursts   int    `json:"HistogramFilesBursts"`
+	HistogramFiles         string `json:"HistogramFiles"`
+}
+
+func applySafetyDefaults(safety *Safety) {
+	if safety.NICContentionBurstSize == 0 {
+		safety.NICContentionBurstSize = defaultNICContentionBurstSize
+	}
+	if safety.HistogramFilesBursts == 0 {
+		safety.HistogramFilesBursts = defaultHistogramFilesBursts
+	}
+	for _, action := range []*string{&safety.NICContention, &safety.HistogramFiles} {
+		if *action == "" {
+			*action = SafetyActionPrompt
+		}
+		if !supportedSafetyActions[*action] {
+			log.Fatalf("Unsupported safety action `%s`, expected one of prompt, fatal, ignore or accept.", *action)
+		}
+	}
+}
+
+// CheckSafety warns about sub-experiments which may disturb the measurements or the client host, and resolves each
+// warning as configured in the Safety section of the configuration. Bursts are split evenly across the client hosts.
+func CheckSafety(config *Configuration, mode PromptMode, clientHosts int) {
+	safety := config.Safety
+
+	for index, subExperiment := range config.SubExperiments {
+		for _, burstSize := range subExperiment.BurstSizes {
+			burstSize = int(math.Ceil(float64(burstSize) / float64(clientHosts)))
+			if burstSize > safety.NICContentionBurstSize {
+				resolveSafetyWarning(safetyWarning{
+					check:           "nic_contention",
+					subExperimentID: index,
+					value:           burstSize,
+					threshold:       safety.NICContentionBurstSize,
+					action:          safety.NICContention,
+					message:         "Sub-experiment %d has a burst of size %d per client host, NIC (Network Interface Controller) contention may occur.",
+				}, mode)
+			}
+		}
+
+		if subExperiment.Bursts >= safety.HistogramFilesBursts &&
+			(subExperiment.Visualization == "all" || subExperiment.Visualization == "histogram") {
+			resolveSafetyWarning(safetyWarning{
+				check:           "histogram_files",
+				subExperimentID: index,
+				value:           subExperiment.Bursts,
+				threshold:       safety.HistogramFilesBursts,
+				action:          safety.HistogramFiles,
+				message:         "Sub-experiment %d is generating histograms for each burst, this will create a large number (%d) of new files (>10MB).",
+			}, mode)
+		}
+	}
+}
+
+// safetyWarning is a safety check exceeded by a sub-experiment. The message is formatted with the sub-experiment ID
+// and the offending value.
+type safetyWarning struct {
+	check           string
+	subExperimentID int
+	value           int
+	threshold       int
+	action          string
+	message         string
+}
+
+// resolveSafetyWarning applies the action of the warning and emits a machine-readable log line with the decision
+func resolveSafetyWarning(warning safetyWarning, mode PromptMode) {
+	decision := log.WithFields(log.Fields{
+		"safety_check":   warning.check,
+		"sub_experiment": warning.subExperimentID,
+		"value":          warning.value,
+		"threshold":      warning.threshold,
+		"action":         warning.action,
+	})
+
+	if warning.action == SafetyActionIgnore {
+		decision.WithFields(log.Fields{"decision": "continue", "decided_by": "config"}).Info("Safety decision")
+		return
+	}
+	log.Warnf(warning.message, warning.subExperimentID, warning.value)
+
+	proceed, decidedBy := true, "config"
+	switch warning.action {
+	case SafetyActionFatal:
+		proceed = false
+	case SafetyActionPrompt:
+		switch mode {
+		case PromptAssumeYes:
+			decidedBy = "flag"
+		case PromptDisabled:
+			proceed, decidedBy = false, "flag"
+		default:
+			proceed, decidedBy = promptForBool("Do you wish to continue?"), "user"
+		}
+	}
+
+	if proceed {
+		decision.WithFields(log.Fields{"decision": "continue", "decided_by": decidedBy}).Info("Safety decision")
+		return
+	}
+	decision.WithFields(log.Fields{"decision": "abort", "decided_by": decidedBy}).Error("Safety decision")
+	if decidedBy == "user" {
+		os.Exit(0)
+	}
+	os.Exit(1)
+}
@@ -3,11 +3,14 @@ package setup
 import (
 	"fmt"
 	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
 	"gopkg.in/yaml.v3"
 	"math"
 	"os"
 	"os/exec"
 	"regexp"
+	"sort"
+	"stellar/tracing"
 	"stellar/util"
 	"strings"
 	"sync"
@@ -16,6 +19,8 @@ import (
 
 // Serverless describes the serverless.yml contents.
 type Serverless struct {
+	// Runner runs the deployment commands, an ExecRunner being used if nil
+	Runner           util.CommandRunner   `yaml:"-"`
 	Service          string               `yaml:"service"`
 	FrameworkVersion string               `yaml:"frameworkVersion"`
 	Provider         Provider             `yaml:"provider"`
@@ -47,6 +52,8 @@ type Function struct {
 	Events    []Event         `yaml:"events"`
 	Package   FunctionPackage `yaml:"package"`
 	SnapStart bool            `yaml:"snapStart,omitempty"`
+	// Environment holds the variables configuring the function initialization, e.g., the desired init time
+	Environment map[string]string `yaml:"environment,omitempty"`
 }
 
 type FunctionPackage struct {
@@ -203,7 +210,7 @@ func (s *Serverless) AddFunctionConfigAWS(subex *SubExperiment, index int, rando
 			},
 		}
 
-		f := &Function{Handler: handler, Runtime: runtime, Name: name, Events: events}
+		f := &Function{Handler: handler, Runtime: runtime, Name: name, Events: events, Environment: subex.FunctionEnvironment()}
 		f.AddPackagePattern(subex.PackagePattern)
 		if artifactPath != "" {
 			f.Package.Artifact = artifactPath
@@ -239,7 +246,7 @@ func (s *Serverless) AddFunctionConfigAzure(subex *SubExperiment, index int, nam
 		},
 	}
 
-	function := &Function{Handler: handler, Runtime: runtime, Name: name, Events: events}
+	function := &Function{Handler: handler, Runtime: runtime, Name: name, Events: events, Environment: subex.FunctionEnvironment()}
 	function.AddPackagePattern(subex.PackagePattern)
 	s.Functions[name] = function
 }
@@ -267,7 +274,7 @@ func (s *Serverless) AddFunctionConfigAlibaba(subex *SubExperiment, index int, a
 			},
 		}
 
-		function := &Function{Handler: handler, Runtime: runtime, Name: name, Events: events}
+		function := &Function{Handler: handler, Runtime: runtime, Name: name, Events: events, Environment: subex.FunctionEnvironment()}
 		function.AddPackagePattern(subex.PackagePattern)
 		s.Functions[name] = function
 		subex.AddRoute(name)
@@ -293,22 +300,25 @@ func (s *Serverless) CreateServerlessConfigFile(path string) {
 	}
 }
 
-// RemoveService removes the services created by experiments
-func RemoveService(config *Configuration, path string) string {
+// RemoveService removes the services created by experiments, running the commands through the runner
+func RemoveService(config *Configuration, path string, runner util.CommandRunner) string {
 	switch config.Provider {
 	case "aws":
-		return RemoveServerlessService(path)
+		if awsHTTPAPIDeployment != nil {
+			return RemoveAWSSDKService()
+		}
+		return RemoveServerlessService(path, runner)
 	case "azure":
-		RemoveAzureAllServices(config.SubExperiments, path)
+		RemoveAzureAllServices(config.SubExperiments, path, runner)
 		return "All Azure services removed."
 	case "gcr":
-		RemoveGCRAllServices(config.SubExperiments)
+		RemoveGCRAllServices(config.SubExperiments, runner)
 		return "All GCR services deleted."
 	case "cloudflare":
-		RemoveCloudflareAllWorkers(config.SubExperiments)
+		RemoveCloudflareAllWorkers(config.SubExperiments, runner)
 		return "All Cloudflare Workers deleted."
 	case "aliyun":
-		RemoveAlibabaAllServices(path, len(config.SubExperiments))
+		RemoveAlibabaAllServices(path, len(config.SubExperiments), runner)
 		return "All Alibaba Cloud services removed."
 	default:
 		// 25.09 error correction
@@ -319,45 +329,45 @@ func RemoveService(config *Configuration, path string) string {
 }
 
 // RemoveServerlessService removes a service that was deployed using the Serverless framework
-func RemoveServerlessService(path string) string {
+func RemoveServerlessService(path string, runner util.CommandRunner) string {
 	// 25.09 update to correct syntax issue logrus
 	// log.Infof(fmt.Sprintf("Removing Serverless service at %s", path))
 	log.Infof("Removing Serverless service at %s", path)	
 	slsRemoveCmd := exec.Command("sls", "remove")
 	slsRemoveCmd.Dir = path
-	slsRemoveCmdOutput := util.RunCommandAndLogWithRetries(slsRemoveCmd, 3)
+	slsRemoveCmdOutput := util.RunCommandAndLogWithRetries(slsRemoveCmd, 3, runner)
 
-	util.RunCommandAndLog(exec.Command("rm", fmt.Sprintf("%sserverless.yml", path)))
+	util.RunCommandAndLog(exec.Command("rm", fmt.Sprintf("%sserverless.yml", path)), runner)
 
 	return slsRemoveCmdOutput
 }
 
 // RemoveServerlessServiceForcefully forcefully removes a service that was deployed using the Serverless framework
-func RemoveServerlessServiceForcefully(path string) string {
+func RemoveServerlessServiceForcefully(path string, runner util.CommandRunner) string {
 	// 25.09 update to correct syntax issue logrus
 	// log.Infof(fmt.Sprintf("Removing Serverless service at %s", path))
 	log.Infof("Removing Serverless service at %s", path)	
 	slsRemoveCmd := exec.Command("sls", "remove", "--force")
 	slsRemoveCmd.Dir = path
-	slsRemoveCmdOutput := util.RunCommandAndLogWithRetries(slsRemoveCmd, 3)
+	slsRemoveCmdOutput := util.RunCommandAndLogWithRetries(slsRemoveCmd, 3, runner)
 
 	deleteSlsConfigFileCmd := exec.Command("rm", "serverless.yml")
 	deleteSlsConfigFileCmd.Dir = path
-	util.RunCommandAndLog(deleteSlsConfigFileCmd)
+	util.RunCommandAndLog(deleteSlsConfigFileCmd, runner)
 
 	return slsRemoveCmdOutput
 }
 
 // RemoveAzureAllServices removes all Azure services
-func RemoveAzureAllServices(subExperiments []SubExperiment, path string) []string {
+func RemoveAzureAllServices(subExperiments []SubExperiment, path string, runner util.CommandRunner) []string {
 	var removeServiceMessages []string
 	for subExperimentIndex, subExperiment := range subExperiments {
-		removeSubExperimentParallelismInBatches(path, subExperimentIndex, subExperiment, removeServiceMessages, 3)
+		removeSubExperimentParallelismInBatches(path, subExperimentIndex, subExperiment, removeServiceMessages, 3, runner)
 	}
 	return removeServiceMessages
 }
 
-func removeSubExperimentParallelismInBatches(path string, subExperimentIndex int, subExperiment SubExperiment, removeServiceMessages []string, functionsPerBatch int) {
+func removeSubExperimentParallelismInBatches(path string, subExperimentIndex int, subExperiment SubExperiment, removeServiceMessages []string, functionsPerBatch int, runner util.CommandRunner) {
 	numberOfBatches := int(math.Ceil(float64(subExperiment.Parallelism) / float64(functionsPerBatch)))
 
 	for batchNumber := 0; batchNumber < numberOfBatches; batchNumber++ {
@@ -371,7 +381,7 @@ func removeSubExperimentParallelismInBatches(path string, subExperimentIndex int
 				defer wg.Done()
 
 				deploymentDir := fmt.Sprintf("%ssub-experiment-%d/parallelism-%d", path, subExperimentIndex, parallelism)
-				slsRemoveCmdOutput := RemoveServerlessServiceForcefully(deploymentDir)
+				slsRemoveCmdOutput := RemoveServerlessServiceForcefully(deploymentDir, runner)
 				mu.Lock()
 				defer mu.Unlock()
 				removeServiceMessages = append(removeServiceMessages, slsRemoveCmdOutput)
@@ -382,99 +392,116 @@ func removeSubExperimentParallelismInBatches(path string, subExperimentIndex int
 }
 
 // RemoveGCRAllServices removes all GCR services defined in the Subexperiment array
-func RemoveGCRAllServices(subExperiments []SubExperiment) []string {
+func RemoveGCRAllServices(subExperiments []SubExperiment, runner util.CommandRunner) []string {
 	var deleteServiceMessages []string
 	for _, functionName := range providerFunctionNames["gcr"] {
-		deleteMsg := RemoveGCRSingleService(functionName)
+		deleteMsg := RemoveGCRSingleService(functionName, runner)
 		deleteServiceMessages = append(deleteServiceMessages, deleteMsg)
 	}
+	delete(providerFunctionNames, "gcr")
 	return deleteServiceMessages
 }
 
 // RemoveGCRSingleService removes a single GCR service
-func RemoveGCRSingleService(service string) string {
+func RemoveGCRSingleService(service string, runner util.CommandRunner) string {
 	log.Infof("Deleting GCR service %s...", service)
 	deleteServiceCommand := exec.Command("gcloud", "run", "services", "delete", "--quiet", "--region", GCR_DEFAULT_REGION, service)
-	deleteMessage := util.RunCommandAndLog(deleteServiceCommand)
+	deleteMessage := util.RunCommandAndLog(deleteServiceCommand, runner)
 	return deleteMessage
 }
 
 // RemoveCloudflareAllWorkers removes all Cloudflare Workers
-func RemoveCloudflareAllWorkers(subExperiments []SubExperiment) []string {
+func RemoveCloudflareAllWorkers(subExperiments []SubExperiment, runner util.CommandRunner) []string {
 	log.Infof("Removing Cloudflare Workers...")
 	var removeServiceMessages []string
 	for _, functionName := range providerFunctionNames["cloudflare"] {
-		removeMessage := RemoveCloudflareSingleWorker(functionName)
+		removeMessage := RemoveCloudflareSingleWorker(functionName, runner)
 		removeServiceMessages = append(removeServiceMessages, removeMessage)
 	}
+	delete(providerFunctionNames, "cloudflare")
 	return removeServiceMessages
 }
 
 // RemoveCloudflareSingleWorker removes a single Cloudflare Worker specified by name
-func RemoveCloudflareSingleWorker(workerName string) string {
+func RemoveCloudflareSingleWorker(workerName string, runner util.CommandRunner) string {
 	log.Infof("Removing Cloudflare Worker %s...", workerName)
 	removeWorkerCommand := exec.Command("wrangler", "delete", "--name", workerName, "--force")
-	removeMessage := util.RunCommandAndLog(removeWorkerCommand)
+	removeMessage := util.RunCommandAndLog(removeWorkerCommand, runner)
 	return removeMessage
 }
 
 // RemoveAlibabaAllServices removes all Alibaba Cloud services
-func RemoveAlibabaAllServices(path string, numSubExperiments int) []string {
+func RemoveAlibabaAllServices(path string, numSubExperiments int, runner util.CommandRunner) []string {
 	alibabaCloudAccountId := os.Getenv("ALIYUN_ACCOUNT_ID")
 	if alibabaCloudAccountId == "" {
 		alibabaCloudAccountId = ALIBABA_DEFAULT_ACCOUNT_ID
 	}
 	nameOfBucketToDelete := fmt.Sprintf("oss://sls-%s-%s", alibabaCloudAccountId, ALIBABA_DEFAULT_REGION)
-	util.RunCommandAndLog(exec.Command("aliyun", "oss", "rm", "--bucket", "--recursive", "--force", nameOfBucketToDelete))
+	util.RunCommandAndLog(exec.Command("aliyun", "oss", "rm", "--bucket", "--recursive", "--force", nameOfBucketToDelete), runner)
 
 	var removeServiceMessages []string
 	for i := 0; i < numSubExperiments; i++ {
 		subExPath := fmt.Sprintf("%ssub-experiment-%d/", path, i)
-		slsRemoveCmdOutput := RemoveServerlessService(subExPath)
+		slsRemoveCmdOutput := RemoveServerlessService(subExPath, runner)
 		removeServiceMessages = append(removeServiceMessages, slsRemoveCmdOutput)
 	}
 	return removeServiceMessages
 }
 
-// DeployService deploys the functions defined in the serverless.com file
-func DeployService(path string) string {
+// DeployService deploys the functions defined in the serverless.com file, running `sls deploy` through the runner
+func DeployService(path string, runner util.CommandRunner) string {
+	defer tracing.StartStep("deploy", attribute.String("stellar.path", path)).End()
+
 	// 25.09 update to correct syntax issue logrus	
 	// log.Infof(fmt.Sprintf("Deploying service at %s", path))
 	log.Infof("Deploying service at %s", path)	
 	slsDeployCmd := exec.Command("sls", "deploy")
 	slsDeployCmd.Dir = path
-	slsDeployMessage := util.RunCommandAndLogWithRetries(slsDeployCmd, 3)
+	slsDeployMessage := util.RunCommandAndLogWithRetries(slsDeployCmd, 3, runner)
 	return slsDeployMessage
 }
 
 // DeployGCRContainerService deploys a container service to cloud provider
 func (s *Serverless) DeployGCRContainerService(subex *SubExperiment, index int, randomTag string, imageLink string, path string, region string) {
 	log.Infof("Deploying container service(s) to GCR...")
+	defer tracing.StartStep("deploy", attribute.String("stellar.provider", "gcr"), attribute.Int("stellar.sub_experiment", index)).End()
 	for i := 0; i < subex.Parallelism; i++ {
 		name := fmt.Sprintf("%s-%s", randomTag, createName(subex, index, i))
 		providerFunctionNames["gcr"] = append(providerFunctionNames["gcr"], name) // Used for function removal
 
-		var gcrDeployCommand *exec.Cmd
+		gcrDeployArgs := []string{"run", "deploy", name, "--image", imageLink, "--allow-unauthenticated", "--region", region}
 		if subex.CPUBoostEnabled {
-			gcrDeployCommand = exec.Command("gcloud", "run", "deploy", name, "--image", imageLink, "--allow-unauthenticated", "--region", region, "--cpu-boost")
-		} else {
-			gcrDeployCommand = exec.Command("gcloud", "run", "deploy", name, "--image", imageLink, "--allow-unauthenticated", "--region", region)
+			gcrDeployArgs = append(gcrDeployArgs, "--cpu-boost")
+		}
+		if environment := subex.FunctionEnvironment(); environment != nil {
+			gcrDeployArgs = append(gcrDeployArgs, "--set-env-vars", gcrEnvironmentVariables(environment))
 		}
 
-		deployMessage := util.RunCommandAndLog(gcrDeployCommand)
+		deployMessage := util.RunCommandAndLog(exec.Command("gcloud", gcrDeployArgs...), s.Runner)
 		subex.Endpoints = append(subex.Endpoints, EndpointInfo{ID: GetGCREndpointID(deployMessage)})
 		subex.AddRoute("")
 	}
 }
 
-func DeployCloudflareWorkers(subex *SubExperiment, index int, randomTag string, path string) {
+// gcrEnvironmentVariables formats the environment in the KEY=VALUE list expected by `gcloud run deploy --set-env-vars`
+func gcrEnvironmentVariables(environment map[string]string) string {
+	variables := make([]string, 0, len(environment))
+	for key, value := range environment {
+		variables = append(variables, fmt.Sprintf("%s=%s", key, value))
+	}
+	sort.Strings(variables)
+	return strings.Join(variables, ",")
+}
+
+func DeployCloudflareWorkers(subex *SubExperiment, index int, randomTag string, path string, runner util.CommandRunner) {
 	log.Infof("Deploying Cloudflare Workers...")
+	defer tracing.StartStep("deploy", attribute.String("stellar.provider", "cloudflare"), attribute.Int("stellar.sub_experiment", index)).End()
 	for i := 0; i < subex.Parallelism; i++ {
 		name := fmt.Sprintf("%s-%s", randomTag, createName(subex, index, i))
 		providerFunctionNames["cloudflare"] = append(providerFunctionNames["cloudflare"], name) // Used for function removal
 
 		cloudFlareDeployCommand := exec.Command("wrangler", "deploy", fmt.Sprintf("%s/%s/%s", path, subex.Function, subex.Handler), "--name", name, "--compatibility-date", time.Now().Format("2006-01-02"))
-		deployMessage := util.RunCommandAndLog(cloudFlareDeployCommand)
+		deployMessage := util.RunCommandAndLog(cloudFlareDeployCommand, runner)
 		subex.Endpoints = append(subex.Endpoints, EndpointInfo{ID: GetCloudflareEndpointID(deployMessage)})
 		subex.AddRoute("")
 	}
@@ -0,0 +1,34 @@
+package setup
+
+import (
+	"github.com/stretchr/testify/require"
+	"stellar/setup"
+	"stellar/setup/deployment/connection/amazon"
+	"testing"
+)
+
+func TestAWSHTTPAPIFunctions(t *testing.T) {
+	subex := &setup.SubExperiment{
+		Title:            "snapstart",
+		Parallelism:      2,
+		Handler:          "bootstrap",
+		Runtime:          "go1.x",
+		FunctionMemoryMB: 256,
+		SnapStartEnabled: true,
+	}
+
+	functions := setup.AWSHTTPAPIFunctions(subex, 3, "abcde", "artifacts/hellogo/hellogo.zip")
+
+	require.Equal(t, []amazon.HTTPAPIFunction{
+		{Name: "abcde-snapstart-3-0", Route: "abcde-snapstart-3-0", Handler: "bootstrap", Runtime: "provided.al2023",
+			MemoryMB: 256, ZipPath: "artifacts/hellogo/hellogo.zip", SnapStart: true},
+		{Name: "abcde-snapstart-3-1", Route: "abcde-snapstart-3-1", Handler: "bootstrap", Runtime: "provided.al2023",
+			MemoryMB: 256, ZipPath: "artifacts/hellogo/hellogo.zip", SnapStart: true},
+	}, functions)
+	require.Equal(t, []string{"abcde-snapstart-3-0", "abcde-snapstart-3-1"}, subex.Routes)
+}
+
+func TestHTTPAPIDeploymentEndpointID(t *testing.T) {
+	deployment := amazon.HTTPAPIDeployment{APIEndpoint: "https://z4a0lmtx64.execute-api.us-west-1.amazonaws.com"}
+	require.Equal(t, "z4a0lmtx64", deployment.EndpointID())
+}
@@ -0,0 +1,42 @@
+package setup
+
+import (
+	"github.com/stretchr/testify/require"
+	"path/filepath"
+	"stellar/setup"
+	"testing"
+)
+
+func TestBusySpinCacheRoundTrip(t *testing.T) {
+	cachePath := filepath.Join(t.TempDir(), "busy-spin-cache.json")
+
+	cache := setup.LoadBusySpinCache(cachePath)
+	require.Empty(t, cache.Calibrations)
+
+	cache.Store(setup.BusySpinCalibration{Provider: "aws", Runtime: "python3.9", FunctionMemoryMB: 128, ServiceTime: "1000ms", IncrementLimit: 1})
+	cache.Store(setup.BusySpinCalibration{Provider: "aws", Runtime: "python3.9", FunctionMemoryMB: 128, ServiceTime: "1s", IncrementLimit: 2})
+	cache.Save()
+
+	calibration, ok := setup.LoadBusySpinCache(cachePath).Lookup("aws", "python3.9", 128, "1s")
+	require.True(t, ok)
+	require.Equal(t, int64(2), calibration.IncrementLimit)
+
+	_, ok = setup.LoadBusySpinCache(cachePath).Lookup("aws", "python3.9", 256, "1s")
+	require.False(t, ok)
+}
+
+func TestFindBusySpinIncrementsFromCache(t *testing.T) {
+	configPath := writeTestConfiguration(t, `{
+		"Runtime": "python3.9",
+		"SubExperiments": [{"Title": "legacy", "DesiredServiceTimes": ["10ms", "1s"], "SpinMode": "increment"}]
+	}`)
+	config := setup.ExtractConfiguration(configPath)
+
+	cache := setup.LoadBusySpinCache(filepath.Join(t.TempDir(), "busy-spin-cache.json"))
+	cache.Store(setup.BusySpinCalibration{Provider: "aws", Runtime: "python3.9", FunctionMemoryMB: 128, ServiceTime: "10ms", IncrementLimit: 100})
+	cache.Store(setup.BusySpinCalibration{Provider: "aws", Runtime: "python3.9", FunctionMemoryMB: 128, ServiceTime: "1s", IncrementLimit: 10000})
+
+	setup.FindBusySpinIncrements(&config, cache)
+
+	require.Equal(t, []int64{100, 10000}, config.SubExperiments[0].BusySpinIncrements)
+}
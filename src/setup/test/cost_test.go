@@ -0,0 +1,40 @@
+package setup
+
+import (
+	"github.com/stretchr/testify/require"
+	"stellar/setup"
+	"testing"
+	"time"
+)
+
+func TestPlannedCost(t *testing.T) {
+	configPath := writeTestConfiguration(t, `{
+		"Provider": "aws",
+		"SubExperiments": [{"Title": "planned", "Bursts": 4, "BurstSizes": [1, 3], "Parallelism": 2,
+			"DesiredServiceTimes": ["100ms"], "FunctionMemoryMB": 2048, "DataTransferChainLength": 2}]
+	}`)
+	config := setup.ExtractConfiguration(configPath)
+
+	estimate := config.SubExperiments[0].PlannedCost(config.PricingModel())
+
+	// Bursts 0 and 1 send one request, bursts 2 and 3 send three, each invoking two functions for 100ms with 2GB
+	require.Equal(t, 16., estimate.Usage.Requests)
+	require.Equal(t, 8., estimate.Usage.GatewayRequests)
+	require.InDelta(t, 3.2, estimate.Usage.GBSeconds, 1e-9)
+	require.InDelta(t, 16*0.2e-6+3.2*0.0000166667+8*3.5e-6, estimate.TotalUSD(), 1e-12)
+}
+
+func TestRecordedCostCustomPricing(t *testing.T) {
+	configPath := writeTestConfiguration(t, `{
+		"Provider": "aws",
+		"MaxBudgetUSD": 5,
+		"Pricing": {"GBSecondUSD": 1, "MinimumBilledMs": 100, "BillingGranularityMs": 100},
+		"SubExperiments": [{"Title": "recorded", "FunctionMemoryMB": 1024}]
+	}`)
+	config := setup.ExtractConfiguration(configPath)
+
+	estimate := config.SubExperiments[0].RecordedCost(config.PricingModel(), []float64{10, 150}, time.Minute)
+
+	require.Equal(t, 5., config.MaxBudgetUSD)
+	require.InDelta(t, 0.3, estimate.TotalUSD(), 1e-9)
+}
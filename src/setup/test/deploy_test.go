@@ -0,0 +1,110 @@
+package setup
+
+import (
+	"github.com/stretchr/testify/require"
+	"stellar/setup"
+	"stellar/util"
+	"testing"
+)
+
+func commandLines(fake *util.FakeRunner) []string {
+	var lines []string
+	for _, command := range fake.Commands() {
+		lines = append(lines, command.String())
+	}
+	return lines
+}
+
+func TestDeployAndRemoveServerlessServiceAWS(t *testing.T) {
+	fake := &util.FakeRunner{}
+	fake.ReplyFromFile("sls deploy", "fixtures/sls-deploy-aws.txt")
+
+	deployMessage := setup.DeployService("serverless/aws/", fake)
+	setup.RemoveServerlessService("serverless/aws/", fake)
+
+	require.Equal(t, "z4a0lmtx64", setup.GetAWSEndpointID(deployMessage))
+	require.Equal(t, []util.RecordedCommand{
+		{Args: []string{"sls", "deploy"}, Dir: "serverless/aws/"},
+		{Args: []string{"sls", "remove"}, Dir: "serverless/aws/"},
+		{Args: []string{"rm", "serverless/aws/serverless.yml"}},
+	}, fake.Commands())
+}
+
+func TestDeployAndRemoveGCRContainerServices(t *testing.T) {
+	fake := &util.FakeRunner{}
+	fake.ReplyFromFile("gcloud run deploy", "fixtures/gcloud-run-deploy.txt")
+	config := &setup.Configuration{Provider: "gcr", SubExperiments: []setup.SubExperiment{{Title: "hellogcr", Parallelism: 2, CPUBoostEnabled: true}}}
+
+	serverless := &setup.Serverless{Runner: fake}
+	serverless.DeployGCRContainerService(&config.SubExperiments[0], 0, "abcde", "docker.io/stellar/hellogcr:latest", "", "us-west1")
+	setup.RemoveService(config, "", fake)
+
+	require.Equal(t, []setup.EndpointInfo{
+		{ID: "abcde-hellogcr-0-0-nfjrndgaha-uw.a.run.app"},
+		{ID: "abcde-hellogcr-0-0-nfjrndgaha-uw.a.run.app"},
+	}, config.SubExperiments[0].Endpoints)
+	require.Equal(t, []string{
+		"gcloud run deploy abcde-hellogcr-0-0 --image docker.io/stellar/hellogcr:latest --allow-unauthenticated --region us-west1 --cpu-boost",
+		"gcloud run deploy abcde-hellogcr-0-1 --image docker.io/stellar/hellogcr:latest --allow-unauthenticated --region us-west1 --cpu-boost",
+		"gcloud run services delete --quiet --region us-west1 abcde-hellogcr-0-0",
+		"gcloud run services delete --quiet --region us-west1 abcde-hellogcr-0-1",
+	}, commandLines(fake))
+}
+
+func TestDeployGCRContainerServiceWithEnvironment(t *testing.T) {
+	fake := &util.FakeRunner{}
+	fake.ReplyFromFile("gcloud run deploy", "fixtures/gcloud-run-deploy.txt")
+	config := &setup.Configuration{Provider: "gcr", SubExperiments: []setup.SubExperiment{
+		{Title: "hellogcr", Parallelism: 1, DesiredInitTime: "1s", InitMemoryMB: 64},
+	}}
+
+	serverless := &setup.Serverless{Runner: fake}
+	serverless.DeployGCRContainerService(&config.SubExperiments[0], 0, "abcde", "docker.io/stellar/hellogcr:latest", "", "us-west1")
+	setup.RemoveService(config, "", fake)
+
+	require.Equal(t, []string{
+		"gcloud run deploy abcde-hellogcr-0-0 --image docker.io/stellar/hellogcr:latest --allow-unauthenticated --region us-west1 --set-env-vars INIT_MEMORY_MB=64,INIT_TIME_MS=1000",
+		"gcloud run services delete --quiet --region us-west1 abcde-hellogcr-0-0",
+	}, commandLines(fake))
+}
+
+func TestDeployAndRemoveCloudflareWorkers(t *testing.T) {
+	fake := &util.FakeRunner{}
+	fake.ReplyFromFile("wrangler deploy", "fixtures/wrangler-deploy.txt")
+	config := &setup.Configuration{Provider: "cloudflare", SubExperiments: []setup.SubExperiment{
+		{Title: "hellonode", Function: "hellonode", Handler: "index.js", Parallelism: 1},
+	}}
+
+	setup.DeployCloudflareWorkers(&config.SubExperiments[0], 0, "abcde", "serverless/cloudflare", fake)
+	setup.RemoveService(config, "", fake)
+
+	require.Equal(t, []setup.EndpointInfo{{ID: "abcde-hellonode-0-0.stellarbench.workers.dev"}}, config.SubExperiments[0].Endpoints)
+	lines := commandLines(fake)
+	require.Len(t, lines, 2)
+	require.Contains(t, lines[0], "wrangler deploy serverless/cloudflare/hellonode/index.js --name abcde-hellonode-0-0")
+	require.Equal(t, "wrangler delete --name abcde-hellonode-0-0 --force", lines[1])
+}
+
+func TestRemoveAlibabaServices(t *testing.T) {
+	t.Setenv("ALIYUN_ACCOUNT_ID", "1234")
+	fake := &util.FakeRunner{}
+
+	setup.RemoveService(&setup.Configuration{Provider: "aliyun", SubExperiments: make([]setup.SubExperiment, 2)}, "serverless/aliyun/", fake)
+
+	require.Equal(t, []string{
+		"aliyun oss rm --bucket --recursive --force oss://sls-1234-us-west-1",
+		"sls remove",
+		"rm serverless/aliyun/sub-experiment-0/serverless.yml",
+		"sls remove",
+		"rm serverless/aliyun/sub-experiment-1/serverless.yml",
+	}, commandLines(fake))
+}
+
+func TestEndpointIDsFromDeployFixtures(t *testing.T) {
+	fake := &util.FakeRunner{}
+	fake.ReplyFromFile("sls deploy", "fixtures/sls-deploy-azure.txt")
+	require.Equal(t, "sls-seasi-dev-stellar-sub-experiment-1", setup.GetAzureEndpointID(setup.DeployService("azure/", fake)))
+
+	fake.ReplyFromFile("sls deploy", "fixtures/sls-deploy-aliyun.txt")
+	require.Equal(t, "5cfeb440ed6d4ad69ae29d8408aa606e", setup.GetAlibabaEndpointID(setup.DeployService("aliyun/", fake)))
+}
@@ -0,0 +1,90 @@
+package setup
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"stellar/setup"
+	"strings"
+	"testing"
+)
+
+const rawCodePath = "../deployment/raw-code"
+
+// functionSourceExtensions are the files read by the functions at runtime, generated protobuf code aside
+var functionSourceExtensions = map[string]bool{".go": true, ".py": true, ".js": true, ".java": true, ".rb": true, ".rs": true}
+
+// readFunctionSources concatenates the source code of the function in the given directory
+func readFunctionSources(t *testing.T, functionPath string) string {
+	var sources strings.Builder
+	err := filepath.WalkDir(functionPath, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() && entry.Name() == "node_modules" {
+			return filepath.SkipDir
+		}
+		name := entry.Name()
+		if entry.IsDir() || !functionSourceExtensions[filepath.Ext(name)] || strings.HasSuffix(name, "_test.go") ||
+			strings.HasSuffix(name, ".pb.go") || strings.Contains(name, "_pb2") {
+			return nil
+		}
+		contents, err := os.ReadFile(path)
+		sources.Write(contents)
+		return err
+	})
+	require.NoError(t, err)
+	return sources.String()
+}
+
+// TestBundledFunctionsSpinForTheServiceTime makes sure that every bundled function busy-spinning up to an increment
+// limit either receives a calibrated, non-zero limit or understands the time-based spin parameters it is sent instead.
+func TestBundledFunctionsSpinForTheServiceTime(t *testing.T) {
+	type bundledFunction struct{ provider, function, path string }
+	var functions []bundledFunction
+
+	functionPaths, err := filepath.Glob(filepath.Join(rawCodePath, "functions", "*", "*"))
+	require.NoError(t, err)
+	for _, path := range functionPaths {
+		functions = append(functions, bundledFunction{filepath.Base(path), filepath.Base(filepath.Dir(path)), path})
+	}
+	serverlessPaths, err := filepath.Glob(filepath.Join(rawCodePath, "serverless", "*", "*"))
+	require.NoError(t, err)
+	for _, path := range serverlessPaths {
+		functions = append(functions, bundledFunction{filepath.Base(filepath.Dir(path)), filepath.Base(path), path})
+	}
+
+	cache := setup.LoadBusySpinCache(filepath.Join(t.TempDir(), "busy-spin-cache.json"))
+	tested := 0
+	for _, function := range functions {
+		if info, err := os.Stat(function.path); err != nil || !info.IsDir() {
+			continue
+		}
+		sources := readFunctionSources(t, function.path)
+		if !strings.Contains(sources, "IncrementLimit") {
+			continue // the function does not busy-spin
+		}
+
+		t.Run(strings.TrimPrefix(function.path, rawCodePath+"/"), func(t *testing.T) {
+			cache.Store(setup.BusySpinCalibration{Provider: function.provider, Runtime: "calibrated", FunctionMemoryMB: 128, ServiceTime: "1s", IncrementLimit: 1000})
+			config := setup.ExtractConfiguration(writeTestConfiguration(t, fmt.Sprintf(`{
+				"Provider": %q,
+				"Runtime": "calibrated",
+				"SubExperiments": [{"Title": "spin", "Function": %q, "DesiredServiceTimes": ["1s"]}]
+			}`, function.provider, function.function)))
+			setup.FindBusySpinIncrements(&config, cache)
+
+			parameters := config.SubExperiments[0].BusySpinAt(0).QueryParameters()
+			if parameters.Get("ServiceTimeMs") != "" {
+				require.Contains(t, sources, "ServiceTimeMs", "the function only understands increment limits but is sent %s",
+					parameters.Encode())
+				return
+			}
+			require.NotEqual(t, "0", parameters.Get("IncrementLimit"))
+		})
+		tested++
+	}
+	require.NotZero(t, tested)
+}
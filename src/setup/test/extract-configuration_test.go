@@ -0,0 +1,135 @@
+package setup
+
+import (
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"stellar/setup"
+	"testing"
+	"time"
+)
+
+func writeTestConfiguration(t *testing.T, contents string) string {
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(configPath, []byte(contents), 0644))
+	return configPath
+}
+
+func TestExtractConfigurationComparisonDefaults(t *testing.T) {
+	configPath := writeTestConfiguration(t, `{
+		"SubExperiments": [{"Title": "mem128"}, {"Title": "mem512"}],
+		"Comparisons": [
+			{"SubExperiments": ["mem128", "mem512"]},
+			{"Title": "memory", "SubExperiments": ["mem128", "mem512"], "PlotType": "percentile", "Parameter": "IATSeconds", "Percentiles": [90]}
+		]
+	}`)
+
+	config := setup.ExtractConfiguration(configPath)
+
+	require.Equal(t, 0, config.SubExperiments[0].ID)
+	require.Equal(t, 1, config.SubExperiments[1].ID)
+	require.Equal(t, setup.Comparison{
+		Title:          "comparison0",
+		SubExperiments: []string{"mem128", "mem512"},
+		PlotType:       "cdf",
+		Parameter:      "FunctionMemoryMB",
+		Percentiles:    []float64{50, 99},
+	}, config.Comparisons[0])
+	require.Equal(t, setup.Comparison{
+		Title:          "memory",
+		SubExperiments: []string{"mem128", "mem512"},
+		PlotType:       "percentile",
+		Parameter:      "IATSeconds",
+		Percentiles:    []float64{90},
+	}, config.Comparisons[1])
+}
+
+func TestExtractConfigurationPlotOptions(t *testing.T) {
+	configPath := writeTestConfiguration(t, `{
+		"SubExperiments": [
+			{"Title": "default"},
+			{"Title": "paper", "PlotOptions": {"Format": "PDF", "LogX": true, "XMax": 500, "AnnotatedPercentiles": [50, 99]}}
+		]
+	}`)
+
+	config := setup.ExtractConfiguration(configPath)
+
+	require.Equal(t, "png", config.SubExperiments[0].PlotOptions.Format)
+	require.Nil(t, config.SubExperiments[0].PlotOptions.XMax)
+
+	paperOptions := config.SubExperiments[1].PlotOptions
+	require.Equal(t, "pdf", paperOptions.Format)
+	require.True(t, paperOptions.LogX)
+	require.Equal(t, 500., *paperOptions.XMax)
+	require.Equal(t, []float64{50, 99}, paperOptions.AnnotatedPercentiles)
+}
+
+func TestExtractConfigurationWorkload(t *testing.T) {
+	configPath := writeTestConfiguration(t, `{
+		"SubExperiments": [
+			{"Title": "spin"},
+			{"Title": "synthetic", "Function": "syntheticpy", "Workload": {"MemoryMiB": 64, "OutboundCalls": 2, "OutboundURL": "http://10.0.0.1/", "InitBlobMiB": 32}}
+		]
+	}`)
+
+	config := setup.ExtractConfiguration(configPath)
+
+	require.Empty(t, config.SubExperiments[0].Workload.QueryParameters())
+	require.Nil(t, config.SubExperiments[0].Workload.Environment())
+
+	workload := config.SubExperiments[1].Workload
+	require.Equal(t, "MemoryMiB=64&OutboundCalls=2&OutboundURL=http%3A%2F%2F10.0.0.1%2F", workload.QueryParameters().Encode())
+	require.Equal(t, map[string]string{"INIT_BLOB_MIB": "32"}, workload.Environment())
+}
+
+func TestExtractConfigurationInitEnvironment(t *testing.T) {
+	configPath := writeTestConfiguration(t, `{
+		"SubExperiments": [
+			{"Title": "no-init"},
+			{"Title": "init", "DesiredInitTime": "1.5s", "InitMemoryMB": 256, "Workload": {"InitBlobMiB": 8}}
+		]
+	}`)
+
+	config := setup.ExtractConfiguration(configPath)
+
+	require.Nil(t, config.SubExperiments[0].FunctionEnvironment())
+	require.Equal(t, map[string]string{
+		"INIT_TIME_MS":   "1500",
+		"INIT_MEMORY_MB": "256",
+		"INIT_BLOB_MIB":  "8",
+	}, config.SubExperiments[1].FunctionEnvironment())
+}
+
+func TestCheckInitSimulation(t *testing.T) {
+	noInit := setup.SubExperiment{Title: "no-init", Function: "producer-consumer"}
+	require.NoError(t, noInit.CheckInitSimulation("aws", false))
+
+	initTime := setup.SubExperiment{Title: "init", Function: "hellopy", DesiredInitTime: "2s"}
+	require.NoError(t, initTime.CheckInitSimulation("aws", true))
+	require.NoError(t, initTime.CheckInitSimulation("gcr", true))
+	require.Error(t, initTime.CheckInitSimulation("aws", false))
+	require.Error(t, initTime.CheckInitSimulation("cloudflare", true))
+
+	initMemory := setup.SubExperiment{Title: "init", Function: "hellopy-read-random", InitMemoryMB: 64}
+	require.Error(t, initMemory.CheckInitSimulation("aws", true))
+	initMemory.Function = "producer-consumer"
+	require.Error(t, initMemory.CheckInitSimulation("aws", true))
+}
+
+func TestExtractConfigurationSpinMode(t *testing.T) {
+	configPath := writeTestConfiguration(t, `{
+		"SubExperiments": [
+			{"Title": "wall", "DesiredServiceTimes": ["10ms", "1.5s"]},
+			{"Title": "cpu", "DesiredServiceTimes": ["250us"], "SpinMode": "cpu"}
+		]
+	}`)
+
+	config := setup.ExtractConfiguration(configPath)
+	setup.FindBusySpinIncrements(&config, nil)
+
+	require.Equal(t, setup.SpinModeWallClock, config.SubExperiments[0].SpinMode)
+	require.Equal(t, setup.BusySpin{Mode: setup.SpinModeWallClock, Duration: 1500 * time.Millisecond},
+		config.SubExperiments[0].BusySpinAt(5))
+	require.Equal(t, "IncrementLimit=0&ServiceTimeMs=0.25&SpinClock=cpu",
+		config.SubExperiments[1].BusySpinAt(0).QueryParameters().Encode())
+}
@@ -0,0 +1,51 @@
+package setup
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"runtime"
+	"stellar/setup"
+	"testing"
+	"time"
+)
+
+func TestResolveSeed(t *testing.T) {
+	config := setup.Configuration{Seed: 7}
+	setup.ResolveSeed(&config, 0)
+	require.Equal(t, int64(7), config.Seed)
+	require.Equal(t, int64(7), config.FillerSeed)
+
+	setup.ResolveSeed(&config, 42)
+	require.Equal(t, int64(42), config.Seed)
+
+	require.Equal(t, int64(42), config.FillerSeed)
+
+	// without a seed, the fillers do not follow the seed picked from the current time, so that artifacts are reused
+	config = setup.Configuration{}
+	setup.ResolveSeed(&config, 0)
+	require.NotZero(t, config.Seed)
+	require.Equal(t, setup.DefaultFillerSeed, config.FillerSeed)
+}
+
+func TestRunManifest(t *testing.T) {
+	config := setup.ExtractConfiguration(writeTestConfiguration(t, `{"Seed": 42, "SubExperiments": [{"Title": "hellopy"}]}`))
+	setup.ResolveSeed(&config, 0)
+	config.SubExperiments[0].Endpoints = []setup.EndpointInfo{{ID: "z4a0lmtx64"}}
+	outputDirectoryPath := t.TempDir()
+
+	setup.NewRunManifest(config, time.Date(2023, time.May, 4, 12, 0, 0, 0, time.UTC)).Write(outputDirectoryPath)
+
+	contents, err := os.ReadFile(filepath.Join(outputDirectoryPath, "manifest.json"))
+	require.NoError(t, err)
+	var manifest setup.RunManifest
+	require.NoError(t, json.Unmarshal(contents, &manifest))
+
+	require.Equal(t, int64(42), manifest.Seed)
+	require.Equal(t, int64(42), manifest.FillerSeed)
+	require.Equal(t, runtime.Version(), manifest.GoVersion)
+	require.Equal(t, runtime.NumCPU(), manifest.ClientHost.CPUs)
+	require.Equal(t, "python3.9", manifest.Configuration.SubExperiments[0].Runtime)
+	require.Equal(t, []setup.EndpointInfo{{ID: "z4a0lmtx64"}}, manifest.Configuration.SubExperiments[0].Endpoints)
+}
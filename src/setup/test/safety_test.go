@@ -0,0 +1,41 @@
+package setup
+
+import (
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+	"stellar/setup"
+	"testing"
+)
+
+func TestCheckSafetyDecisions(t *testing.T) {
+	configPath := writeTestConfiguration(t, `{
+		"Safety": {"NICContentionBurstSize": 100, "NICContention": "accept", "HistogramFiles": "prompt"},
+		"SubExperiments": [{"Title": "large", "Bursts": 600, "BurstSizes": [50, 200], "Visualization": "histogram"}]
+	}`)
+	config := setup.ExtractConfiguration(configPath)
+	require.Equal(t, 500, config.Safety.HistogramFilesBursts)
+
+	hook := logtest.NewGlobal()
+	defer hook.Reset()
+	setup.CheckSafety(&config, setup.PromptAssumeYes, 1)
+
+	var decisions []map[string]interface{}
+	for _, entry := range hook.AllEntries() {
+		if entry.Message == "Safety decision" {
+			decisions = append(decisions, entry.Data)
+		}
+	}
+	require.Len(t, decisions, 2)
+	require.Equal(t, "nic_contention", decisions[0]["safety_check"])
+	require.Equal(t, 200, decisions[0]["value"])
+	require.Equal(t, "config", decisions[0]["decided_by"])
+	require.Equal(t, "histogram_files", decisions[1]["safety_check"])
+	require.Equal(t, "continue", decisions[1]["decision"])
+	require.Equal(t, "flag", decisions[1]["decided_by"])
+
+	// Split across two client hosts, each burst stays below the NIC contention threshold
+	hook.Reset()
+	setup.CheckSafety(&config, setup.PromptAssumeYes, 2)
+	require.Len(t, hook.AllEntries(), 2)
+	require.Equal(t, "histogram_files", hook.LastEntry().Data["safety_check"])
+}
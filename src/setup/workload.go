@@ -0,0 +1,138 @@
+// MIT License
+//
+// Copyright (c) 2021 Theodor Amariucai and EASE Lab
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package setup
+
+import (
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"net/url"
+	code_generation "stellar/setup/code-generation"
+	"strconv"
+	"time"
+)
+
+// WorkloadProfile configures the behaviour of the synthetic workload functions (e.g., `syntheticpy`). Every field
+// is optional, the zero value leaves the function busy-spinning only.
+type WorkloadProfile struct {
+	// MemoryMiB is the amount of memory each request allocates and touches page by page
+	MemoryMiB int `json:"MemoryMiB"`
+	// DiskWriteMiB is the amount of data each request writes (and syncs) to /tmp
+	DiskWriteMiB int `json:"DiskWriteMiB"`
+	// DiskReadMiB is the amount of data each request reads back from /tmp
+	DiskReadMiB int `json:"DiskReadMiB"`
+	// OutboundCalls is the number of sequential HTTP GET requests each request makes to OutboundURL
+	OutboundCalls int    `json:"OutboundCalls"`
+	OutboundURL   string `json:"OutboundURL"`
+	// InitBlobMiB is the size of the model-like blob loaded once per instance, at initialization
+	InitBlobMiB int `json:"InitBlobMiB"`
+}
+
+// QueryParameters returns the per-request workload parameters sent to the function, leaving out unset fields
+func (w WorkloadProfile) QueryParameters() url.Values {
+	parameters := url.Values{}
+	for name, value := range map[string]int{
+		"MemoryMiB":     w.MemoryMiB,
+		"DiskWriteMiB":  w.DiskWriteMiB,
+		"DiskReadMiB":   w.DiskReadMiB,
+		"OutboundCalls": w.OutboundCalls,
+	} {
+		if value > 0 {
+			parameters.Set(name, strconv.Itoa(value))
+		}
+	}
+	if w.OutboundCalls > 0 {
+		parameters.Set("OutboundURL", w.OutboundURL)
+	}
+	return parameters
+}
+
+// Environment returns the environment variables configuring the function at deployment time
+func (w WorkloadProfile) Environment() map[string]string {
+	if w.InitBlobMiB <= 0 {
+		return nil
+	}
+	return map[string]string{"INIT_BLOB_MIB": strconv.Itoa(w.InitBlobMiB)}
+}
+
+// InitTime parses the time the deployed functions should spend in global initialization, 0 if unset
+func (s *SubExperiment) InitTime() (time.Duration, error) {
+	if s.DesiredInitTime == "" {
+		return 0, nil
+	}
+	initTime, err := time.ParseDuration(s.DesiredInitTime)
+	if err == nil && initTime < 0 {
+		err = fmt.Errorf("init time %s is negative", s.DesiredInitTime)
+	}
+	return initTime, err
+}
+
+// FunctionEnvironment returns the environment variables the deployed functions read during initialization
+func (s *SubExperiment) FunctionEnvironment() map[string]string {
+	environment := s.Workload.Environment()
+	if environment == nil {
+		environment = make(map[string]string)
+	}
+
+	if initTime, err := s.InitTime(); err == nil && initTime > 0 {
+		environment["INIT_TIME_MS"] = strconv.FormatInt(initTime.Milliseconds(), 10)
+	}
+	if s.InitMemoryMB > 0 {
+		environment["INIT_MEMORY_MB"] = strconv.Itoa(s.InitMemoryMB)
+	}
+
+	if len(environment) == 0 {
+		return nil
+	}
+	return environment
+}
+
+// CheckInitSimulation returns an error if the sub-experiment sets an init time or memory its functions would ignore.
+// Only the functions rendered from the templates simulate them, and only when deployed with their environment.
+func (s *SubExperiment) CheckInitSimulation(provider string, serverless bool) error {
+	if initTime, _ := s.InitTime(); initTime == 0 && s.InitMemoryMB == 0 {
+		return nil
+	}
+
+	switch {
+	case !serverless:
+		return fmt.Errorf("sub-experiment %q sets an init time or memory, which only serverless deployments configure",
+			s.Title)
+	case !code_generation.IsGenerated(s.Function, provider):
+		return fmt.Errorf("function %s of sub-experiment %q cannot simulate an init time or memory on %s",
+			s.Function, s.Title, provider)
+	}
+	return nil
+}
+
+// checkWorkload validates the workload profile of the sub-experiment. Outbound calls need an explicit URL, so that
+// requests never call a third-party host whose latency would end up in the measurements.
+func checkWorkload(subExperiment *SubExperiment) {
+	workload := &subExperiment.Workload
+	if workload.MemoryMiB < 0 || workload.DiskWriteMiB < 0 || workload.DiskReadMiB < 0 ||
+		workload.OutboundCalls < 0 || workload.InitBlobMiB < 0 {
+		log.Fatalf("Sub-experiment %q has a negative workload parameter.", subExperiment.Title)
+	}
+	if workload.OutboundCalls > 0 && workload.OutboundURL == "" {
+		log.Fatalf("Sub-experiment %q makes outbound calls but sets no OutboundURL.", subExperiment.Title)
+	}
+}
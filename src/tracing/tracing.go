@@ -0,0 +1,153 @@
+// MIT License
+//
+// Copyright (c) 2021 Theodor Amariucai and EASE Lab
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package tracing exports OpenTelemetry spans of the provisioning steps and benchmark requests, and propagates
+// their W3C trace context to the functions.
+package tracing
+
+import (
+	"context"
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.20.0"
+	"go.opentelemetry.io/otel/trace"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	instrumentationName = "stellar"
+	shutdownTimeout     = 30 * time.Second
+)
+
+// runContext holds the span of the whole run (or worker), the parent of the provisioning steps
+var runContext = context.Background()
+
+func init() {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+}
+
+// Initialize exports the spans to the OTLP/HTTP endpoint URL (e.g., http://localhost:4318) if set, to the JSON file
+// otherwise, and starts the span of the run. Without either, spans are not recorded, nor trace context propagated.
+// The returned function ends the run span and flushes the spans, it is also run if the client exits on a fatal error.
+func Initialize(runName string, otlpEndpoint string, jsonFilePath string) func() {
+	var exporter sdktrace.SpanExporter
+	var jsonFile *os.File
+
+	switch {
+	case otlpEndpoint != "":
+		endpointURL, err := url.Parse(otlpEndpoint)
+		if err != nil || endpointURL.Host == "" {
+			log.Fatalf("Could not parse OTLP endpoint URL %q, expected e.g. http://localhost:4318", otlpEndpoint)
+		}
+		options := []otlptracehttp.Option{otlptracehttp.WithEndpoint(endpointURL.Host)}
+		if endpointURL.Scheme == "http" {
+			options = append(options, otlptracehttp.WithInsecure())
+		}
+		if endpointURL.Path != "" && endpointURL.Path != "/" {
+			options = append(options, otlptracehttp.WithURLPath(endpointURL.Path))
+		}
+
+		exporter, err = otlptracehttp.New(context.Background(), options...)
+		if err != nil {
+			log.Fatalf("Could not create OTLP trace exporter: %s", err.Error())
+		}
+		log.Infof("Exporting traces to OTLP endpoint %s", otlpEndpoint)
+	case jsonFilePath != "":
+		var err error
+		jsonFile, err = os.Create(jsonFilePath)
+		if err != nil {
+			log.Fatalf("Could not create trace file: %s", err.Error())
+		}
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(jsonFile))
+		if err != nil {
+			log.Fatalf("Could not create JSON trace exporter: %s", err.Error())
+		}
+		log.Infof("Writing traces to `%s`", jsonFilePath)
+	default:
+		return func() {}
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(instrumentationName))),
+	)
+	otel.SetTracerProvider(provider)
+
+	var runSpan trace.Span
+	runContext, runSpan = Tracer().Start(context.Background(), runName)
+	log.Infof("Tracing the run with trace ID %s", runSpan.SpanContext().TraceID())
+
+	flush := func() {
+		runSpan.End()
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := provider.Shutdown(ctx); err != nil {
+			log.Errorf("Could not flush the spans: %s", err.Error())
+		}
+		if jsonFile != nil {
+			jsonFile.Close()
+		}
+	}
+	var shutdownOnce sync.Once
+	shutdown := func() {
+		shutdownOnce.Do(flush)
+	}
+	log.RegisterExitHandler(shutdown)
+	return shutdown
+}
+
+// Tracer creates the spans of STeLLAR.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// RunContext returns the context of the run span.
+func RunContext() context.Context {
+	return runContext
+}
+
+// StartStep starts the span of a provisioning step, as a child of the run span.
+func StartStep(name string, attributes ...attribute.KeyValue) trace.Span {
+	_, span := Tracer().Start(runContext, name, trace.WithAttributes(attributes...))
+	return span
+}
+
+// Inject returns the trace context of the span in the given context, to be propagated as headers or metadata.
+func Inject(ctx context.Context) map[string]string {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	return carrier
+}
+
+// Extract returns a context holding the propagated trace context.
+func Extract(ctx context.Context, traceContext map[string]string) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(traceContext))
+}
@@ -0,0 +1,144 @@
+// MIT License
+//
+// Copyright (c) 2021 Theodor Amariucai and EASE Lab
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package util
+
+import (
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// CommandRunner runs the external commands of the deployment layer (e.g., `sls`, `gcloud` or `docker`) and returns
+// their combined output. It is passed to the code running the commands, so that tests can replace it with a FakeRunner.
+type CommandRunner interface {
+	CombinedOutput(cmd *exec.Cmd) ([]byte, error)
+}
+
+// ExecRunner runs the commands on the client host. It is used wherever no runner is given.
+type ExecRunner struct{}
+
+func (ExecRunner) CombinedOutput(cmd *exec.Cmd) ([]byte, error) {
+	// Creating a copy of exec.Cmd as it cannot be reused after calling its Run, Output or CombinedOutput methods
+	copyOfCmd := *cmd
+	return copyOfCmd.CombinedOutput()
+}
+
+// RecordingRunner runs the commands through another runner and saves their outputs in a fixtures directory, one
+// file per command line, so that they can be replayed by a FakeRunner.
+type RecordingRunner struct {
+	Runner                CommandRunner
+	FixturesDirectoryPath string
+}
+
+var nonFileNameCharactersRegex = regexp.MustCompile(`[^a-zA-Z0-9.]+`)
+
+func (r RecordingRunner) CombinedOutput(cmd *exec.Cmd) ([]byte, error) {
+	output, err := r.Runner.CombinedOutput(cmd)
+
+	fixtureName := strings.Trim(nonFileNameCharactersRegex.ReplaceAllString(strings.Join(cmd.Args, " "), "-"), "-")
+	if len(fixtureName) > 100 {
+		fixtureName = fixtureName[:100]
+	}
+	fixturePath := filepath.Join(r.FixturesDirectoryPath, fixtureName+".txt")
+	if writeErr := os.WriteFile(fixturePath, output, 0644); writeErr != nil {
+		log.Errorf("Could not record the output of command %s to %s: %s", cmd.String(), fixturePath, writeErr.Error())
+	}
+
+	return output, err
+}
+
+// FakeRunner records the commands it is asked to run without running them, replying with the outputs registered for
+// them. Commands without a registered reply succeed with an empty output. It is safe for concurrent use.
+type FakeRunner struct {
+	mu       sync.Mutex
+	commands []RecordedCommand
+	replies  []fakeReply
+}
+
+// RecordedCommand is a command run through a FakeRunner.
+type RecordedCommand struct {
+	Args []string
+	Dir  string
+}
+
+func (c RecordedCommand) String() string {
+	return strings.Join(c.Args, " ")
+}
+
+type fakeReply struct {
+	prefix string
+	output string
+	err    error
+}
+
+// Reply registers the output of the commands whose line starts with the given prefix, e.g., `sls deploy`. The
+// replies registered last take precedence.
+func (f *FakeRunner) Reply(prefix string, output string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.replies = append(f.replies, fakeReply{prefix: prefix, output: output})
+}
+
+// ReplyFromFile registers the contents of a fixture file as the output of the commands starting with the given prefix.
+func (f *FakeRunner) ReplyFromFile(prefix string, fixturePath string) {
+	output, err := os.ReadFile(fixturePath)
+	if err != nil {
+		log.Fatalf("Could not read command output fixture %s: %s", fixturePath, err.Error())
+	}
+	f.Reply(prefix, string(output))
+}
+
+// Fail makes the commands starting with the given prefix fail with the given output.
+func (f *FakeRunner) Fail(prefix string, output string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.replies = append(f.replies, fakeReply{prefix: prefix, output: output, err: fmt.Errorf("fake failure of %q", prefix)})
+}
+
+// Commands returns the commands run so far, in order.
+func (f *FakeRunner) Commands() []RecordedCommand {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]RecordedCommand(nil), f.commands...)
+}
+
+func (f *FakeRunner) CombinedOutput(cmd *exec.Cmd) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	command := RecordedCommand{Args: cmd.Args, Dir: cmd.Dir}
+	f.commands = append(f.commands, command)
+
+	line := command.String()
+	for i := len(f.replies) - 1; i >= 0; i-- {
+		if strings.HasPrefix(line, f.replies[i].prefix) {
+			return []byte(f.replies[i].output), f.replies[i].err
+		}
+	}
+	return nil, nil
+}
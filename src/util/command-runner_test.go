@@ -0,0 +1,58 @@
+// MIT License
+//
+// Copyright (c) 2021 Theodor Amariucai and EASE Lab
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package util
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeRunnerReplaysLatestMatchingReply(t *testing.T) {
+	t.Parallel()
+	fake := &FakeRunner{}
+	fake.Reply("sls", "generic")
+	fake.Reply("sls deploy", "deployed")
+
+	require.Equal(t, "deployed", RunCommandAndLog(exec.Command("sls", "deploy"), fake))
+	require.Equal(t, "generic", RunCommandAndLog(exec.Command("sls", "remove"), fake))
+	require.Equal(t, "", RunCommandAndLog(exec.Command("rm", "serverless.yml"), fake))
+	require.Len(t, fake.Commands(), 3)
+}
+
+func TestRecordingRunnerSavesFixtures(t *testing.T) {
+	t.Parallel()
+	fixturesDirectoryPath := t.TempDir()
+	fake := &FakeRunner{}
+	fake.Reply("gcloud run deploy", "Service URL: https://hello.a.run.app")
+	recorder := RecordingRunner{Runner: fake, FixturesDirectoryPath: fixturesDirectoryPath}
+
+	RunCommandAndLog(exec.Command("gcloud", "run", "deploy", "hello", "--region", "us-west1"), recorder)
+
+	fixture, err := os.ReadFile(filepath.Join(fixturesDirectoryPath, "gcloud-run-deploy-hello-region-us-west1.txt"))
+	require.NoError(t, err)
+	require.Equal(t, "Service URL: https://hello.a.run.app", string(fixture))
+}
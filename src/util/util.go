@@ -29,8 +29,21 @@ import (
 	"math/rand"
 	"os"
 	"os/exec"
+	"sync"
+	"time"
 )
 
+// randomNames generates the random letters of service names, see SeedRandomNames
+var randomNames = rand.New(rand.NewSource(time.Now().UnixNano()))
+var randomNamesMutex sync.Mutex
+
+// SeedRandomNames seeds the random letters of service names, so that a run with the same seed deploys the same services
+func SeedRandomNames(seed int64) {
+	randomNamesMutex.Lock()
+	defer randomNamesMutex.Unlock()
+	randomNames = rand.New(rand.NewSource(seed))
+}
+
 // ReadFile reads a file and returns the object
 func ReadFile(path string) *os.File {
 	log.Debugf("Reading file from `%s`", path)
@@ -59,14 +72,26 @@ func IntegerMin(x, y int) int {
 	return y
 }
 
-// RunCommandAndLog runs a command in the terminal, logs the result and returns it
-func RunCommandAndLog(cmd *exec.Cmd) string {
-	return RunCommandAndLogWithRetries(cmd, 1)
+// IntegerMax returns the maximum of two integers
+func IntegerMax(x, y int) int {
+	if x > y {
+		return x
+	}
+	return y
 }
 
-// RunCommandAndLogWithRetries runs a command in the terminal, logs the result and returns it,
-// while retrying the same command up to a specified number of attempts if it fails
-func RunCommandAndLogWithRetries(cmd *exec.Cmd, maxAttempts int) string {
+// RunCommandAndLog runs a command in the terminal through the runner, logs the result and returns it
+func RunCommandAndLog(cmd *exec.Cmd, runner CommandRunner) string {
+	return RunCommandAndLogWithRetries(cmd, 1, runner)
+}
+
+// RunCommandAndLogWithRetries runs a command in the terminal through the runner, or an ExecRunner if nil, logs the
+// result and returns it, while retrying the same command up to a specified number of attempts if it fails
+func RunCommandAndLogWithRetries(cmd *exec.Cmd, maxAttempts int, runner CommandRunner) string {
+	if runner == nil {
+		runner = ExecRunner{}
+	}
+
 	log.Infof("Running the command %s with a maximum of %d retries.", cmd.String(), maxAttempts)
 
 	var stdoutStderr []byte
@@ -75,10 +100,7 @@ func RunCommandAndLogWithRetries(cmd *exec.Cmd, maxAttempts int) string {
 	for i := 1; i <= maxAttempts; i++ {
 		log.Infof("Attempt %d at running command %s", i, cmd.String())
 
-		// Creating a copy of exec.Cmd as it cannot be reused after calling its Run, Output or CombinedOutput methods
-		copyOfCmd := *cmd
-
-		stdoutStderr, err = copyOfCmd.CombinedOutput()
+		stdoutStderr, err = runner.CombinedOutput(cmd)
 		log.Infof("Command combined output: %s\n", stdoutStderr)
 
 		if err == nil {
@@ -103,9 +125,11 @@ func StringContains(s []string, str string) bool {
 
 func GenerateRandLowercaseLetters(length int) string {
 	const lowercaseAlphabet = "abcdefghijklmnopqrstuvwxyz"
+	randomNamesMutex.Lock()
+	defer randomNamesMutex.Unlock()
 	b := make([]byte, length)
 	for i := range b {
-		b[i] = lowercaseAlphabet[rand.Intn(len(lowercaseAlphabet))]
+		b[i] = lowercaseAlphabet[randomNames.Intn(len(lowercaseAlphabet))]
 	}
 	return string(b)
 }
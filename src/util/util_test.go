@@ -40,3 +40,13 @@ func TestMBToBytes(t *testing.T) {
 	res = MebibyteToBytes(0.)
 	require.Equal(t, int64(0), res)
 }
+
+func TestSeedRandomNames(t *testing.T) {
+	SeedRandomNames(42)
+	firstNames := []string{GenerateRandLowercaseLetters(5), GenerateRandLowercaseLetters(5)}
+	SeedRandomNames(42)
+	secondNames := []string{GenerateRandLowercaseLetters(5), GenerateRandLowercaseLetters(5)}
+
+	require.Equal(t, firstNames, secondNames)
+	require.NotEqual(t, firstNames[0], firstNames[1])
+}